@@ -30,6 +30,7 @@ import (
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/binary"
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/compose"
 	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/kind"
+	_ "sigs.k8s.io/kwok/pkg/kwokctl/runtime/plugin"
 )
 
 func main() {
@@ -157,6 +157,19 @@ type KwokConfigurationOptions struct {
 	// EnablePodsOnNodeSyncListPager enables pager list for workers to sync pods on nodes.
 	// +default=true
 	EnablePodsOnNodeSyncListPager *bool `json:"enablePodsOnNodeSyncListPager"`
+
+	// RandomSeed is the seed of the random number generators used by stages.
+	// If it is not 0, the random numbers are derived from it and the UID of each resource,
+	// so the stage weights, the delay jitter and the Rand() in CEL expressions are reproducible.
+	// is the default value for flag --random-seed
+	RandomSeed int64 `json:"randomSeed,omitempty"`
+
+	// TimeScale is the factor by which the time of the controller runs faster than the real time.
+	// The stage delays, the jitter and the renewal interval of node leases are divided by it,
+	// and the SinceSecond in CEL expressions is scaled accordingly, while Now keeps the real time.
+	// is the default value for flag --time-scale
+	// +default=1
+	TimeScale *float64 `json:"timeScale,omitempty"`
 }
 
 // TracingConfiguration provides versioned configuration for OpenTelemetry tracing clients.
@@ -53,6 +53,20 @@ type KwokctlConfiguration struct {
 type KwokctlConfigurationStatus struct {
 	// Version is the version of the kwokctl.
 	Version string `json:"version,omitempty"`
+	// Restarts is the restarts of the components by kwokctl supervise.
+	Restarts []ComponentRestart `json:"restarts,omitempty"`
+}
+
+// ComponentRestart holds information about the restarts of a component.
+type ComponentRestart struct {
+	// Name is the name of the component.
+	Name string `json:"name"`
+	// Count is the number of restarts.
+	Count int32 `json:"count"`
+	// Reason is the reason of the last restart.
+	Reason string `json:"reason,omitempty"`
+	// LastRestartTime is the time of the last restart.
+	LastRestartTime metav1.Time `json:"lastRestartTime,omitempty"`
 }
 
 // ExtraArgs holds information about the extra args.
@@ -91,6 +105,19 @@ type KwokctlConfigurationOptions struct {
 	// is the default value for flag --kube-apiserver-insecure-port and env KWOK_KUBE_APISERVER_INSECURE_PORT
 	KubeApiserverInsecurePort uint32 `json:"kubeApiserverInsecurePort,omitempty"`
 
+	// KubeApiserverReplicas is the number of kube-apiserver replicas.
+	// When it is more than one, a load balancer is exposed on KubeApiserverPort instead,
+	// only available for binary and docker/podman/nerdctl runtime.
+	// is the default value for flag --kube-apiserver-replicas and env KWOK_KUBE_APISERVER_REPLICAS
+	// +default=1
+	KubeApiserverReplicas uint32 `json:"kubeApiserverReplicas,omitempty"`
+
+	// EtcdReplicas is the number of etcd members.
+	// only available for binary and docker/podman/nerdctl runtime.
+	// is the default value for flag --etcd-replicas and env KWOK_ETCD_REPLICAS
+	// +default=1
+	EtcdReplicas uint32 `json:"etcdReplicas,omitempty"`
+
 	// InsecureKubeconfig is the flag to use insecure kubeconfig.
 	// only available when KubeApiserverInsecurePort is set.
 	InsecureKubeconfig bool `json:"insecureKubeconfig,omitempty"`
@@ -229,6 +256,10 @@ type KwokctlConfigurationOptions struct {
 	// is the default value for flag --kube-apiserver-image and env KWOK_KUBE_APISERVER_IMAGE
 	KubeApiserverImage string `json:"kubeApiserverImage,omitempty"`
 
+	// KubeApiserverLoadBalancerImage is the image of the load balancer in front of kube-apiserver replicas.
+	// is the default value for env KWOK_KUBE_APISERVER_LOAD_BALANCER_IMAGE
+	KubeApiserverLoadBalancerImage string `json:"kubeApiserverLoadBalancerImage,omitempty"`
+
 	// KubeControllerManagerImage is the image of kube-controller-manager.
 	// is the default value for flag --kube-controller-manager-image and env KWOK_KUBE_CONTROLLER_MANAGER_IMAGE
 	KubeControllerManagerImage string `json:"kubeControllerManagerImage,omitempty"`
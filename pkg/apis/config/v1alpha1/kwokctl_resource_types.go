@@ -40,4 +40,18 @@ type KwokctlResource struct {
 	Parameters json.RawMessage `json:"parameters,omitempty"`
 	// Template is the template for the kwokctl resource configuration.
 	Template string `json:"template,omitempty"`
+	// Children is the list of child templates created for each replica,
+	// the children are owned by the replica and deleted along with it.
+	Children []KwokctlResourceChild `json:"children,omitempty"`
+}
+
+// KwokctlResourceChild provides the child template of a kwokctl resource.
+type KwokctlResourceChild struct {
+	// Name is the name of the child, used as the name prefix of the child objects after the parent name.
+	Name string `json:"name"`
+	// Replicas is the number of the child objects for each parent.
+	Replicas int `json:"replicas"`
+	// Template is the template for the child objects,
+	// the parent object is available by Parent and the index of the child by ChildIndex.
+	Template string `json:"template"`
 }
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ScenarioKind is the kind of the scenario.
+	ScenarioKind = "Scenario"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Scenario provides an ordered list of steps to be run against a cluster by kwokctl.
+type Scenario struct {
+	//+k8s:conversion-gen=false
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Steps is the list of steps to be run in order.
+	Steps []ScenarioStep `json:"steps"`
+}
+
+// ScenarioStep is a step of the scenario, only one of the actions can be set.
+type ScenarioStep struct {
+	// Name is the name of the step, used in the report.
+	Name string `json:"name"`
+	// Scale scales a KwokctlResource.
+	Scale *ScenarioScale `json:"scale,omitempty"`
+	// Apply applies manifests to the cluster.
+	Apply *ScenarioApply `json:"apply,omitempty"`
+	// Wait waits for a condition of resources, or for a duration.
+	Wait *ScenarioWait `json:"wait,omitempty"`
+	// Assert asserts the count of resources.
+	Assert *ScenarioAssert `json:"assert,omitempty"`
+	// Snapshot saves a snapshot of the cluster.
+	Snapshot *ScenarioSnapshot `json:"snapshot,omitempty"`
+}
+
+// ScenarioScale scales a KwokctlResource like kwokctl scale.
+type ScenarioScale struct {
+	// Resource is the name of the KwokctlResource, the default pod and node are also available.
+	Resource string `json:"resource"`
+	// Name is the name prefix of the resources, defaults to the resource.
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the resources.
+	Namespace string `json:"namespace,omitempty"`
+	// Replicas is the number of the resources.
+	Replicas uint64 `json:"replicas"`
+	// SerialLength is the length of the serial number.
+	// +default=6
+	SerialLength int `json:"serialLength,omitempty"`
+	// Params is the parameters to update, in the form of key=value.
+	Params []string `json:"params,omitempty"`
+}
+
+// ScenarioApply applies manifests like kubectl apply.
+type ScenarioApply struct {
+	// Path is the path of the manifests file or directory.
+	Path string `json:"path,omitempty"`
+	// Manifests is the inline manifests.
+	Manifests string `json:"manifests,omitempty"`
+}
+
+// ScenarioWait waits for a condition of resources like kubectl wait, or for a duration.
+type ScenarioWait struct {
+	// Resource is the type of the resources, if it is empty, just wait for the timeout.
+	Resource string `json:"resource,omitempty"`
+	// Namespace is the namespace of the resources, empty means all namespaces.
+	Namespace string `json:"namespace,omitempty"`
+	// Selector is the label selector of the resources.
+	Selector string `json:"selector,omitempty"`
+	// For is the condition to wait for, same as kubectl wait --for.
+	For string `json:"for,omitempty"`
+	// TimeoutMilliseconds is the timeout of the waiting, or the duration to wait if resource is empty.
+	TimeoutMilliseconds int64 `json:"timeoutMilliseconds,omitempty"`
+}
+
+// ScenarioAssert asserts the count of resources.
+type ScenarioAssert struct {
+	// Resource is the type of the resources.
+	Resource string `json:"resource"`
+	// Namespace is the namespace of the resources, empty means all namespaces.
+	Namespace string `json:"namespace,omitempty"`
+	// Selector is the label selector of the resources.
+	Selector string `json:"selector,omitempty"`
+	// FieldSelector is the field selector of the resources.
+	FieldSelector string `json:"fieldSelector,omitempty"`
+	// Count is the expected count of the resources.
+	Count int `json:"count"`
+}
+
+// ScenarioSnapshot saves a snapshot of the cluster like kwokctl snapshot save.
+type ScenarioSnapshot struct {
+	// Path is the path of the snapshot.
+	Path string `json:"path"`
+	// Format is the format of the snapshot file (etcd, k8s).
+	// +default="etcd"
+	Format string `json:"format,omitempty"`
+	// Filters is the resources to save, only support for k8s format.
+	Filters []string `json:"filters,omitempty"`
+}
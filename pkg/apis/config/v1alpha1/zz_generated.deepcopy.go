@@ -134,6 +134,23 @@ func (in *ComponentPatches) DeepCopy() *ComponentPatches {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentRestart) DeepCopyInto(out *ComponentRestart) {
+	*out = *in
+	in.LastRestartTime.DeepCopyInto(&out.LastRestartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentRestart.
+func (in *ComponentRestart) DeepCopy() *ComponentRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Env) DeepCopyInto(out *Env) {
 	*out = *in
@@ -232,6 +249,11 @@ func (in *KwokConfigurationOptions) DeepCopyInto(out *KwokConfigurationOptions)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TimeScale != nil {
+		in, out := &in.TimeScale, &out.TimeScale
+		*out = new(float64)
+		**out = **in
+	}
 	return
 }
 
@@ -265,7 +287,7 @@ func (in *KwokctlConfiguration) DeepCopyInto(out *KwokctlConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -381,6 +403,13 @@ func (in *KwokctlConfigurationOptions) DeepCopy() *KwokctlConfigurationOptions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KwokctlConfigurationStatus) DeepCopyInto(out *KwokctlConfigurationStatus) {
 	*out = *in
+	if in.Restarts != nil {
+		in, out := &in.Restarts, &out.Restarts
+		*out = make([]ComponentRestart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -404,6 +433,11 @@ func (in *KwokctlResource) DeepCopyInto(out *KwokctlResource) {
 		*out = make(json.RawMessage, len(*in))
 		copy(*out, *in)
 	}
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]KwokctlResourceChild, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -425,6 +459,22 @@ func (in *KwokctlResource) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KwokctlResourceChild) DeepCopyInto(out *KwokctlResourceChild) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KwokctlResourceChild.
+func (in *KwokctlResourceChild) DeepCopy() *KwokctlResourceChild {
+	if in == nil {
+		return nil
+	}
+	out := new(KwokctlResourceChild)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Port) DeepCopyInto(out *Port) {
 	*out = *in
@@ -441,6 +491,170 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scenario) DeepCopyInto(out *Scenario) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ScenarioStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scenario.
+func (in *Scenario) DeepCopy() *Scenario {
+	if in == nil {
+		return nil
+	}
+	out := new(Scenario)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Scenario) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioApply) DeepCopyInto(out *ScenarioApply) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioApply.
+func (in *ScenarioApply) DeepCopy() *ScenarioApply {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioApply)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioAssert) DeepCopyInto(out *ScenarioAssert) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioAssert.
+func (in *ScenarioAssert) DeepCopy() *ScenarioAssert {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioAssert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioScale) DeepCopyInto(out *ScenarioScale) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioScale.
+func (in *ScenarioScale) DeepCopy() *ScenarioScale {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioScale)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioSnapshot) DeepCopyInto(out *ScenarioSnapshot) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioSnapshot.
+func (in *ScenarioSnapshot) DeepCopy() *ScenarioSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioStep) DeepCopyInto(out *ScenarioStep) {
+	*out = *in
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		*out = new(ScenarioScale)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Apply != nil {
+		in, out := &in.Apply, &out.Apply
+		*out = new(ScenarioApply)
+		**out = **in
+	}
+	if in.Wait != nil {
+		in, out := &in.Wait, &out.Wait
+		*out = new(ScenarioWait)
+		**out = **in
+	}
+	if in.Assert != nil {
+		in, out := &in.Assert, &out.Assert
+		*out = new(ScenarioAssert)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(ScenarioSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioStep.
+func (in *ScenarioStep) DeepCopy() *ScenarioStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioWait) DeepCopyInto(out *ScenarioWait) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioWait.
+func (in *ScenarioWait) DeepCopy() *ScenarioWait {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioWait)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracingConfiguration) DeepCopyInto(out *TracingConfiguration) {
 	*out = *in
@@ -31,6 +31,7 @@ import (
 func RegisterDefaults(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&KwokConfiguration{}, func(obj interface{}) { SetObjectDefaults_KwokConfiguration(obj.(*KwokConfiguration)) })
 	scheme.AddTypeDefaultingFunc(&KwokctlConfiguration{}, func(obj interface{}) { SetObjectDefaults_KwokctlConfiguration(obj.(*KwokctlConfiguration)) })
+	scheme.AddTypeDefaultingFunc(&Scenario{}, func(obj interface{}) { SetObjectDefaults_Scenario(obj.(*Scenario)) })
 	return nil
 }
 
@@ -74,9 +75,19 @@ func SetObjectDefaults_KwokConfiguration(in *KwokConfiguration) {
 		var ptrVar1 bool = true
 		in.Options.EnablePodsOnNodeSyncListPager = &ptrVar1
 	}
+	if in.Options.TimeScale == nil {
+		var ptrVar1 float64 = 1
+		in.Options.TimeScale = &ptrVar1
+	}
 }
 
 func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
+	if in.Options.KubeApiserverReplicas == 0 {
+		in.Options.KubeApiserverReplicas = 1
+	}
+	if in.Options.EtcdReplicas == 0 {
+		in.Options.EtcdReplicas = 1
+	}
 	if in.Options.QuietPull == nil {
 		var ptrVar1 bool = false
 		in.Options.QuietPull = &ptrVar1
@@ -132,3 +143,19 @@ func SetObjectDefaults_KwokctlConfiguration(in *KwokctlConfiguration) {
 		}
 	}
 }
+
+func SetObjectDefaults_Scenario(in *Scenario) {
+	for i := range in.Steps {
+		a := &in.Steps[i]
+		if a.Scale != nil {
+			if a.Scale.SerialLength == 0 {
+				a.Scale.SerialLength = 6
+			}
+		}
+		if a.Snapshot != nil {
+			if a.Snapshot.Format == "" {
+				a.Snapshot.Format = "etcd"
+			}
+		}
+	}
+}
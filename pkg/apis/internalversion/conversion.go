@@ -68,6 +68,28 @@ func ConvertToInternalKwokctlResource(in *configv1alpha1.KwokctlResource) (*Kwok
 	return &out, nil
 }
 
+// ConvertToV1alpha1Scenario converts an internal version Scenario to a v1alpha1.Scenario.
+func ConvertToV1alpha1Scenario(in *Scenario) (*configv1alpha1.Scenario, error) {
+	var out configv1alpha1.Scenario
+	out.APIVersion = configv1alpha1.GroupVersion.String()
+	out.Kind = configv1alpha1.ScenarioKind
+	err := Convert_internalversion_Scenario_To_v1alpha1_Scenario(in, &out, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConvertToInternalScenario converts a v1alpha1.Scenario to an internal version.
+func ConvertToInternalScenario(in *configv1alpha1.Scenario) (*Scenario, error) {
+	var out Scenario
+	err := Convert_v1alpha1_Scenario_To_internalversion_Scenario(in, &out, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // ConvertToV1alpha1KwokConfiguration converts an internal version KwokConfiguration to a v1alpha1.KwokConfiguration.
 func ConvertToV1alpha1KwokConfiguration(in *KwokConfiguration) (*configv1alpha1.KwokConfiguration, error) {
 	var out configv1alpha1.KwokConfiguration
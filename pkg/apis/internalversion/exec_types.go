@@ -42,6 +42,8 @@ type ExecTarget struct {
 	Containers []string
 	// Local holds information how to exec to a local target.
 	Local *ExecTargetLocal
+	// Script holds canned responses to the commands, nothing is run on the host.
+	Script *ExecTargetScript
 }
 
 // ExecTargetLocal holds information how to exec to a local target.
@@ -54,6 +56,38 @@ type ExecTargetLocal struct {
 	SecurityContext *SecurityContext
 }
 
+// ExecTargetScript holds canned responses to the commands.
+type ExecTargetScript struct {
+	// Rules is a list of responses, the first rule that matches the command is used.
+	Rules []ExecScriptRule
+	// Default is the response to the commands that no rule matches.
+	Default *ExecScriptResponse
+}
+
+// ExecScriptRule matches a command to a response.
+type ExecScriptRule struct {
+	// Command matches the command with exactly these arguments.
+	Command []string
+	// Prefix matches the commands that start with these arguments.
+	Prefix []string
+	// Regex matches the command with its arguments joined by spaces.
+	Regex string
+	// Response is the response to the matched command.
+	Response ExecScriptResponse
+}
+
+// ExecScriptResponse is a canned response to a command.
+type ExecScriptResponse struct {
+	// Stdout is written to the standard output.
+	Stdout string
+	// Stderr is written to the standard error.
+	Stderr string
+	// ExitCode is the exit code of the command.
+	ExitCode int32
+	// DelayMilliseconds is the time to wait before responding.
+	DelayMilliseconds *int64
+}
+
 // EnvVar represents an environment variable present in a Container.
 type EnvVar struct {
 	// Name of the environment variable.
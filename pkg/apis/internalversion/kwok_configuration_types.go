@@ -108,6 +108,12 @@ type KwokConfigurationOptions struct {
 
 	// EnablePodsOnNodeSyncListPager enables pager list for workers to sync pods on nodes.
 	EnablePodsOnNodeSyncListPager bool
+
+	// RandomSeed is the seed of the random number generators used by stages.
+	RandomSeed int64
+
+	// TimeScale is the factor by which the time of the controller runs faster than the real time.
+	TimeScale float64
 }
 
 // TracingConfiguration provides versioned configuration for OpenTelemetry tracing clients.
@@ -39,6 +39,20 @@ type KwokctlConfiguration struct {
 type KwokctlConfigurationStatus struct {
 	// Version is the version of the kwokctl.
 	Version string
+	// Restarts is the restarts of the components by kwokctl supervise.
+	Restarts []ComponentRestart
+}
+
+// ComponentRestart holds information about the restarts of a component.
+type ComponentRestart struct {
+	// Name is the name of the component.
+	Name string
+	// Count is the number of restarts.
+	Count int32
+	// Reason is the reason of the last restart.
+	Reason string
+	// LastRestartTime is the time of the last restart.
+	LastRestartTime metav1.Time
 }
 
 // ExtraArgs holds information about the extra args.
@@ -74,6 +88,12 @@ type KwokctlConfigurationOptions struct {
 	// KubeApiserverInsecurePort is the port to expose kubectl proxy.
 	KubeApiserverInsecurePort uint32
 
+	// KubeApiserverReplicas is the number of kube-apiserver replicas.
+	KubeApiserverReplicas uint32
+
+	// EtcdReplicas is the number of etcd members.
+	EtcdReplicas uint32
+
 	// InsecureKubeconfig is the flag to use insecure kubeconfig.
 	// only available when KubeApiserverInsecurePort is set.
 	InsecureKubeconfig bool
@@ -159,6 +179,9 @@ type KwokctlConfigurationOptions struct {
 	// KubeApiserverImage is the image of kube-apiserver.
 	KubeApiserverImage string
 
+	// KubeApiserverLoadBalancerImage is the image of the load balancer in front of kube-apiserver replicas.
+	KubeApiserverLoadBalancerImage string
+
 	// KubeControllerManagerImage is the image of kube-controller-manager.
 	KubeControllerManagerImage string
 
@@ -31,4 +31,16 @@ type KwokctlResource struct {
 	Parameters json.RawMessage
 	// Template is the template for the kwokctl resource configuration.
 	Template string
+	// Children is the list of child templates created for each replica.
+	Children []KwokctlResourceChild
+}
+
+// KwokctlResourceChild provides the child template of a kwokctl resource.
+type KwokctlResourceChild struct {
+	// Name is the name of the child.
+	Name string
+	// Replicas is the number of the child objects for each parent.
+	Replicas int
+	// Template is the template for the child objects.
+	Template string
 }
@@ -45,4 +45,32 @@ type Log struct {
 	PreviousLogsFile string
 	// Follow up if true
 	Follow bool
+	// Generator generates synthetic logs instead of reading the LogsFile.
+	Generator *LogGenerator
 }
+
+// LogGenerator holds information how to generate logs.
+// Only one of Template and Expression should be set.
+type LogGenerator struct {
+	// Template is the go template to render each line with the pod.
+	Template string
+	// Expression is the CEL expression to evaluate each line, it must return a string.
+	Expression string
+	// LinesPerSecond is the number of lines generated per second.
+	LinesPerSecond *float64
+	// Format is the format of the lines.
+	Format LogFormat
+	// Seed is the seed of the random numbers,
+	// the same lines are generated for a container with the same seed.
+	Seed *int64
+}
+
+// LogFormat is the format of the generated logs.
+type LogFormat string
+
+const (
+	// LogFormatPlain writes the lines as they are.
+	LogFormatPlain LogFormat = "plain"
+	// LogFormatJSON writes the lines as JSON objects with the time and the message.
+	LogFormatJSON LogFormat = "json"
+)
@@ -45,6 +45,59 @@ type Forward struct {
 	// Command is the command to run to forward with stdin/stdout.
 	// if set, Target will be ignored.
 	Command []string
+	// Responder responds to the connections in the server without forwarding them.
+	// if set, Target and Command will be ignored.
+	Responder *ForwardResponder
+}
+
+// ForwardResponder holds information how to respond to the connections.
+// Only one of HTTP and TCP should be set.
+type ForwardResponder struct {
+	// HTTP serves the connections as a static HTTP server.
+	HTTP *ForwardHTTPResponder
+	// TCP writes a banner to the connections and echoes them.
+	TCP *ForwardTCPResponder
+}
+
+// ForwardHTTPResponder holds the routes of a static HTTP server.
+type ForwardHTTPResponder struct {
+	// Routes is a list of routes, the first route that matches the request is used.
+	// if no route matches, the response is 404 Not Found.
+	Routes []ForwardHTTPRoute
+}
+
+// ForwardHTTPRoute holds the response to the requests that match the route.
+type ForwardHTTPRoute struct {
+	// Method matches the method of the request.
+	// if not set, all methods will be matched.
+	Method string
+	// Path matches the path of the request, a path ending with "/" matches all paths with the prefix.
+	// if not set, all paths will be matched.
+	Path string
+	// StatusCode is the status code of the response.
+	StatusCode int32
+	// Headers is a list of headers of the response.
+	Headers []ForwardHTTPHeader
+	// Body is the body of the response, it is rendered as a go template with the pod.
+	Body string
+}
+
+// ForwardHTTPHeader is a header of the HTTP response.
+type ForwardHTTPHeader struct {
+	// Name is the name of the header.
+	Name string
+	// Value is the value of the header.
+	Value string
+}
+
+// ForwardTCPResponder holds information how to respond to the TCP connections.
+type ForwardTCPResponder struct {
+	// Banner is written to the connection when it is accepted,
+	// it is rendered as a go template with the pod.
+	Banner string
+	// Echo writes back the data received from the connection if true,
+	// otherwise the connection is closed after the banner is written.
+	Echo bool
 }
 
 // ForwardTarget holds information how to forward to a target.
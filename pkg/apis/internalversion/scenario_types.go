@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalversion
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Scenario provides an ordered list of steps to be run against a cluster by kwokctl.
+type Scenario struct {
+	// Standard list metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ObjectMeta
+	// Steps is the list of steps to be run in order.
+	Steps []ScenarioStep
+}
+
+// ScenarioStep is a step of the scenario, only one of the actions can be set.
+type ScenarioStep struct {
+	// Name is the name of the step, used in the report.
+	Name string
+	// Scale scales a KwokctlResource.
+	Scale *ScenarioScale
+	// Apply applies manifests to the cluster.
+	Apply *ScenarioApply
+	// Wait waits for a condition of resources, or for a duration.
+	Wait *ScenarioWait
+	// Assert asserts the count of resources.
+	Assert *ScenarioAssert
+	// Snapshot saves a snapshot of the cluster.
+	Snapshot *ScenarioSnapshot
+}
+
+// ScenarioScale scales a KwokctlResource like kwokctl scale.
+type ScenarioScale struct {
+	// Resource is the name of the KwokctlResource, the default pod and node are also available.
+	Resource string
+	// Name is the name prefix of the resources, defaults to the resource.
+	Name string
+	// Namespace is the namespace of the resources.
+	Namespace string
+	// Replicas is the number of the resources.
+	Replicas uint64
+	// SerialLength is the length of the serial number.
+	SerialLength int
+	// Params is the parameters to update, in the form of key=value.
+	Params []string
+}
+
+// ScenarioApply applies manifests like kubectl apply.
+type ScenarioApply struct {
+	// Path is the path of the manifests file or directory.
+	Path string
+	// Manifests is the inline manifests.
+	Manifests string
+}
+
+// ScenarioWait waits for a condition of resources like kubectl wait, or for a duration.
+type ScenarioWait struct {
+	// Resource is the type of the resources, if it is empty, just wait for the timeout.
+	Resource string
+	// Namespace is the namespace of the resources, empty means all namespaces.
+	Namespace string
+	// Selector is the label selector of the resources.
+	Selector string
+	// For is the condition to wait for, same as kubectl wait --for.
+	For string
+	// TimeoutMilliseconds is the timeout of the waiting, or the duration to wait if resource is empty.
+	TimeoutMilliseconds int64
+}
+
+// ScenarioAssert asserts the count of resources.
+type ScenarioAssert struct {
+	// Resource is the type of the resources.
+	Resource string
+	// Namespace is the namespace of the resources, empty means all namespaces.
+	Namespace string
+	// Selector is the label selector of the resources.
+	Selector string
+	// FieldSelector is the field selector of the resources.
+	FieldSelector string
+	// Count is the expected count of the resources.
+	Count int
+}
+
+// ScenarioSnapshot saves a snapshot of the cluster like kwokctl snapshot save.
+type ScenarioSnapshot struct {
+	// Path is the path of the snapshot.
+	Path string
+	// Format is the format of the snapshot file (etcd, k8s).
+	Format string
+	// Filters is the resources to save, only support for k8s format.
+	Filters []string
+}
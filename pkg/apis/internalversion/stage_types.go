@@ -48,6 +48,8 @@ type StageSpec struct {
 	Next StageNext
 	// ImmediateNextStage means that the next stage of matching is performed immediately, without waiting for the Apiserver to push.
 	ImmediateNextStage bool
+	// RetryPolicy describes how to retry when applying the stage fails.
+	RetryPolicy *StageRetryPolicy
 }
 
 // StageResourceRef specifies the kind and version of the resource.
@@ -77,6 +79,40 @@ type StageDelay struct {
 	JitterDurationFrom *ExpressionFromSource
 }
 
+// StageRetryPolicy describes how to retry when applying the stage fails.
+type StageRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one, zero means no limit.
+	MaxAttempts *int64
+	// Backoff is the backoff curve between retries.
+	Backoff *StageBackoff
+	// OnExhausted is the action taken when the retries are exhausted or the error is not retryable.
+	OnExhausted StageRetryExhaustedAction
+}
+
+// StageRetryExhaustedAction is the action taken when the retries are exhausted.
+type StageRetryExhaustedAction string
+
+const (
+	// StageRetryExhaustedActionEvent means that a warning event will be sent.
+	StageRetryExhaustedActionEvent StageRetryExhaustedAction = "Event"
+	// StageRetryExhaustedActionSkip means that the stage will be skipped.
+	StageRetryExhaustedActionSkip StageRetryExhaustedAction = "Skip"
+	// StageRetryExhaustedActionDelete means that the resource will be deleted.
+	StageRetryExhaustedActionDelete StageRetryExhaustedAction = "Delete"
+)
+
+// StageBackoff describes the backoff curve between retries.
+type StageBackoff struct {
+	// DurationMilliseconds is the delay before the first retry.
+	DurationMilliseconds *int64
+	// Factor is multiplied by the delay of each retry to get the next one.
+	Factor *float64
+	// Jitter adds a random amount of up to Jitter*delay to each delay.
+	Jitter *float64
+	// CapMilliseconds is the maximum delay between retries.
+	CapMilliseconds *int64
+}
+
 // StageNext describes a stage will be moved to.
 type StageNext struct {
 	// Event means that an event will be sent.
@@ -151,7 +187,7 @@ type StageEvent struct {
 }
 
 // StageSelector is a resource selector. the result of matchLabels and matchAnnotations and
-// matchExpressions are ANDed. An empty resource selector matches all objects. A null
+// matchExpressions and matchCEL are ANDed. An empty resource selector matches all objects. A null
 // resource selector matches no objects.
 type StageSelector struct {
 	// MatchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
@@ -164,6 +200,9 @@ type StageSelector struct {
 	MatchAnnotations map[string]string
 	// MatchExpressions is a list of label selector requirements. The requirements are ANDed.
 	MatchExpressions []SelectorRequirement
+	// MatchCEL is a list of CEL expressions that must evaluate to true. The requirements are ANDed.
+	// The resource is available as "self", Pod and Node are also available typed as "pod" and "node".
+	MatchCEL []string
 }
 
 // SelectorRequirement is a resource selector requirement is a selector that contains values, a key,
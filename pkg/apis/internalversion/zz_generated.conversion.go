@@ -200,6 +200,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ComponentRestart)(nil), (*configv1alpha1.ComponentRestart)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ComponentRestart_To_v1alpha1_ComponentRestart(a.(*ComponentRestart), b.(*configv1alpha1.ComponentRestart), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ComponentRestart)(nil), (*ComponentRestart)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ComponentRestart_To_internalversion_ComponentRestart(a.(*configv1alpha1.ComponentRestart), b.(*ComponentRestart), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Env)(nil), (*configv1alpha1.Env)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Env_To_v1alpha1_Env(a.(*Env), b.(*configv1alpha1.Env), scope)
 	}); err != nil {
@@ -230,6 +240,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ExecScriptResponse)(nil), (*v1alpha1.ExecScriptResponse)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ExecScriptResponse_To_v1alpha1_ExecScriptResponse(a.(*ExecScriptResponse), b.(*v1alpha1.ExecScriptResponse), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ExecScriptResponse)(nil), (*ExecScriptResponse)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExecScriptResponse_To_internalversion_ExecScriptResponse(a.(*v1alpha1.ExecScriptResponse), b.(*ExecScriptResponse), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExecScriptRule)(nil), (*v1alpha1.ExecScriptRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule(a.(*ExecScriptRule), b.(*v1alpha1.ExecScriptRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ExecScriptRule)(nil), (*ExecScriptRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule(a.(*v1alpha1.ExecScriptRule), b.(*ExecScriptRule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ExecSpec)(nil), (*v1alpha1.ExecSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_ExecSpec_To_v1alpha1_ExecSpec(a.(*ExecSpec), b.(*v1alpha1.ExecSpec), scope)
 	}); err != nil {
@@ -260,6 +290,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ExecTargetScript)(nil), (*v1alpha1.ExecTargetScript)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ExecTargetScript_To_v1alpha1_ExecTargetScript(a.(*ExecTargetScript), b.(*v1alpha1.ExecTargetScript), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ExecTargetScript)(nil), (*ExecTargetScript)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExecTargetScript_To_internalversion_ExecTargetScript(a.(*v1alpha1.ExecTargetScript), b.(*ExecTargetScript), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ExpressionFromSource)(nil), (*v1alpha1.ExpressionFromSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_ExpressionFromSource_To_v1alpha1_ExpressionFromSource(a.(*ExpressionFromSource), b.(*v1alpha1.ExpressionFromSource), scope)
 	}); err != nil {
@@ -300,6 +340,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ForwardHTTPHeader)(nil), (*v1alpha1.ForwardHTTPHeader)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader(a.(*ForwardHTTPHeader), b.(*v1alpha1.ForwardHTTPHeader), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ForwardHTTPHeader)(nil), (*ForwardHTTPHeader)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader(a.(*v1alpha1.ForwardHTTPHeader), b.(*ForwardHTTPHeader), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ForwardHTTPResponder)(nil), (*v1alpha1.ForwardHTTPResponder)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ForwardHTTPResponder_To_v1alpha1_ForwardHTTPResponder(a.(*ForwardHTTPResponder), b.(*v1alpha1.ForwardHTTPResponder), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ForwardHTTPResponder)(nil), (*ForwardHTTPResponder)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardHTTPResponder_To_internalversion_ForwardHTTPResponder(a.(*v1alpha1.ForwardHTTPResponder), b.(*ForwardHTTPResponder), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ForwardHTTPRoute)(nil), (*v1alpha1.ForwardHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(a.(*ForwardHTTPRoute), b.(*v1alpha1.ForwardHTTPRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ForwardHTTPRoute)(nil), (*ForwardHTTPRoute)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(a.(*v1alpha1.ForwardHTTPRoute), b.(*ForwardHTTPRoute), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ForwardResponder)(nil), (*v1alpha1.ForwardResponder)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ForwardResponder_To_v1alpha1_ForwardResponder(a.(*ForwardResponder), b.(*v1alpha1.ForwardResponder), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ForwardResponder)(nil), (*ForwardResponder)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardResponder_To_internalversion_ForwardResponder(a.(*v1alpha1.ForwardResponder), b.(*ForwardResponder), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ForwardTCPResponder)(nil), (*v1alpha1.ForwardTCPResponder)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ForwardTCPResponder_To_v1alpha1_ForwardTCPResponder(a.(*ForwardTCPResponder), b.(*v1alpha1.ForwardTCPResponder), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.ForwardTCPResponder)(nil), (*ForwardTCPResponder)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ForwardTCPResponder_To_internalversion_ForwardTCPResponder(a.(*v1alpha1.ForwardTCPResponder), b.(*ForwardTCPResponder), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ForwardTarget)(nil), (*v1alpha1.ForwardTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_ForwardTarget_To_v1alpha1_ForwardTarget(a.(*ForwardTarget), b.(*v1alpha1.ForwardTarget), scope)
 	}); err != nil {
@@ -380,6 +470,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KwokctlResourceChild)(nil), (*configv1alpha1.KwokctlResourceChild)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_KwokctlResourceChild_To_v1alpha1_KwokctlResourceChild(a.(*KwokctlResourceChild), b.(*configv1alpha1.KwokctlResourceChild), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.KwokctlResourceChild)(nil), (*KwokctlResourceChild)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KwokctlResourceChild_To_internalversion_KwokctlResourceChild(a.(*configv1alpha1.KwokctlResourceChild), b.(*KwokctlResourceChild), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Log)(nil), (*v1alpha1.Log)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Log_To_v1alpha1_Log(a.(*Log), b.(*v1alpha1.Log), scope)
 	}); err != nil {
@@ -390,6 +490,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LogGenerator)(nil), (*v1alpha1.LogGenerator)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_LogGenerator_To_v1alpha1_LogGenerator(a.(*LogGenerator), b.(*v1alpha1.LogGenerator), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.LogGenerator)(nil), (*LogGenerator)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LogGenerator_To_internalversion_LogGenerator(a.(*v1alpha1.LogGenerator), b.(*LogGenerator), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Logs)(nil), (*v1alpha1.Logs)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_Logs_To_v1alpha1_Logs(a.(*Logs), b.(*v1alpha1.Logs), scope)
 	}); err != nil {
@@ -540,6 +650,76 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Scenario)(nil), (*configv1alpha1.Scenario)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_Scenario_To_v1alpha1_Scenario(a.(*Scenario), b.(*configv1alpha1.Scenario), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.Scenario)(nil), (*Scenario)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Scenario_To_internalversion_Scenario(a.(*configv1alpha1.Scenario), b.(*Scenario), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScenarioApply)(nil), (*configv1alpha1.ScenarioApply)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ScenarioApply_To_v1alpha1_ScenarioApply(a.(*ScenarioApply), b.(*configv1alpha1.ScenarioApply), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ScenarioApply)(nil), (*ScenarioApply)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ScenarioApply_To_internalversion_ScenarioApply(a.(*configv1alpha1.ScenarioApply), b.(*ScenarioApply), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScenarioAssert)(nil), (*configv1alpha1.ScenarioAssert)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ScenarioAssert_To_v1alpha1_ScenarioAssert(a.(*ScenarioAssert), b.(*configv1alpha1.ScenarioAssert), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ScenarioAssert)(nil), (*ScenarioAssert)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ScenarioAssert_To_internalversion_ScenarioAssert(a.(*configv1alpha1.ScenarioAssert), b.(*ScenarioAssert), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScenarioScale)(nil), (*configv1alpha1.ScenarioScale)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ScenarioScale_To_v1alpha1_ScenarioScale(a.(*ScenarioScale), b.(*configv1alpha1.ScenarioScale), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ScenarioScale)(nil), (*ScenarioScale)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ScenarioScale_To_internalversion_ScenarioScale(a.(*configv1alpha1.ScenarioScale), b.(*ScenarioScale), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScenarioSnapshot)(nil), (*configv1alpha1.ScenarioSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ScenarioSnapshot_To_v1alpha1_ScenarioSnapshot(a.(*ScenarioSnapshot), b.(*configv1alpha1.ScenarioSnapshot), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ScenarioSnapshot)(nil), (*ScenarioSnapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ScenarioSnapshot_To_internalversion_ScenarioSnapshot(a.(*configv1alpha1.ScenarioSnapshot), b.(*ScenarioSnapshot), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScenarioStep)(nil), (*configv1alpha1.ScenarioStep)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ScenarioStep_To_v1alpha1_ScenarioStep(a.(*ScenarioStep), b.(*configv1alpha1.ScenarioStep), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ScenarioStep)(nil), (*ScenarioStep)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ScenarioStep_To_internalversion_ScenarioStep(a.(*configv1alpha1.ScenarioStep), b.(*ScenarioStep), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScenarioWait)(nil), (*configv1alpha1.ScenarioWait)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_ScenarioWait_To_v1alpha1_ScenarioWait(a.(*ScenarioWait), b.(*configv1alpha1.ScenarioWait), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*configv1alpha1.ScenarioWait)(nil), (*ScenarioWait)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ScenarioWait_To_internalversion_ScenarioWait(a.(*configv1alpha1.ScenarioWait), b.(*ScenarioWait), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SecurityContext)(nil), (*v1alpha1.SecurityContext)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_SecurityContext_To_v1alpha1_SecurityContext(a.(*SecurityContext), b.(*v1alpha1.SecurityContext), scope)
 	}); err != nil {
@@ -570,6 +750,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StageBackoff)(nil), (*v1alpha1.StageBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StageBackoff_To_v1alpha1_StageBackoff(a.(*StageBackoff), b.(*v1alpha1.StageBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StageBackoff)(nil), (*StageBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StageBackoff_To_internalversion_StageBackoff(a.(*v1alpha1.StageBackoff), b.(*StageBackoff), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StageDelay)(nil), (*v1alpha1.StageDelay)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_StageDelay_To_v1alpha1_StageDelay(a.(*StageDelay), b.(*v1alpha1.StageDelay), scope)
 	}); err != nil {
@@ -615,6 +805,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*StageRetryPolicy)(nil), (*v1alpha1.StageRetryPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_internalversion_StageRetryPolicy_To_v1alpha1_StageRetryPolicy(a.(*StageRetryPolicy), b.(*v1alpha1.StageRetryPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1alpha1.StageRetryPolicy)(nil), (*StageRetryPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StageRetryPolicy_To_internalversion_StageRetryPolicy(a.(*v1alpha1.StageRetryPolicy), b.(*StageRetryPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*StageSelector)(nil), (*v1alpha1.StageSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_internalversion_StageSelector_To_v1alpha1_StageSelector(a.(*StageSelector), b.(*v1alpha1.StageSelector), scope)
 	}); err != nil {
@@ -987,7 +1187,17 @@ func Convert_v1alpha1_ClusterPortForward_To_internalversion_ClusterPortForward(i
 
 func autoConvert_internalversion_ClusterPortForwardSpec_To_v1alpha1_ClusterPortForwardSpec(in *ClusterPortForwardSpec, out *v1alpha1.ClusterPortForwardSpec, s conversion.Scope) error {
 	out.Selector = (*v1alpha1.ObjectSelector)(unsafe.Pointer(in.Selector))
-	out.Forwards = *(*[]v1alpha1.Forward)(unsafe.Pointer(&in.Forwards))
+	if in.Forwards != nil {
+		in, out := &in.Forwards, &out.Forwards
+		*out = make([]v1alpha1.Forward, len(*in))
+		for i := range *in {
+			if err := Convert_internalversion_Forward_To_v1alpha1_Forward(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Forwards = nil
+	}
 	return nil
 }
 
@@ -998,7 +1208,17 @@ func Convert_internalversion_ClusterPortForwardSpec_To_v1alpha1_ClusterPortForwa
 
 func autoConvert_v1alpha1_ClusterPortForwardSpec_To_internalversion_ClusterPortForwardSpec(in *v1alpha1.ClusterPortForwardSpec, out *ClusterPortForwardSpec, s conversion.Scope) error {
 	out.Selector = (*ObjectSelector)(unsafe.Pointer(in.Selector))
-	out.Forwards = *(*[]Forward)(unsafe.Pointer(&in.Forwards))
+	if in.Forwards != nil {
+		in, out := &in.Forwards, &out.Forwards
+		*out = make([]Forward, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_Forward_To_internalversion_Forward(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Forwards = nil
+	}
 	return nil
 }
 
@@ -1199,6 +1419,32 @@ func Convert_v1alpha1_ComponentPatches_To_internalversion_ComponentPatches(in *c
 	return autoConvert_v1alpha1_ComponentPatches_To_internalversion_ComponentPatches(in, out, s)
 }
 
+func autoConvert_internalversion_ComponentRestart_To_v1alpha1_ComponentRestart(in *ComponentRestart, out *configv1alpha1.ComponentRestart, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Count = in.Count
+	out.Reason = in.Reason
+	out.LastRestartTime = in.LastRestartTime
+	return nil
+}
+
+// Convert_internalversion_ComponentRestart_To_v1alpha1_ComponentRestart is an autogenerated conversion function.
+func Convert_internalversion_ComponentRestart_To_v1alpha1_ComponentRestart(in *ComponentRestart, out *configv1alpha1.ComponentRestart, s conversion.Scope) error {
+	return autoConvert_internalversion_ComponentRestart_To_v1alpha1_ComponentRestart(in, out, s)
+}
+
+func autoConvert_v1alpha1_ComponentRestart_To_internalversion_ComponentRestart(in *configv1alpha1.ComponentRestart, out *ComponentRestart, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Count = in.Count
+	out.Reason = in.Reason
+	out.LastRestartTime = in.LastRestartTime
+	return nil
+}
+
+// Convert_v1alpha1_ComponentRestart_To_internalversion_ComponentRestart is an autogenerated conversion function.
+func Convert_v1alpha1_ComponentRestart_To_internalversion_ComponentRestart(in *configv1alpha1.ComponentRestart, out *ComponentRestart, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ComponentRestart_To_internalversion_ComponentRestart(in, out, s)
+}
+
 func autoConvert_internalversion_Env_To_v1alpha1_Env(in *Env, out *configv1alpha1.Env, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Value = in.Value
@@ -1271,6 +1517,62 @@ func Convert_v1alpha1_Exec_To_internalversion_Exec(in *v1alpha1.Exec, out *Exec,
 	return autoConvert_v1alpha1_Exec_To_internalversion_Exec(in, out, s)
 }
 
+func autoConvert_internalversion_ExecScriptResponse_To_v1alpha1_ExecScriptResponse(in *ExecScriptResponse, out *v1alpha1.ExecScriptResponse, s conversion.Scope) error {
+	out.Stdout = in.Stdout
+	out.Stderr = in.Stderr
+	out.ExitCode = in.ExitCode
+	out.DelayMilliseconds = (*int64)(unsafe.Pointer(in.DelayMilliseconds))
+	return nil
+}
+
+// Convert_internalversion_ExecScriptResponse_To_v1alpha1_ExecScriptResponse is an autogenerated conversion function.
+func Convert_internalversion_ExecScriptResponse_To_v1alpha1_ExecScriptResponse(in *ExecScriptResponse, out *v1alpha1.ExecScriptResponse, s conversion.Scope) error {
+	return autoConvert_internalversion_ExecScriptResponse_To_v1alpha1_ExecScriptResponse(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExecScriptResponse_To_internalversion_ExecScriptResponse(in *v1alpha1.ExecScriptResponse, out *ExecScriptResponse, s conversion.Scope) error {
+	out.Stdout = in.Stdout
+	out.Stderr = in.Stderr
+	out.ExitCode = in.ExitCode
+	out.DelayMilliseconds = (*int64)(unsafe.Pointer(in.DelayMilliseconds))
+	return nil
+}
+
+// Convert_v1alpha1_ExecScriptResponse_To_internalversion_ExecScriptResponse is an autogenerated conversion function.
+func Convert_v1alpha1_ExecScriptResponse_To_internalversion_ExecScriptResponse(in *v1alpha1.ExecScriptResponse, out *ExecScriptResponse, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExecScriptResponse_To_internalversion_ExecScriptResponse(in, out, s)
+}
+
+func autoConvert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule(in *ExecScriptRule, out *v1alpha1.ExecScriptRule, s conversion.Scope) error {
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Prefix = *(*[]string)(unsafe.Pointer(&in.Prefix))
+	out.Regex = in.Regex
+	if err := Convert_internalversion_ExecScriptResponse_To_v1alpha1_ExecScriptResponse(&in.Response, &out.Response, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule is an autogenerated conversion function.
+func Convert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule(in *ExecScriptRule, out *v1alpha1.ExecScriptRule, s conversion.Scope) error {
+	return autoConvert_internalversion_ExecScriptRule_To_v1alpha1_ExecScriptRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule(in *v1alpha1.ExecScriptRule, out *ExecScriptRule, s conversion.Scope) error {
+	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	out.Prefix = *(*[]string)(unsafe.Pointer(&in.Prefix))
+	out.Regex = in.Regex
+	if err := Convert_v1alpha1_ExecScriptResponse_To_internalversion_ExecScriptResponse(&in.Response, &out.Response, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule is an autogenerated conversion function.
+func Convert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule(in *v1alpha1.ExecScriptRule, out *ExecScriptRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExecScriptRule_To_internalversion_ExecScriptRule(in, out, s)
+}
+
 func autoConvert_internalversion_ExecSpec_To_v1alpha1_ExecSpec(in *ExecSpec, out *v1alpha1.ExecSpec, s conversion.Scope) error {
 	out.Execs = *(*[]v1alpha1.ExecTarget)(unsafe.Pointer(&in.Execs))
 	return nil
@@ -1294,6 +1596,7 @@ func Convert_v1alpha1_ExecSpec_To_internalversion_ExecSpec(in *v1alpha1.ExecSpec
 func autoConvert_internalversion_ExecTarget_To_v1alpha1_ExecTarget(in *ExecTarget, out *v1alpha1.ExecTarget, s conversion.Scope) error {
 	out.Containers = *(*[]string)(unsafe.Pointer(&in.Containers))
 	out.Local = (*v1alpha1.ExecTargetLocal)(unsafe.Pointer(in.Local))
+	out.Script = (*v1alpha1.ExecTargetScript)(unsafe.Pointer(in.Script))
 	return nil
 }
 
@@ -1305,6 +1608,7 @@ func Convert_internalversion_ExecTarget_To_v1alpha1_ExecTarget(in *ExecTarget, o
 func autoConvert_v1alpha1_ExecTarget_To_internalversion_ExecTarget(in *v1alpha1.ExecTarget, out *ExecTarget, s conversion.Scope) error {
 	out.Containers = *(*[]string)(unsafe.Pointer(&in.Containers))
 	out.Local = (*ExecTargetLocal)(unsafe.Pointer(in.Local))
+	out.Script = (*ExecTargetScript)(unsafe.Pointer(in.Script))
 	return nil
 }
 
@@ -1337,6 +1641,28 @@ func Convert_v1alpha1_ExecTargetLocal_To_internalversion_ExecTargetLocal(in *v1a
 	return autoConvert_v1alpha1_ExecTargetLocal_To_internalversion_ExecTargetLocal(in, out, s)
 }
 
+func autoConvert_internalversion_ExecTargetScript_To_v1alpha1_ExecTargetScript(in *ExecTargetScript, out *v1alpha1.ExecTargetScript, s conversion.Scope) error {
+	out.Rules = *(*[]v1alpha1.ExecScriptRule)(unsafe.Pointer(&in.Rules))
+	out.Default = (*v1alpha1.ExecScriptResponse)(unsafe.Pointer(in.Default))
+	return nil
+}
+
+// Convert_internalversion_ExecTargetScript_To_v1alpha1_ExecTargetScript is an autogenerated conversion function.
+func Convert_internalversion_ExecTargetScript_To_v1alpha1_ExecTargetScript(in *ExecTargetScript, out *v1alpha1.ExecTargetScript, s conversion.Scope) error {
+	return autoConvert_internalversion_ExecTargetScript_To_v1alpha1_ExecTargetScript(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExecTargetScript_To_internalversion_ExecTargetScript(in *v1alpha1.ExecTargetScript, out *ExecTargetScript, s conversion.Scope) error {
+	out.Rules = *(*[]ExecScriptRule)(unsafe.Pointer(&in.Rules))
+	out.Default = (*ExecScriptResponse)(unsafe.Pointer(in.Default))
+	return nil
+}
+
+// Convert_v1alpha1_ExecTargetScript_To_internalversion_ExecTargetScript is an autogenerated conversion function.
+func Convert_v1alpha1_ExecTargetScript_To_internalversion_ExecTargetScript(in *v1alpha1.ExecTargetScript, out *ExecTargetScript, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExecTargetScript_To_internalversion_ExecTargetScript(in, out, s)
+}
+
 func autoConvert_internalversion_ExpressionFromSource_To_v1alpha1_ExpressionFromSource(in *ExpressionFromSource, out *v1alpha1.ExpressionFromSource, s conversion.Scope) error {
 	out.ExpressionFrom = in.ExpressionFrom
 	return nil
@@ -1405,6 +1731,15 @@ func autoConvert_internalversion_Forward_To_v1alpha1_Forward(in *Forward, out *v
 	out.Ports = *(*[]int32)(unsafe.Pointer(&in.Ports))
 	out.Target = (*v1alpha1.ForwardTarget)(unsafe.Pointer(in.Target))
 	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	if in.Responder != nil {
+		in, out := &in.Responder, &out.Responder
+		*out = new(v1alpha1.ForwardResponder)
+		if err := Convert_internalversion_ForwardResponder_To_v1alpha1_ForwardResponder(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Responder = nil
+	}
 	return nil
 }
 
@@ -1417,6 +1752,15 @@ func autoConvert_v1alpha1_Forward_To_internalversion_Forward(in *v1alpha1.Forwar
 	out.Ports = *(*[]int32)(unsafe.Pointer(&in.Ports))
 	out.Target = (*ForwardTarget)(unsafe.Pointer(in.Target))
 	out.Command = *(*[]string)(unsafe.Pointer(&in.Command))
+	if in.Responder != nil {
+		in, out := &in.Responder, &out.Responder
+		*out = new(ForwardResponder)
+		if err := Convert_v1alpha1_ForwardResponder_To_internalversion_ForwardResponder(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Responder = nil
+	}
 	return nil
 }
 
@@ -1425,6 +1769,180 @@ func Convert_v1alpha1_Forward_To_internalversion_Forward(in *v1alpha1.Forward, o
 	return autoConvert_v1alpha1_Forward_To_internalversion_Forward(in, out, s)
 }
 
+func autoConvert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader(in *ForwardHTTPHeader, out *v1alpha1.ForwardHTTPHeader, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader is an autogenerated conversion function.
+func Convert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader(in *ForwardHTTPHeader, out *v1alpha1.ForwardHTTPHeader, s conversion.Scope) error {
+	return autoConvert_internalversion_ForwardHTTPHeader_To_v1alpha1_ForwardHTTPHeader(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader(in *v1alpha1.ForwardHTTPHeader, out *ForwardHTTPHeader, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	return nil
+}
+
+// Convert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader(in *v1alpha1.ForwardHTTPHeader, out *ForwardHTTPHeader, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardHTTPHeader_To_internalversion_ForwardHTTPHeader(in, out, s)
+}
+
+func autoConvert_internalversion_ForwardHTTPResponder_To_v1alpha1_ForwardHTTPResponder(in *ForwardHTTPResponder, out *v1alpha1.ForwardHTTPResponder, s conversion.Scope) error {
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]v1alpha1.ForwardHTTPRoute, len(*in))
+		for i := range *in {
+			if err := Convert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
+	return nil
+}
+
+// Convert_internalversion_ForwardHTTPResponder_To_v1alpha1_ForwardHTTPResponder is an autogenerated conversion function.
+func Convert_internalversion_ForwardHTTPResponder_To_v1alpha1_ForwardHTTPResponder(in *ForwardHTTPResponder, out *v1alpha1.ForwardHTTPResponder, s conversion.Scope) error {
+	return autoConvert_internalversion_ForwardHTTPResponder_To_v1alpha1_ForwardHTTPResponder(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardHTTPResponder_To_internalversion_ForwardHTTPResponder(in *v1alpha1.ForwardHTTPResponder, out *ForwardHTTPResponder, s conversion.Scope) error {
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]ForwardHTTPRoute, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ForwardHTTPResponder_To_internalversion_ForwardHTTPResponder is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardHTTPResponder_To_internalversion_ForwardHTTPResponder(in *v1alpha1.ForwardHTTPResponder, out *ForwardHTTPResponder, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardHTTPResponder_To_internalversion_ForwardHTTPResponder(in, out, s)
+}
+
+func autoConvert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(in *ForwardHTTPRoute, out *v1alpha1.ForwardHTTPRoute, s conversion.Scope) error {
+	out.Method = in.Method
+	out.Path = in.Path
+	if err := v1.Convert_int32_To_Pointer_int32(&in.StatusCode, &out.StatusCode, s); err != nil {
+		return err
+	}
+	out.Headers = *(*[]v1alpha1.ForwardHTTPHeader)(unsafe.Pointer(&in.Headers))
+	out.Body = in.Body
+	return nil
+}
+
+// Convert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute is an autogenerated conversion function.
+func Convert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(in *ForwardHTTPRoute, out *v1alpha1.ForwardHTTPRoute, s conversion.Scope) error {
+	return autoConvert_internalversion_ForwardHTTPRoute_To_v1alpha1_ForwardHTTPRoute(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(in *v1alpha1.ForwardHTTPRoute, out *ForwardHTTPRoute, s conversion.Scope) error {
+	out.Method = in.Method
+	out.Path = in.Path
+	if err := v1.Convert_Pointer_int32_To_int32(&in.StatusCode, &out.StatusCode, s); err != nil {
+		return err
+	}
+	out.Headers = *(*[]ForwardHTTPHeader)(unsafe.Pointer(&in.Headers))
+	out.Body = in.Body
+	return nil
+}
+
+// Convert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(in *v1alpha1.ForwardHTTPRoute, out *ForwardHTTPRoute, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardHTTPRoute_To_internalversion_ForwardHTTPRoute(in, out, s)
+}
+
+func autoConvert_internalversion_ForwardResponder_To_v1alpha1_ForwardResponder(in *ForwardResponder, out *v1alpha1.ForwardResponder, s conversion.Scope) error {
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(v1alpha1.ForwardHTTPResponder)
+		if err := Convert_internalversion_ForwardHTTPResponder_To_v1alpha1_ForwardHTTPResponder(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP = nil
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(v1alpha1.ForwardTCPResponder)
+		if err := Convert_internalversion_ForwardTCPResponder_To_v1alpha1_ForwardTCPResponder(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TCP = nil
+	}
+	return nil
+}
+
+// Convert_internalversion_ForwardResponder_To_v1alpha1_ForwardResponder is an autogenerated conversion function.
+func Convert_internalversion_ForwardResponder_To_v1alpha1_ForwardResponder(in *ForwardResponder, out *v1alpha1.ForwardResponder, s conversion.Scope) error {
+	return autoConvert_internalversion_ForwardResponder_To_v1alpha1_ForwardResponder(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardResponder_To_internalversion_ForwardResponder(in *v1alpha1.ForwardResponder, out *ForwardResponder, s conversion.Scope) error {
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(ForwardHTTPResponder)
+		if err := Convert_v1alpha1_ForwardHTTPResponder_To_internalversion_ForwardHTTPResponder(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP = nil
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(ForwardTCPResponder)
+		if err := Convert_v1alpha1_ForwardTCPResponder_To_internalversion_ForwardTCPResponder(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TCP = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ForwardResponder_To_internalversion_ForwardResponder is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardResponder_To_internalversion_ForwardResponder(in *v1alpha1.ForwardResponder, out *ForwardResponder, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardResponder_To_internalversion_ForwardResponder(in, out, s)
+}
+
+func autoConvert_internalversion_ForwardTCPResponder_To_v1alpha1_ForwardTCPResponder(in *ForwardTCPResponder, out *v1alpha1.ForwardTCPResponder, s conversion.Scope) error {
+	out.Banner = in.Banner
+	if err := v1.Convert_bool_To_Pointer_bool(&in.Echo, &out.Echo, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_internalversion_ForwardTCPResponder_To_v1alpha1_ForwardTCPResponder is an autogenerated conversion function.
+func Convert_internalversion_ForwardTCPResponder_To_v1alpha1_ForwardTCPResponder(in *ForwardTCPResponder, out *v1alpha1.ForwardTCPResponder, s conversion.Scope) error {
+	return autoConvert_internalversion_ForwardTCPResponder_To_v1alpha1_ForwardTCPResponder(in, out, s)
+}
+
+func autoConvert_v1alpha1_ForwardTCPResponder_To_internalversion_ForwardTCPResponder(in *v1alpha1.ForwardTCPResponder, out *ForwardTCPResponder, s conversion.Scope) error {
+	out.Banner = in.Banner
+	if err := v1.Convert_Pointer_bool_To_bool(&in.Echo, &out.Echo, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ForwardTCPResponder_To_internalversion_ForwardTCPResponder is an autogenerated conversion function.
+func Convert_v1alpha1_ForwardTCPResponder_To_internalversion_ForwardTCPResponder(in *v1alpha1.ForwardTCPResponder, out *ForwardTCPResponder, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ForwardTCPResponder_To_internalversion_ForwardTCPResponder(in, out, s)
+}
+
 func autoConvert_internalversion_ForwardTarget_To_v1alpha1_ForwardTarget(in *ForwardTarget, out *v1alpha1.ForwardTarget, s conversion.Scope) error {
 	out.Port = in.Port
 	out.Address = in.Address
@@ -1537,6 +2055,10 @@ func autoConvert_internalversion_KwokConfigurationOptions_To_v1alpha1_KwokConfig
 	if err := v1.Convert_bool_To_Pointer_bool(&in.EnablePodsOnNodeSyncListPager, &out.EnablePodsOnNodeSyncListPager, s); err != nil {
 		return err
 	}
+	out.RandomSeed = in.RandomSeed
+	if err := v1.Convert_float64_To_Pointer_float64(&in.TimeScale, &out.TimeScale, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1582,6 +2104,10 @@ func autoConvert_v1alpha1_KwokConfigurationOptions_To_internalversion_KwokConfig
 	if err := v1.Convert_Pointer_bool_To_bool(&in.EnablePodsOnNodeSyncListPager, &out.EnablePodsOnNodeSyncListPager, s); err != nil {
 		return err
 	}
+	out.RandomSeed = in.RandomSeed
+	if err := v1.Convert_Pointer_float64_To_float64(&in.TimeScale, &out.TimeScale, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1671,6 +2197,8 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	out.EnableCRDs = *(*[]string)(unsafe.Pointer(&in.EnableCRDs))
 	out.KubeApiserverPort = in.KubeApiserverPort
 	out.KubeApiserverInsecurePort = in.KubeApiserverInsecurePort
+	out.KubeApiserverReplicas = in.KubeApiserverReplicas
+	out.EtcdReplicas = in.EtcdReplicas
 	out.InsecureKubeconfig = in.InsecureKubeconfig
 	out.Runtime = in.Runtime
 	out.Runtimes = *(*[]string)(unsafe.Pointer(&in.Runtimes))
@@ -1707,6 +2235,7 @@ func autoConvert_internalversion_KwokctlConfigurationOptions_To_v1alpha1_Kwokctl
 	}
 	out.EtcdImage = in.EtcdImage
 	out.KubeApiserverImage = in.KubeApiserverImage
+	out.KubeApiserverLoadBalancerImage = in.KubeApiserverLoadBalancerImage
 	out.KubeControllerManagerImage = in.KubeControllerManagerImage
 	out.KubeSchedulerImage = in.KubeSchedulerImage
 	out.KubectlImage = in.KubectlImage
@@ -1775,6 +2304,8 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	out.EnableCRDs = *(*[]string)(unsafe.Pointer(&in.EnableCRDs))
 	out.KubeApiserverPort = in.KubeApiserverPort
 	out.KubeApiserverInsecurePort = in.KubeApiserverInsecurePort
+	out.KubeApiserverReplicas = in.KubeApiserverReplicas
+	out.EtcdReplicas = in.EtcdReplicas
 	out.InsecureKubeconfig = in.InsecureKubeconfig
 	out.Runtime = in.Runtime
 	out.Runtimes = *(*[]string)(unsafe.Pointer(&in.Runtimes))
@@ -1818,6 +2349,7 @@ func autoConvert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_Kwokctl
 	// INFO: in.MetricsServerImagePrefix opted out of conversion generation
 	out.EtcdImage = in.EtcdImage
 	out.KubeApiserverImage = in.KubeApiserverImage
+	out.KubeApiserverLoadBalancerImage = in.KubeApiserverLoadBalancerImage
 	out.KubeControllerManagerImage = in.KubeControllerManagerImage
 	out.KubeSchedulerImage = in.KubeSchedulerImage
 	out.KubectlImage = in.KubectlImage
@@ -1893,6 +2425,7 @@ func Convert_v1alpha1_KwokctlConfigurationOptions_To_internalversion_KwokctlConf
 
 func autoConvert_internalversion_KwokctlConfigurationStatus_To_v1alpha1_KwokctlConfigurationStatus(in *KwokctlConfigurationStatus, out *configv1alpha1.KwokctlConfigurationStatus, s conversion.Scope) error {
 	out.Version = in.Version
+	out.Restarts = *(*[]configv1alpha1.ComponentRestart)(unsafe.Pointer(&in.Restarts))
 	return nil
 }
 
@@ -1903,6 +2436,7 @@ func Convert_internalversion_KwokctlConfigurationStatus_To_v1alpha1_KwokctlConfi
 
 func autoConvert_v1alpha1_KwokctlConfigurationStatus_To_internalversion_KwokctlConfigurationStatus(in *configv1alpha1.KwokctlConfigurationStatus, out *KwokctlConfigurationStatus, s conversion.Scope) error {
 	out.Version = in.Version
+	out.Restarts = *(*[]ComponentRestart)(unsafe.Pointer(&in.Restarts))
 	return nil
 }
 
@@ -1915,6 +2449,7 @@ func autoConvert_internalversion_KwokctlResource_To_v1alpha1_KwokctlResource(in
 	out.ObjectMeta = in.ObjectMeta
 	out.Parameters = *(*json.RawMessage)(unsafe.Pointer(&in.Parameters))
 	out.Template = in.Template
+	out.Children = *(*[]configv1alpha1.KwokctlResourceChild)(unsafe.Pointer(&in.Children))
 	return nil
 }
 
@@ -1928,6 +2463,7 @@ func autoConvert_v1alpha1_KwokctlResource_To_internalversion_KwokctlResource(in
 	out.ObjectMeta = in.ObjectMeta
 	out.Parameters = *(*json.RawMessage)(unsafe.Pointer(&in.Parameters))
 	out.Template = in.Template
+	out.Children = *(*[]KwokctlResourceChild)(unsafe.Pointer(&in.Children))
 	return nil
 }
 
@@ -1936,6 +2472,30 @@ func Convert_v1alpha1_KwokctlResource_To_internalversion_KwokctlResource(in *con
 	return autoConvert_v1alpha1_KwokctlResource_To_internalversion_KwokctlResource(in, out, s)
 }
 
+func autoConvert_internalversion_KwokctlResourceChild_To_v1alpha1_KwokctlResourceChild(in *KwokctlResourceChild, out *configv1alpha1.KwokctlResourceChild, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Replicas = in.Replicas
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_internalversion_KwokctlResourceChild_To_v1alpha1_KwokctlResourceChild is an autogenerated conversion function.
+func Convert_internalversion_KwokctlResourceChild_To_v1alpha1_KwokctlResourceChild(in *KwokctlResourceChild, out *configv1alpha1.KwokctlResourceChild, s conversion.Scope) error {
+	return autoConvert_internalversion_KwokctlResourceChild_To_v1alpha1_KwokctlResourceChild(in, out, s)
+}
+
+func autoConvert_v1alpha1_KwokctlResourceChild_To_internalversion_KwokctlResourceChild(in *configv1alpha1.KwokctlResourceChild, out *KwokctlResourceChild, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Replicas = in.Replicas
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_v1alpha1_KwokctlResourceChild_To_internalversion_KwokctlResourceChild is an autogenerated conversion function.
+func Convert_v1alpha1_KwokctlResourceChild_To_internalversion_KwokctlResourceChild(in *configv1alpha1.KwokctlResourceChild, out *KwokctlResourceChild, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KwokctlResourceChild_To_internalversion_KwokctlResourceChild(in, out, s)
+}
+
 func autoConvert_internalversion_Log_To_v1alpha1_Log(in *Log, out *v1alpha1.Log, s conversion.Scope) error {
 	out.Containers = *(*[]string)(unsafe.Pointer(&in.Containers))
 	if err := v1.Convert_string_To_Pointer_string(&in.LogsFile, &out.LogsFile, s); err != nil {
@@ -1947,6 +2507,7 @@ func autoConvert_internalversion_Log_To_v1alpha1_Log(in *Log, out *v1alpha1.Log,
 	if err := v1.Convert_bool_To_Pointer_bool(&in.Follow, &out.Follow, s); err != nil {
 		return err
 	}
+	out.Generator = (*v1alpha1.LogGenerator)(unsafe.Pointer(in.Generator))
 	return nil
 }
 
@@ -1966,6 +2527,7 @@ func autoConvert_v1alpha1_Log_To_internalversion_Log(in *v1alpha1.Log, out *Log,
 	if err := v1.Convert_Pointer_bool_To_bool(&in.Follow, &out.Follow, s); err != nil {
 		return err
 	}
+	out.Generator = (*LogGenerator)(unsafe.Pointer(in.Generator))
 	return nil
 }
 
@@ -1974,6 +2536,34 @@ func Convert_v1alpha1_Log_To_internalversion_Log(in *v1alpha1.Log, out *Log, s c
 	return autoConvert_v1alpha1_Log_To_internalversion_Log(in, out, s)
 }
 
+func autoConvert_internalversion_LogGenerator_To_v1alpha1_LogGenerator(in *LogGenerator, out *v1alpha1.LogGenerator, s conversion.Scope) error {
+	out.Template = in.Template
+	out.Expression = in.Expression
+	out.LinesPerSecond = (*float64)(unsafe.Pointer(in.LinesPerSecond))
+	out.Format = v1alpha1.LogFormat(in.Format)
+	out.Seed = (*int64)(unsafe.Pointer(in.Seed))
+	return nil
+}
+
+// Convert_internalversion_LogGenerator_To_v1alpha1_LogGenerator is an autogenerated conversion function.
+func Convert_internalversion_LogGenerator_To_v1alpha1_LogGenerator(in *LogGenerator, out *v1alpha1.LogGenerator, s conversion.Scope) error {
+	return autoConvert_internalversion_LogGenerator_To_v1alpha1_LogGenerator(in, out, s)
+}
+
+func autoConvert_v1alpha1_LogGenerator_To_internalversion_LogGenerator(in *v1alpha1.LogGenerator, out *LogGenerator, s conversion.Scope) error {
+	out.Template = in.Template
+	out.Expression = in.Expression
+	out.LinesPerSecond = (*float64)(unsafe.Pointer(in.LinesPerSecond))
+	out.Format = LogFormat(in.Format)
+	out.Seed = (*int64)(unsafe.Pointer(in.Seed))
+	return nil
+}
+
+// Convert_v1alpha1_LogGenerator_To_internalversion_LogGenerator is an autogenerated conversion function.
+func Convert_v1alpha1_LogGenerator_To_internalversion_LogGenerator(in *v1alpha1.LogGenerator, out *LogGenerator, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LogGenerator_To_internalversion_LogGenerator(in, out, s)
+}
+
 func autoConvert_internalversion_Logs_To_v1alpha1_Logs(in *Logs, out *v1alpha1.Logs, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_internalversion_LogsSpec_To_v1alpha1_LogsSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -2247,7 +2837,17 @@ func Convert_v1alpha1_PortForward_To_internalversion_PortForward(in *v1alpha1.Po
 }
 
 func autoConvert_internalversion_PortForwardSpec_To_v1alpha1_PortForwardSpec(in *PortForwardSpec, out *v1alpha1.PortForwardSpec, s conversion.Scope) error {
-	out.Forwards = *(*[]v1alpha1.Forward)(unsafe.Pointer(&in.Forwards))
+	if in.Forwards != nil {
+		in, out := &in.Forwards, &out.Forwards
+		*out = make([]v1alpha1.Forward, len(*in))
+		for i := range *in {
+			if err := Convert_internalversion_Forward_To_v1alpha1_Forward(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Forwards = nil
+	}
 	return nil
 }
 
@@ -2257,7 +2857,17 @@ func Convert_internalversion_PortForwardSpec_To_v1alpha1_PortForwardSpec(in *Por
 }
 
 func autoConvert_v1alpha1_PortForwardSpec_To_internalversion_PortForwardSpec(in *v1alpha1.PortForwardSpec, out *PortForwardSpec, s conversion.Scope) error {
-	out.Forwards = *(*[]Forward)(unsafe.Pointer(&in.Forwards))
+	if in.Forwards != nil {
+		in, out := &in.Forwards, &out.Forwards
+		*out = make([]Forward, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_Forward_To_internalversion_Forward(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Forwards = nil
+	}
 	return nil
 }
 
@@ -2358,6 +2968,191 @@ func Convert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(i
 	return autoConvert_v1alpha1_ResourceUsageValue_To_internalversion_ResourceUsageValue(in, out, s)
 }
 
+func autoConvert_internalversion_Scenario_To_v1alpha1_Scenario(in *Scenario, out *configv1alpha1.Scenario, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Steps = *(*[]configv1alpha1.ScenarioStep)(unsafe.Pointer(&in.Steps))
+	return nil
+}
+
+// Convert_internalversion_Scenario_To_v1alpha1_Scenario is an autogenerated conversion function.
+func Convert_internalversion_Scenario_To_v1alpha1_Scenario(in *Scenario, out *configv1alpha1.Scenario, s conversion.Scope) error {
+	return autoConvert_internalversion_Scenario_To_v1alpha1_Scenario(in, out, s)
+}
+
+func autoConvert_v1alpha1_Scenario_To_internalversion_Scenario(in *configv1alpha1.Scenario, out *Scenario, s conversion.Scope) error {
+	// INFO: in.TypeMeta opted out of conversion generation
+	out.ObjectMeta = in.ObjectMeta
+	out.Steps = *(*[]ScenarioStep)(unsafe.Pointer(&in.Steps))
+	return nil
+}
+
+// Convert_v1alpha1_Scenario_To_internalversion_Scenario is an autogenerated conversion function.
+func Convert_v1alpha1_Scenario_To_internalversion_Scenario(in *configv1alpha1.Scenario, out *Scenario, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Scenario_To_internalversion_Scenario(in, out, s)
+}
+
+func autoConvert_internalversion_ScenarioApply_To_v1alpha1_ScenarioApply(in *ScenarioApply, out *configv1alpha1.ScenarioApply, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Manifests = in.Manifests
+	return nil
+}
+
+// Convert_internalversion_ScenarioApply_To_v1alpha1_ScenarioApply is an autogenerated conversion function.
+func Convert_internalversion_ScenarioApply_To_v1alpha1_ScenarioApply(in *ScenarioApply, out *configv1alpha1.ScenarioApply, s conversion.Scope) error {
+	return autoConvert_internalversion_ScenarioApply_To_v1alpha1_ScenarioApply(in, out, s)
+}
+
+func autoConvert_v1alpha1_ScenarioApply_To_internalversion_ScenarioApply(in *configv1alpha1.ScenarioApply, out *ScenarioApply, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Manifests = in.Manifests
+	return nil
+}
+
+// Convert_v1alpha1_ScenarioApply_To_internalversion_ScenarioApply is an autogenerated conversion function.
+func Convert_v1alpha1_ScenarioApply_To_internalversion_ScenarioApply(in *configv1alpha1.ScenarioApply, out *ScenarioApply, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ScenarioApply_To_internalversion_ScenarioApply(in, out, s)
+}
+
+func autoConvert_internalversion_ScenarioAssert_To_v1alpha1_ScenarioAssert(in *ScenarioAssert, out *configv1alpha1.ScenarioAssert, s conversion.Scope) error {
+	out.Resource = in.Resource
+	out.Namespace = in.Namespace
+	out.Selector = in.Selector
+	out.FieldSelector = in.FieldSelector
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_internalversion_ScenarioAssert_To_v1alpha1_ScenarioAssert is an autogenerated conversion function.
+func Convert_internalversion_ScenarioAssert_To_v1alpha1_ScenarioAssert(in *ScenarioAssert, out *configv1alpha1.ScenarioAssert, s conversion.Scope) error {
+	return autoConvert_internalversion_ScenarioAssert_To_v1alpha1_ScenarioAssert(in, out, s)
+}
+
+func autoConvert_v1alpha1_ScenarioAssert_To_internalversion_ScenarioAssert(in *configv1alpha1.ScenarioAssert, out *ScenarioAssert, s conversion.Scope) error {
+	out.Resource = in.Resource
+	out.Namespace = in.Namespace
+	out.Selector = in.Selector
+	out.FieldSelector = in.FieldSelector
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_v1alpha1_ScenarioAssert_To_internalversion_ScenarioAssert is an autogenerated conversion function.
+func Convert_v1alpha1_ScenarioAssert_To_internalversion_ScenarioAssert(in *configv1alpha1.ScenarioAssert, out *ScenarioAssert, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ScenarioAssert_To_internalversion_ScenarioAssert(in, out, s)
+}
+
+func autoConvert_internalversion_ScenarioScale_To_v1alpha1_ScenarioScale(in *ScenarioScale, out *configv1alpha1.ScenarioScale, s conversion.Scope) error {
+	out.Resource = in.Resource
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Replicas = in.Replicas
+	out.SerialLength = in.SerialLength
+	out.Params = *(*[]string)(unsafe.Pointer(&in.Params))
+	return nil
+}
+
+// Convert_internalversion_ScenarioScale_To_v1alpha1_ScenarioScale is an autogenerated conversion function.
+func Convert_internalversion_ScenarioScale_To_v1alpha1_ScenarioScale(in *ScenarioScale, out *configv1alpha1.ScenarioScale, s conversion.Scope) error {
+	return autoConvert_internalversion_ScenarioScale_To_v1alpha1_ScenarioScale(in, out, s)
+}
+
+func autoConvert_v1alpha1_ScenarioScale_To_internalversion_ScenarioScale(in *configv1alpha1.ScenarioScale, out *ScenarioScale, s conversion.Scope) error {
+	out.Resource = in.Resource
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Replicas = in.Replicas
+	out.SerialLength = in.SerialLength
+	out.Params = *(*[]string)(unsafe.Pointer(&in.Params))
+	return nil
+}
+
+// Convert_v1alpha1_ScenarioScale_To_internalversion_ScenarioScale is an autogenerated conversion function.
+func Convert_v1alpha1_ScenarioScale_To_internalversion_ScenarioScale(in *configv1alpha1.ScenarioScale, out *ScenarioScale, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ScenarioScale_To_internalversion_ScenarioScale(in, out, s)
+}
+
+func autoConvert_internalversion_ScenarioSnapshot_To_v1alpha1_ScenarioSnapshot(in *ScenarioSnapshot, out *configv1alpha1.ScenarioSnapshot, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Format = in.Format
+	out.Filters = *(*[]string)(unsafe.Pointer(&in.Filters))
+	return nil
+}
+
+// Convert_internalversion_ScenarioSnapshot_To_v1alpha1_ScenarioSnapshot is an autogenerated conversion function.
+func Convert_internalversion_ScenarioSnapshot_To_v1alpha1_ScenarioSnapshot(in *ScenarioSnapshot, out *configv1alpha1.ScenarioSnapshot, s conversion.Scope) error {
+	return autoConvert_internalversion_ScenarioSnapshot_To_v1alpha1_ScenarioSnapshot(in, out, s)
+}
+
+func autoConvert_v1alpha1_ScenarioSnapshot_To_internalversion_ScenarioSnapshot(in *configv1alpha1.ScenarioSnapshot, out *ScenarioSnapshot, s conversion.Scope) error {
+	out.Path = in.Path
+	out.Format = in.Format
+	out.Filters = *(*[]string)(unsafe.Pointer(&in.Filters))
+	return nil
+}
+
+// Convert_v1alpha1_ScenarioSnapshot_To_internalversion_ScenarioSnapshot is an autogenerated conversion function.
+func Convert_v1alpha1_ScenarioSnapshot_To_internalversion_ScenarioSnapshot(in *configv1alpha1.ScenarioSnapshot, out *ScenarioSnapshot, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ScenarioSnapshot_To_internalversion_ScenarioSnapshot(in, out, s)
+}
+
+func autoConvert_internalversion_ScenarioStep_To_v1alpha1_ScenarioStep(in *ScenarioStep, out *configv1alpha1.ScenarioStep, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Scale = (*configv1alpha1.ScenarioScale)(unsafe.Pointer(in.Scale))
+	out.Apply = (*configv1alpha1.ScenarioApply)(unsafe.Pointer(in.Apply))
+	out.Wait = (*configv1alpha1.ScenarioWait)(unsafe.Pointer(in.Wait))
+	out.Assert = (*configv1alpha1.ScenarioAssert)(unsafe.Pointer(in.Assert))
+	out.Snapshot = (*configv1alpha1.ScenarioSnapshot)(unsafe.Pointer(in.Snapshot))
+	return nil
+}
+
+// Convert_internalversion_ScenarioStep_To_v1alpha1_ScenarioStep is an autogenerated conversion function.
+func Convert_internalversion_ScenarioStep_To_v1alpha1_ScenarioStep(in *ScenarioStep, out *configv1alpha1.ScenarioStep, s conversion.Scope) error {
+	return autoConvert_internalversion_ScenarioStep_To_v1alpha1_ScenarioStep(in, out, s)
+}
+
+func autoConvert_v1alpha1_ScenarioStep_To_internalversion_ScenarioStep(in *configv1alpha1.ScenarioStep, out *ScenarioStep, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Scale = (*ScenarioScale)(unsafe.Pointer(in.Scale))
+	out.Apply = (*ScenarioApply)(unsafe.Pointer(in.Apply))
+	out.Wait = (*ScenarioWait)(unsafe.Pointer(in.Wait))
+	out.Assert = (*ScenarioAssert)(unsafe.Pointer(in.Assert))
+	out.Snapshot = (*ScenarioSnapshot)(unsafe.Pointer(in.Snapshot))
+	return nil
+}
+
+// Convert_v1alpha1_ScenarioStep_To_internalversion_ScenarioStep is an autogenerated conversion function.
+func Convert_v1alpha1_ScenarioStep_To_internalversion_ScenarioStep(in *configv1alpha1.ScenarioStep, out *ScenarioStep, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ScenarioStep_To_internalversion_ScenarioStep(in, out, s)
+}
+
+func autoConvert_internalversion_ScenarioWait_To_v1alpha1_ScenarioWait(in *ScenarioWait, out *configv1alpha1.ScenarioWait, s conversion.Scope) error {
+	out.Resource = in.Resource
+	out.Namespace = in.Namespace
+	out.Selector = in.Selector
+	out.For = in.For
+	out.TimeoutMilliseconds = in.TimeoutMilliseconds
+	return nil
+}
+
+// Convert_internalversion_ScenarioWait_To_v1alpha1_ScenarioWait is an autogenerated conversion function.
+func Convert_internalversion_ScenarioWait_To_v1alpha1_ScenarioWait(in *ScenarioWait, out *configv1alpha1.ScenarioWait, s conversion.Scope) error {
+	return autoConvert_internalversion_ScenarioWait_To_v1alpha1_ScenarioWait(in, out, s)
+}
+
+func autoConvert_v1alpha1_ScenarioWait_To_internalversion_ScenarioWait(in *configv1alpha1.ScenarioWait, out *ScenarioWait, s conversion.Scope) error {
+	out.Resource = in.Resource
+	out.Namespace = in.Namespace
+	out.Selector = in.Selector
+	out.For = in.For
+	out.TimeoutMilliseconds = in.TimeoutMilliseconds
+	return nil
+}
+
+// Convert_v1alpha1_ScenarioWait_To_internalversion_ScenarioWait is an autogenerated conversion function.
+func Convert_v1alpha1_ScenarioWait_To_internalversion_ScenarioWait(in *configv1alpha1.ScenarioWait, out *ScenarioWait, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ScenarioWait_To_internalversion_ScenarioWait(in, out, s)
+}
+
 func autoConvert_internalversion_SecurityContext_To_v1alpha1_SecurityContext(in *SecurityContext, out *v1alpha1.SecurityContext, s conversion.Scope) error {
 	out.RunAsUser = (*int64)(unsafe.Pointer(in.RunAsUser))
 	out.RunAsGroup = (*int64)(unsafe.Pointer(in.RunAsGroup))
@@ -2432,6 +3227,32 @@ func Convert_v1alpha1_Stage_To_internalversion_Stage(in *v1alpha1.Stage, out *St
 	return autoConvert_v1alpha1_Stage_To_internalversion_Stage(in, out, s)
 }
 
+func autoConvert_internalversion_StageBackoff_To_v1alpha1_StageBackoff(in *StageBackoff, out *v1alpha1.StageBackoff, s conversion.Scope) error {
+	out.DurationMilliseconds = (*int64)(unsafe.Pointer(in.DurationMilliseconds))
+	out.Factor = (*float64)(unsafe.Pointer(in.Factor))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	out.CapMilliseconds = (*int64)(unsafe.Pointer(in.CapMilliseconds))
+	return nil
+}
+
+// Convert_internalversion_StageBackoff_To_v1alpha1_StageBackoff is an autogenerated conversion function.
+func Convert_internalversion_StageBackoff_To_v1alpha1_StageBackoff(in *StageBackoff, out *v1alpha1.StageBackoff, s conversion.Scope) error {
+	return autoConvert_internalversion_StageBackoff_To_v1alpha1_StageBackoff(in, out, s)
+}
+
+func autoConvert_v1alpha1_StageBackoff_To_internalversion_StageBackoff(in *v1alpha1.StageBackoff, out *StageBackoff, s conversion.Scope) error {
+	out.DurationMilliseconds = (*int64)(unsafe.Pointer(in.DurationMilliseconds))
+	out.Factor = (*float64)(unsafe.Pointer(in.Factor))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	out.CapMilliseconds = (*int64)(unsafe.Pointer(in.CapMilliseconds))
+	return nil
+}
+
+// Convert_v1alpha1_StageBackoff_To_internalversion_StageBackoff is an autogenerated conversion function.
+func Convert_v1alpha1_StageBackoff_To_internalversion_StageBackoff(in *v1alpha1.StageBackoff, out *StageBackoff, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StageBackoff_To_internalversion_StageBackoff(in, out, s)
+}
+
 func autoConvert_internalversion_StageDelay_To_v1alpha1_StageDelay(in *StageDelay, out *v1alpha1.StageDelay, s conversion.Scope) error {
 	out.DurationMilliseconds = (*int64)(unsafe.Pointer(in.DurationMilliseconds))
 	out.DurationFrom = (*v1alpha1.ExpressionFromSource)(unsafe.Pointer(in.DurationFrom))
@@ -2590,10 +3411,35 @@ func Convert_v1alpha1_StageResourceRef_To_internalversion_StageResourceRef(in *v
 	return autoConvert_v1alpha1_StageResourceRef_To_internalversion_StageResourceRef(in, out, s)
 }
 
+func autoConvert_internalversion_StageRetryPolicy_To_v1alpha1_StageRetryPolicy(in *StageRetryPolicy, out *v1alpha1.StageRetryPolicy, s conversion.Scope) error {
+	out.MaxAttempts = (*int64)(unsafe.Pointer(in.MaxAttempts))
+	out.Backoff = (*v1alpha1.StageBackoff)(unsafe.Pointer(in.Backoff))
+	out.OnExhausted = v1alpha1.StageRetryExhaustedAction(in.OnExhausted)
+	return nil
+}
+
+// Convert_internalversion_StageRetryPolicy_To_v1alpha1_StageRetryPolicy is an autogenerated conversion function.
+func Convert_internalversion_StageRetryPolicy_To_v1alpha1_StageRetryPolicy(in *StageRetryPolicy, out *v1alpha1.StageRetryPolicy, s conversion.Scope) error {
+	return autoConvert_internalversion_StageRetryPolicy_To_v1alpha1_StageRetryPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_StageRetryPolicy_To_internalversion_StageRetryPolicy(in *v1alpha1.StageRetryPolicy, out *StageRetryPolicy, s conversion.Scope) error {
+	out.MaxAttempts = (*int64)(unsafe.Pointer(in.MaxAttempts))
+	out.Backoff = (*StageBackoff)(unsafe.Pointer(in.Backoff))
+	out.OnExhausted = StageRetryExhaustedAction(in.OnExhausted)
+	return nil
+}
+
+// Convert_v1alpha1_StageRetryPolicy_To_internalversion_StageRetryPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_StageRetryPolicy_To_internalversion_StageRetryPolicy(in *v1alpha1.StageRetryPolicy, out *StageRetryPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StageRetryPolicy_To_internalversion_StageRetryPolicy(in, out, s)
+}
+
 func autoConvert_internalversion_StageSelector_To_v1alpha1_StageSelector(in *StageSelector, out *v1alpha1.StageSelector, s conversion.Scope) error {
 	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
 	out.MatchAnnotations = *(*map[string]string)(unsafe.Pointer(&in.MatchAnnotations))
 	out.MatchExpressions = *(*[]v1alpha1.SelectorRequirement)(unsafe.Pointer(&in.MatchExpressions))
+	out.MatchCEL = *(*[]string)(unsafe.Pointer(&in.MatchCEL))
 	return nil
 }
 
@@ -2606,6 +3452,7 @@ func autoConvert_v1alpha1_StageSelector_To_internalversion_StageSelector(in *v1a
 	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
 	out.MatchAnnotations = *(*map[string]string)(unsafe.Pointer(&in.MatchAnnotations))
 	out.MatchExpressions = *(*[]SelectorRequirement)(unsafe.Pointer(&in.MatchExpressions))
+	out.MatchCEL = *(*[]string)(unsafe.Pointer(&in.MatchCEL))
 	return nil
 }
 
@@ -2628,6 +3475,7 @@ func autoConvert_internalversion_StageSpec_To_v1alpha1_StageSpec(in *StageSpec,
 	if err := v1.Convert_bool_To_Pointer_bool(&in.ImmediateNextStage, &out.ImmediateNextStage, s); err != nil {
 		return err
 	}
+	out.RetryPolicy = (*v1alpha1.StageRetryPolicy)(unsafe.Pointer(in.RetryPolicy))
 	return nil
 }
 
@@ -2650,6 +3498,7 @@ func autoConvert_v1alpha1_StageSpec_To_internalversion_StageSpec(in *v1alpha1.St
 	if err := v1.Convert_Pointer_bool_To_bool(&in.ImmediateNextStage, &out.ImmediateNextStage, s); err != nil {
 		return err
 	}
+	out.RetryPolicy = (*StageRetryPolicy)(unsafe.Pointer(in.RetryPolicy))
 	return nil
 }
 
@@ -420,6 +420,23 @@ func (in *ComponentPatches) DeepCopy() *ComponentPatches {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentRestart) DeepCopyInto(out *ComponentRestart) {
+	*out = *in
+	in.LastRestartTime.DeepCopyInto(&out.LastRestartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentRestart.
+func (in *ComponentRestart) DeepCopy() *ComponentRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Env) DeepCopyInto(out *Env) {
 	*out = *in
@@ -470,6 +487,54 @@ func (in *Exec) DeepCopy() *Exec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScriptResponse) DeepCopyInto(out *ExecScriptResponse) {
+	*out = *in
+	if in.DelayMilliseconds != nil {
+		in, out := &in.DelayMilliseconds, &out.DelayMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScriptResponse.
+func (in *ExecScriptResponse) DeepCopy() *ExecScriptResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScriptResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScriptRule) DeepCopyInto(out *ExecScriptRule) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Response.DeepCopyInto(&out.Response)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScriptRule.
+func (in *ExecScriptRule) DeepCopy() *ExecScriptRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScriptRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecSpec) DeepCopyInto(out *ExecSpec) {
 	*out = *in
@@ -506,6 +571,11 @@ func (in *ExecTarget) DeepCopyInto(out *ExecTarget) {
 		*out = new(ExecTargetLocal)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(ExecTargetScript)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -545,6 +615,34 @@ func (in *ExecTargetLocal) DeepCopy() *ExecTargetLocal {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecTargetScript) DeepCopyInto(out *ExecTargetScript) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ExecScriptRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(ExecScriptResponse)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecTargetScript.
+func (in *ExecTargetScript) DeepCopy() *ExecTargetScript {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecTargetScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExpressionFromSource) DeepCopyInto(out *ExpressionFromSource) {
 	*out = *in
@@ -611,6 +709,11 @@ func (in *Forward) DeepCopyInto(out *Forward) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Responder != nil {
+		in, out := &in.Responder, &out.Responder
+		*out = new(ForwardResponder)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -624,6 +727,108 @@ func (in *Forward) DeepCopy() *Forward {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPHeader) DeepCopyInto(out *ForwardHTTPHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPHeader.
+func (in *ForwardHTTPHeader) DeepCopy() *ForwardHTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPResponder) DeepCopyInto(out *ForwardHTTPResponder) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]ForwardHTTPRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPResponder.
+func (in *ForwardHTTPResponder) DeepCopy() *ForwardHTTPResponder {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPResponder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPRoute) DeepCopyInto(out *ForwardHTTPRoute) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ForwardHTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPRoute.
+func (in *ForwardHTTPRoute) DeepCopy() *ForwardHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardResponder) DeepCopyInto(out *ForwardResponder) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(ForwardHTTPResponder)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(ForwardTCPResponder)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardResponder.
+func (in *ForwardResponder) DeepCopy() *ForwardResponder {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardResponder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardTCPResponder) DeepCopyInto(out *ForwardTCPResponder) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardTCPResponder.
+func (in *ForwardTCPResponder) DeepCopy() *ForwardTCPResponder {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardTCPResponder)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardTarget) DeepCopyInto(out *ForwardTarget) {
 	*out = *in
@@ -715,7 +920,7 @@ func (in *KwokctlConfiguration) DeepCopyInto(out *KwokctlConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -778,6 +983,13 @@ func (in *KwokctlConfigurationOptions) DeepCopy() *KwokctlConfigurationOptions {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KwokctlConfigurationStatus) DeepCopyInto(out *KwokctlConfigurationStatus) {
 	*out = *in
+	if in.Restarts != nil {
+		in, out := &in.Restarts, &out.Restarts
+		*out = make([]ComponentRestart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -800,6 +1012,11 @@ func (in *KwokctlResource) DeepCopyInto(out *KwokctlResource) {
 		*out = make(json.RawMessage, len(*in))
 		copy(*out, *in)
 	}
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]KwokctlResourceChild, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -813,6 +1030,22 @@ func (in *KwokctlResource) DeepCopy() *KwokctlResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KwokctlResourceChild) DeepCopyInto(out *KwokctlResourceChild) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KwokctlResourceChild.
+func (in *KwokctlResourceChild) DeepCopy() *KwokctlResourceChild {
+	if in == nil {
+		return nil
+	}
+	out := new(KwokctlResourceChild)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Log) DeepCopyInto(out *Log) {
 	*out = *in
@@ -821,6 +1054,11 @@ func (in *Log) DeepCopyInto(out *Log) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Generator != nil {
+		in, out := &in.Generator, &out.Generator
+		*out = new(LogGenerator)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -834,6 +1072,32 @@ func (in *Log) DeepCopy() *Log {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogGenerator) DeepCopyInto(out *LogGenerator) {
+	*out = *in
+	if in.LinesPerSecond != nil {
+		in, out := &in.LinesPerSecond, &out.LinesPerSecond
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogGenerator.
+func (in *LogGenerator) DeepCopy() *LogGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(LogGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Logs) DeepCopyInto(out *Logs) {
 	*out = *in
@@ -1152,6 +1416,161 @@ func (in *ResourceUsageValue) DeepCopy() *ResourceUsageValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scenario) DeepCopyInto(out *Scenario) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ScenarioStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scenario.
+func (in *Scenario) DeepCopy() *Scenario {
+	if in == nil {
+		return nil
+	}
+	out := new(Scenario)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioApply) DeepCopyInto(out *ScenarioApply) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioApply.
+func (in *ScenarioApply) DeepCopy() *ScenarioApply {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioApply)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioAssert) DeepCopyInto(out *ScenarioAssert) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioAssert.
+func (in *ScenarioAssert) DeepCopy() *ScenarioAssert {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioAssert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioScale) DeepCopyInto(out *ScenarioScale) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioScale.
+func (in *ScenarioScale) DeepCopy() *ScenarioScale {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioScale)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioSnapshot) DeepCopyInto(out *ScenarioSnapshot) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioSnapshot.
+func (in *ScenarioSnapshot) DeepCopy() *ScenarioSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioStep) DeepCopyInto(out *ScenarioStep) {
+	*out = *in
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		*out = new(ScenarioScale)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Apply != nil {
+		in, out := &in.Apply, &out.Apply
+		*out = new(ScenarioApply)
+		**out = **in
+	}
+	if in.Wait != nil {
+		in, out := &in.Wait, &out.Wait
+		*out = new(ScenarioWait)
+		**out = **in
+	}
+	if in.Assert != nil {
+		in, out := &in.Assert, &out.Assert
+		*out = new(ScenarioAssert)
+		**out = **in
+	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(ScenarioSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioStep.
+func (in *ScenarioStep) DeepCopy() *ScenarioStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScenarioWait) DeepCopyInto(out *ScenarioWait) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScenarioWait.
+func (in *ScenarioWait) DeepCopy() *ScenarioWait {
+	if in == nil {
+		return nil
+	}
+	out := new(ScenarioWait)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityContext) DeepCopyInto(out *SecurityContext) {
 	*out = *in
@@ -1217,6 +1636,42 @@ func (in *Stage) DeepCopy() *Stage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageBackoff) DeepCopyInto(out *StageBackoff) {
+	*out = *in
+	if in.DurationMilliseconds != nil {
+		in, out := &in.DurationMilliseconds, &out.DurationMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Factor != nil {
+		in, out := &in.Factor, &out.Factor
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(float64)
+		**out = **in
+	}
+	if in.CapMilliseconds != nil {
+		in, out := &in.CapMilliseconds, &out.CapMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageBackoff.
+func (in *StageBackoff) DeepCopy() *StageBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(StageBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageDelay) DeepCopyInto(out *StageDelay) {
 	*out = *in
@@ -1370,6 +1825,32 @@ func (in *StageResourceRef) DeepCopy() *StageResourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageRetryPolicy) DeepCopyInto(out *StageRetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(StageBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageRetryPolicy.
+func (in *StageRetryPolicy) DeepCopy() *StageRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StageRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageSelector) DeepCopyInto(out *StageSelector) {
 	*out = *in
@@ -1394,6 +1875,11 @@ func (in *StageSelector) DeepCopyInto(out *StageSelector) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchCEL != nil {
+		in, out := &in.MatchCEL, &out.MatchCEL
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1427,6 +1913,11 @@ func (in *StageSpec) DeepCopyInto(out *StageSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Next.DeepCopyInto(&out.Next)
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(StageRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
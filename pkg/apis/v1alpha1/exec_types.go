@@ -68,6 +68,8 @@ type ExecTarget struct {
 	Containers []string `json:"containers,omitempty"`
 	// Local holds information how to exec to a local target.
 	Local *ExecTargetLocal `json:"local,omitempty"`
+	// Script holds canned responses to the commands, nothing is run on the host.
+	Script *ExecTargetScript `json:"script,omitempty"`
 }
 
 // ExecTargetLocal holds information how to exec to a local target.
@@ -80,6 +82,41 @@ type ExecTargetLocal struct {
 	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
 }
 
+// ExecTargetScript holds canned responses to the commands.
+type ExecTargetScript struct {
+	// Rules is a list of responses, the first rule that matches the command is used.
+	Rules []ExecScriptRule `json:"rules,omitempty"`
+	// Default is the response to the commands that no rule matches.
+	// if not set, the command fails with exit code 127.
+	Default *ExecScriptResponse `json:"default,omitempty"`
+}
+
+// ExecScriptRule matches a command to a response.
+// Only one of Command, Prefix and Regex should be set.
+type ExecScriptRule struct {
+	// Command matches the command with exactly these arguments.
+	Command []string `json:"command,omitempty"`
+	// Prefix matches the commands that start with these arguments.
+	Prefix []string `json:"prefix,omitempty"`
+	// Regex matches the command with its arguments joined by spaces.
+	Regex string `json:"regex,omitempty"`
+	// Response is the response to the matched command.
+	Response ExecScriptResponse `json:"response"`
+}
+
+// ExecScriptResponse is a canned response to a command.
+// The stdout and stderr are rendered as go templates with the pod.
+type ExecScriptResponse struct {
+	// Stdout is written to the standard output.
+	Stdout string `json:"stdout,omitempty"`
+	// Stderr is written to the standard error.
+	Stderr string `json:"stderr,omitempty"`
+	// ExitCode is the exit code of the command.
+	ExitCode int32 `json:"exitCode,omitempty"`
+	// DelayMilliseconds is the time to wait before responding.
+	DelayMilliseconds *int64 `json:"delayMilliseconds,omitempty"`
+}
+
 // EnvVar represents an environment variable present in a Container.
 type EnvVar struct {
 	// Name of the environment variable.
@@ -71,8 +71,44 @@ type Log struct {
 	PreviousLogsFile *string `json:"previousLogsFile,omitempty"`
 	// Follow up if true
 	Follow *bool `json:"follow,omitempty"`
+	// Generator generates synthetic logs instead of reading the LogsFile.
+	Generator *LogGenerator `json:"generator,omitempty"`
 }
 
+// LogGenerator holds information how to generate logs.
+// Only one of Template and Expression should be set.
+type LogGenerator struct {
+	// Template is the go template to render each line with the pod.
+	Template string `json:"template,omitempty"`
+	// Expression is the CEL expression to evaluate each line, it must return a string.
+	Expression string `json:"expression,omitempty"`
+	// LinesPerSecond is the number of lines generated per second.
+	// +default=1
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:ExclusiveMinimum=true
+	LinesPerSecond *float64 `json:"linesPerSecond,omitempty"`
+	// Format is the format of the lines.
+	// +default="plain"
+	// +kubebuilder:default=plain
+	// +kubebuilder:validation:Enum=plain;json
+	Format LogFormat `json:"format,omitempty"`
+	// Seed is the seed of the random numbers,
+	// the same lines are generated for a container with the same seed.
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// LogFormat is the format of the generated logs.
+// +enum
+type LogFormat string
+
+const (
+	// LogFormatPlain writes the lines as they are.
+	LogFormatPlain LogFormat = "plain"
+	// LogFormatJSON writes the lines as JSON objects with the time and the message.
+	LogFormatJSON LogFormat = "json"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 
@@ -71,6 +71,65 @@ type Forward struct {
 	// Command is the command to run to forward with stdin/stdout.
 	// if set, Target will be ignored.
 	Command []string `json:"command,omitempty"`
+	// Responder responds to the connections in the server without forwarding them.
+	// if set, Target and Command will be ignored.
+	Responder *ForwardResponder `json:"responder,omitempty"`
+}
+
+// ForwardResponder holds information how to respond to the connections.
+// Only one of HTTP and TCP should be set.
+type ForwardResponder struct {
+	// HTTP serves the connections as a static HTTP server.
+	HTTP *ForwardHTTPResponder `json:"http,omitempty"`
+	// TCP writes a banner to the connections and echoes them.
+	TCP *ForwardTCPResponder `json:"tcp,omitempty"`
+}
+
+// ForwardHTTPResponder holds the routes of a static HTTP server.
+type ForwardHTTPResponder struct {
+	// Routes is a list of routes, the first route that matches the request is used.
+	// if no route matches, the response is 404 Not Found.
+	Routes []ForwardHTTPRoute `json:"routes,omitempty"`
+}
+
+// ForwardHTTPRoute holds the response to the requests that match the route.
+type ForwardHTTPRoute struct {
+	// Method matches the method of the request.
+	// if not set, all methods will be matched.
+	Method string `json:"method,omitempty"`
+	// Path matches the path of the request, a path ending with "/" matches all paths with the prefix.
+	// if not set, all paths will be matched.
+	Path string `json:"path,omitempty"`
+	// StatusCode is the status code of the response.
+	// +default=200
+	// +kubebuilder:default=200
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	StatusCode *int32 `json:"statusCode,omitempty"`
+	// Headers is a list of headers of the response.
+	Headers []ForwardHTTPHeader `json:"headers,omitempty"`
+	// Body is the body of the response, it is rendered as a go template with the pod.
+	Body string `json:"body,omitempty"`
+}
+
+// ForwardHTTPHeader is a header of the HTTP response.
+type ForwardHTTPHeader struct {
+	// Name is the name of the header.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Value is the value of the header.
+	Value string `json:"value,omitempty"`
+}
+
+// ForwardTCPResponder holds information how to respond to the TCP connections.
+type ForwardTCPResponder struct {
+	// Banner is written to the connection when it is accepted,
+	// it is rendered as a go template with the pod.
+	Banner string `json:"banner,omitempty"`
+	// Echo writes back the data received from the connection if true,
+	// otherwise the connection is closed after the banner is written.
+	Echo *bool `json:"echo,omitempty"`
 }
 
 // ForwardTarget holds information how to forward to a target.
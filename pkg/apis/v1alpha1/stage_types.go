@@ -32,6 +32,11 @@ const (
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:rbac:groups=kwok.x-k8s.io,resources=stages,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=kwok.x-k8s.io,resources=stages/status,verbs=update;patch
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.resourceRef.kind`
+// +kubebuilder:printcolumn:name="Matched",type=integer,JSONPath=`.status.matched`
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.applied`
+// +kubebuilder:printcolumn:name="Last Applied",type=date,JSONPath=`.status.lastAppliedTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Stage is an API that describes the staged change of a resource
 type Stage struct {
@@ -55,6 +60,14 @@ type StageStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// Matched is the number of times resources matched the stage.
+	Matched int64 `json:"matched,omitempty"`
+	// Applied is the number of times the stage was applied.
+	Applied int64 `json:"applied,omitempty"`
+	// LastError is the last error that occurred when applying the stage, it is cleared by a later success.
+	LastError string `json:"lastError,omitempty"`
+	// LastAppliedTime is the last time the stage was applied.
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
 }
 
 // StageSpec defines the specification for Stage.
@@ -79,6 +92,9 @@ type StageSpec struct {
 	Next StageNext `json:"next"`
 	// ImmediateNextStage means that the next stage of matching is performed immediately, without waiting for the Apiserver to push.
 	ImmediateNextStage *bool `json:"immediateNextStage,omitempty"`
+	// RetryPolicy describes how to retry when applying the stage fails.
+	// If not set, only transient errors are retried with the default backoff.
+	RetryPolicy *StageRetryPolicy `json:"retryPolicy,omitempty"`
 }
 
 // StageResourceRef specifies the kind and version of the resource.
@@ -112,6 +128,52 @@ type StageDelay struct {
 	JitterDurationFrom *ExpressionFromSource `json:"jitterDurationFrom,omitempty"`
 }
 
+// StageRetryPolicy describes how to retry when applying the stage fails.
+type StageRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one, zero means no limit.
+	// +kubebuilder:validation:Minimum=0
+	MaxAttempts *int64 `json:"maxAttempts,omitempty"`
+	// Backoff is the backoff curve between retries.
+	Backoff *StageBackoff `json:"backoff,omitempty"`
+	// OnExhausted is the action taken when the retries are exhausted or the error is not retryable.
+	// +default="Skip"
+	// +kubebuilder:default=Skip
+	// +kubebuilder:validation:Enum=Event;Skip;Delete
+	OnExhausted StageRetryExhaustedAction `json:"onExhausted,omitempty"`
+}
+
+// StageRetryExhaustedAction is the action taken when the retries are exhausted.
+type StageRetryExhaustedAction string
+
+const (
+	// StageRetryExhaustedActionEvent means that a warning event will be sent.
+	StageRetryExhaustedActionEvent StageRetryExhaustedAction = "Event"
+	// StageRetryExhaustedActionSkip means that the stage will be skipped.
+	StageRetryExhaustedActionSkip StageRetryExhaustedAction = "Skip"
+	// StageRetryExhaustedActionDelete means that the resource will be deleted.
+	StageRetryExhaustedActionDelete StageRetryExhaustedAction = "Delete"
+)
+
+// StageBackoff describes the backoff curve between retries.
+type StageBackoff struct {
+	// DurationMilliseconds is the delay before the first retry.
+	// +default=1000
+	// +kubebuilder:default=1000
+	// +kubebuilder:validation:Minimum=0
+	DurationMilliseconds *int64 `json:"durationMilliseconds,omitempty"`
+	// Factor is multiplied by the delay of each retry to get the next one.
+	// +default=2
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=1
+	Factor *float64 `json:"factor,omitempty"`
+	// Jitter adds a random amount of up to Jitter*delay to each delay.
+	// +kubebuilder:validation:Minimum=0
+	Jitter *float64 `json:"jitter,omitempty"`
+	// CapMilliseconds is the maximum delay between retries.
+	// +kubebuilder:validation:Minimum=0
+	CapMilliseconds *int64 `json:"capMilliseconds,omitempty"`
+}
+
 // StageNext describes a stage will be moved to.
 type StageNext struct {
 	// Event means that an event will be sent.
@@ -206,7 +268,7 @@ type StageEvent struct {
 }
 
 // StageSelector is a resource selector. the result of matchLabels and matchAnnotations and
-// matchExpressions are ANDed. An empty resource selector matches all objects. A null
+// matchExpressions and matchCEL are ANDed. An empty resource selector matches all objects. A null
 // resource selector matches no objects.
 type StageSelector struct {
 	// MatchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
@@ -219,6 +281,9 @@ type StageSelector struct {
 	MatchAnnotations map[string]string `json:"matchAnnotations,omitempty"`
 	// MatchExpressions is a list of label selector requirements. The requirements are ANDed.
 	MatchExpressions []SelectorRequirement `json:"matchExpressions,omitempty"`
+	// MatchCEL is a list of CEL expressions that must evaluate to true. The requirements are ANDed.
+	// The resource is available as "self", Pod and Node are also available typed as "pod" and "node".
+	MatchCEL []string `json:"matchCEL,omitempty"`
 }
 
 // SelectorRequirement is a resource selector requirement is a selector that contains values, a key,
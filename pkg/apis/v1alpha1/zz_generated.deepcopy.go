@@ -812,6 +812,54 @@ func (in *ExecList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScriptResponse) DeepCopyInto(out *ExecScriptResponse) {
+	*out = *in
+	if in.DelayMilliseconds != nil {
+		in, out := &in.DelayMilliseconds, &out.DelayMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScriptResponse.
+func (in *ExecScriptResponse) DeepCopy() *ExecScriptResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScriptResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecScriptRule) DeepCopyInto(out *ExecScriptRule) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Response.DeepCopyInto(&out.Response)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecScriptRule.
+func (in *ExecScriptRule) DeepCopy() *ExecScriptRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecScriptRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecSpec) DeepCopyInto(out *ExecSpec) {
 	*out = *in
@@ -871,6 +919,11 @@ func (in *ExecTarget) DeepCopyInto(out *ExecTarget) {
 		*out = new(ExecTargetLocal)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(ExecTargetScript)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -910,6 +963,34 @@ func (in *ExecTargetLocal) DeepCopy() *ExecTargetLocal {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecTargetScript) DeepCopyInto(out *ExecTargetScript) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ExecScriptRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(ExecScriptResponse)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecTargetScript.
+func (in *ExecTargetScript) DeepCopy() *ExecTargetScript {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecTargetScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExpressionFromSource) DeepCopyInto(out *ExpressionFromSource) {
 	*out = *in
@@ -960,6 +1041,11 @@ func (in *Forward) DeepCopyInto(out *Forward) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Responder != nil {
+		in, out := &in.Responder, &out.Responder
+		*out = new(ForwardResponder)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -973,6 +1059,118 @@ func (in *Forward) DeepCopy() *Forward {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPHeader) DeepCopyInto(out *ForwardHTTPHeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPHeader.
+func (in *ForwardHTTPHeader) DeepCopy() *ForwardHTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPResponder) DeepCopyInto(out *ForwardHTTPResponder) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]ForwardHTTPRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPResponder.
+func (in *ForwardHTTPResponder) DeepCopy() *ForwardHTTPResponder {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPResponder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardHTTPRoute) DeepCopyInto(out *ForwardHTTPRoute) {
+	*out = *in
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ForwardHTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardHTTPRoute.
+func (in *ForwardHTTPRoute) DeepCopy() *ForwardHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardResponder) DeepCopyInto(out *ForwardResponder) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(ForwardHTTPResponder)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(ForwardTCPResponder)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardResponder.
+func (in *ForwardResponder) DeepCopy() *ForwardResponder {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardResponder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardTCPResponder) DeepCopyInto(out *ForwardTCPResponder) {
+	*out = *in
+	if in.Echo != nil {
+		in, out := &in.Echo, &out.Echo
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardTCPResponder.
+func (in *ForwardTCPResponder) DeepCopy() *ForwardTCPResponder {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardTCPResponder)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardTarget) DeepCopyInto(out *ForwardTarget) {
 	*out = *in
@@ -1028,6 +1226,11 @@ func (in *Log) DeepCopyInto(out *Log) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Generator != nil {
+		in, out := &in.Generator, &out.Generator
+		*out = new(LogGenerator)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1041,6 +1244,32 @@ func (in *Log) DeepCopy() *Log {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogGenerator) DeepCopyInto(out *LogGenerator) {
+	*out = *in
+	if in.LinesPerSecond != nil {
+		in, out := &in.LinesPerSecond, &out.LinesPerSecond
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogGenerator.
+func (in *LogGenerator) DeepCopy() *LogGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(LogGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Logs) DeepCopyInto(out *Logs) {
 	*out = *in
@@ -1682,6 +1911,42 @@ func (in *Stage) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageBackoff) DeepCopyInto(out *StageBackoff) {
+	*out = *in
+	if in.DurationMilliseconds != nil {
+		in, out := &in.DurationMilliseconds, &out.DurationMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Factor != nil {
+		in, out := &in.Factor, &out.Factor
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(float64)
+		**out = **in
+	}
+	if in.CapMilliseconds != nil {
+		in, out := &in.CapMilliseconds, &out.CapMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageBackoff.
+func (in *StageBackoff) DeepCopy() *StageBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(StageBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageDelay) DeepCopyInto(out *StageDelay) {
 	*out = *in
@@ -1878,6 +2143,32 @@ func (in *StageResourceRef) DeepCopy() *StageResourceRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StageRetryPolicy) DeepCopyInto(out *StageRetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(StageBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageRetryPolicy.
+func (in *StageRetryPolicy) DeepCopy() *StageRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StageRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StageSelector) DeepCopyInto(out *StageSelector) {
 	*out = *in
@@ -1902,6 +2193,11 @@ func (in *StageSelector) DeepCopyInto(out *StageSelector) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchCEL != nil {
+		in, out := &in.MatchCEL, &out.MatchCEL
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1940,6 +2236,11 @@ func (in *StageSpec) DeepCopyInto(out *StageSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(StageRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1963,6 +2264,10 @@ func (in *StageStatus) DeepCopyInto(out *StageStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
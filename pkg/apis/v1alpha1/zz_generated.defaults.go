@@ -29,13 +29,89 @@ import (
 // Public to allow building arbitrary schemes.
 // All generated defaulters are covering - they call all nested defaulters.
 func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ClusterLogs{}, func(obj interface{}) { SetObjectDefaults_ClusterLogs(obj.(*ClusterLogs)) })
+	scheme.AddTypeDefaultingFunc(&ClusterLogsList{}, func(obj interface{}) { SetObjectDefaults_ClusterLogsList(obj.(*ClusterLogsList)) })
+	scheme.AddTypeDefaultingFunc(&ClusterPortForward{}, func(obj interface{}) { SetObjectDefaults_ClusterPortForward(obj.(*ClusterPortForward)) })
+	scheme.AddTypeDefaultingFunc(&ClusterPortForwardList{}, func(obj interface{}) { SetObjectDefaults_ClusterPortForwardList(obj.(*ClusterPortForwardList)) })
+	scheme.AddTypeDefaultingFunc(&Logs{}, func(obj interface{}) { SetObjectDefaults_Logs(obj.(*Logs)) })
+	scheme.AddTypeDefaultingFunc(&LogsList{}, func(obj interface{}) { SetObjectDefaults_LogsList(obj.(*LogsList)) })
 	scheme.AddTypeDefaultingFunc(&Metric{}, func(obj interface{}) { SetObjectDefaults_Metric(obj.(*Metric)) })
 	scheme.AddTypeDefaultingFunc(&MetricList{}, func(obj interface{}) { SetObjectDefaults_MetricList(obj.(*MetricList)) })
+	scheme.AddTypeDefaultingFunc(&PortForward{}, func(obj interface{}) { SetObjectDefaults_PortForward(obj.(*PortForward)) })
+	scheme.AddTypeDefaultingFunc(&PortForwardList{}, func(obj interface{}) { SetObjectDefaults_PortForwardList(obj.(*PortForwardList)) })
 	scheme.AddTypeDefaultingFunc(&Stage{}, func(obj interface{}) { SetObjectDefaults_Stage(obj.(*Stage)) })
 	scheme.AddTypeDefaultingFunc(&StageList{}, func(obj interface{}) { SetObjectDefaults_StageList(obj.(*StageList)) })
 	return nil
 }
 
+func SetObjectDefaults_ClusterLogs(in *ClusterLogs) {
+	for i := range in.Spec.Logs {
+		a := &in.Spec.Logs[i]
+		if a.Generator != nil {
+			if a.Generator.LinesPerSecond == nil {
+				var ptrVar1 float64 = 1
+				a.Generator.LinesPerSecond = &ptrVar1
+			}
+			if a.Generator.Format == "" {
+				a.Generator.Format = "plain"
+			}
+		}
+	}
+}
+
+func SetObjectDefaults_ClusterLogsList(in *ClusterLogsList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_ClusterLogs(a)
+	}
+}
+
+func SetObjectDefaults_ClusterPortForward(in *ClusterPortForward) {
+	for i := range in.Spec.Forwards {
+		a := &in.Spec.Forwards[i]
+		if a.Responder != nil {
+			if a.Responder.HTTP != nil {
+				for j := range a.Responder.HTTP.Routes {
+					b := &a.Responder.HTTP.Routes[j]
+					if b.StatusCode == nil {
+						var ptrVar1 int32 = 200
+						b.StatusCode = &ptrVar1
+					}
+				}
+			}
+		}
+	}
+}
+
+func SetObjectDefaults_ClusterPortForwardList(in *ClusterPortForwardList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_ClusterPortForward(a)
+	}
+}
+
+func SetObjectDefaults_Logs(in *Logs) {
+	for i := range in.Spec.Logs {
+		a := &in.Spec.Logs[i]
+		if a.Generator != nil {
+			if a.Generator.LinesPerSecond == nil {
+				var ptrVar1 float64 = 1
+				a.Generator.LinesPerSecond = &ptrVar1
+			}
+			if a.Generator.Format == "" {
+				a.Generator.Format = "plain"
+			}
+		}
+	}
+}
+
+func SetObjectDefaults_LogsList(in *LogsList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_Logs(a)
+	}
+}
+
 func SetObjectDefaults_Metric(in *Metric) {
 	for i := range in.Spec.Metrics {
 		a := &in.Spec.Metrics[i]
@@ -52,6 +128,30 @@ func SetObjectDefaults_MetricList(in *MetricList) {
 	}
 }
 
+func SetObjectDefaults_PortForward(in *PortForward) {
+	for i := range in.Spec.Forwards {
+		a := &in.Spec.Forwards[i]
+		if a.Responder != nil {
+			if a.Responder.HTTP != nil {
+				for j := range a.Responder.HTTP.Routes {
+					b := &a.Responder.HTTP.Routes[j]
+					if b.StatusCode == nil {
+						var ptrVar1 int32 = 200
+						b.StatusCode = &ptrVar1
+					}
+				}
+			}
+		}
+	}
+}
+
+func SetObjectDefaults_PortForwardList(in *PortForwardList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_PortForward(a)
+	}
+}
+
 func SetObjectDefaults_Stage(in *Stage) {
 	if in.Spec.ResourceRef.APIGroup == "" {
 		in.Spec.ResourceRef.APIGroup = "v1"
@@ -60,6 +160,21 @@ func SetObjectDefaults_Stage(in *Stage) {
 		var ptrVar1 string = "status"
 		in.Spec.Next.StatusSubresource = &ptrVar1
 	}
+	if in.Spec.RetryPolicy != nil {
+		if in.Spec.RetryPolicy.Backoff != nil {
+			if in.Spec.RetryPolicy.Backoff.DurationMilliseconds == nil {
+				var ptrVar1 int64 = 1000
+				in.Spec.RetryPolicy.Backoff.DurationMilliseconds = &ptrVar1
+			}
+			if in.Spec.RetryPolicy.Backoff.Factor == nil {
+				var ptrVar1 float64 = 2
+				in.Spec.RetryPolicy.Backoff.Factor = &ptrVar1
+			}
+		}
+		if in.Spec.RetryPolicy.OnExhausted == "" {
+			in.Spec.RetryPolicy.OnExhausted = "Skip"
+		}
+	}
 }
 
 func SetObjectDefaults_StageList(in *StageList) {
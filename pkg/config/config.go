@@ -110,6 +110,12 @@ var configHandlers = map[string]configHandler{
 		MutateToInternal: mutateToInternalConfig(internalversion.ConvertToInternalKwokctlResource),
 		MutateToVersiond: mutateToVersiondConfig(internalversion.ConvertToV1alpha1KwokctlResource),
 	},
+	configv1alpha1.ScenarioKind: {
+		Unmarshal:        unmarshalConfig[*configv1alpha1.Scenario],
+		Marshal:          marshalConfig,
+		MutateToInternal: mutateToInternalConfig(convertToInternalScenario),
+		MutateToVersiond: mutateToVersiondConfig(internalversion.ConvertToV1alpha1Scenario),
+	},
 	v1alpha1.StageKind: {
 		Unmarshal:        unmarshalConfig[*v1alpha1.Stage],
 		Marshal:          marshalConfig,
@@ -276,7 +282,19 @@ func Load(ctx context.Context, src ...string) ([]InternalObject, error) {
 	if err != nil {
 		return nil, err
 	}
+	return loadFromRawMessages(ctx, raws, src)
+}
+
+// LoadFromReader loads the objects of the YAML or JSON documents in the reader.
+func LoadFromReader(ctx context.Context, r io.Reader) ([]InternalObject, error) {
+	raws, err := loadRaw(r)
+	if err != nil {
+		return nil, err
+	}
+	return loadFromRawMessages(ctx, raws, nil)
+}
 
+func loadFromRawMessages(ctx context.Context, raws []json.RawMessage, src []string) ([]InternalObject, error) {
 	result := map[string][]versiondObject{}
 
 	logger := log.FromContext(ctx)
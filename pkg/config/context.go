@@ -36,6 +36,11 @@ func setupContext(ctx context.Context, objs []InternalObject) context.Context {
 	return context.WithValue(ctx, configCtx(0), val)
 }
 
+// NewContext returns a context with the given objects, replacing the ones loaded from the flags.
+func NewContext(ctx context.Context, objs []InternalObject) context.Context {
+	return setupContext(ctx, objs)
+}
+
 // addToContext adds the given objects to the context.
 func addToContext(ctx context.Context, objs ...InternalObject) {
 	v := ctx.Value(configCtx(0))
@@ -18,7 +18,11 @@ package resources
 
 import (
 	"context"
+	"reflect"
+	"strconv"
+	"sync/atomic"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -64,6 +68,9 @@ type dynamicGetter[O any, T runtime.Object, L runtime.Object] struct {
 
 	store      cache.Store
 	controller cache.Controller
+
+	// version is increased when the resources change other than the status
+	version atomic.Uint64
 }
 
 func (c *dynamicGetter[O, T, L]) Start(ctx context.Context) error {
@@ -80,12 +87,18 @@ func (c *dynamicGetter[O, T, L]) Start(ctx context.Context) error {
 		ObjectType: t,
 		Handler: cache.ResourceEventHandlerFuncs{
 			AddFunc: func(obj interface{}) {
+				c.version.Add(1)
 				c.sync()
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
+				if !changedOtherThanStatus(oldObj, newObj) {
+					return
+				}
+				c.version.Add(1)
 				c.sync()
 			},
 			DeleteFunc: func(obj interface{}) {
+				c.version.Add(1)
 				c.sync()
 			},
 		},
@@ -109,7 +122,7 @@ func (c *dynamicGetter[O, T, L]) Get() O {
 }
 
 func (c *dynamicGetter[O, T, L]) Version() string {
-	return c.controller.LastSyncResourceVersion()
+	return strconv.FormatUint(c.version.Load(), 10)
 }
 
 func (c *dynamicGetter[O, T, L]) Sync() <-chan struct{} {
@@ -122,3 +135,21 @@ func (c *dynamicGetter[O, T, L]) sync() {
 	default:
 	}
 }
+
+// changedOtherThanStatus returns whether the update changes the resource other than the status,
+// the generation is not increased by the updates of the status subresource.
+func changedOtherThanStatus(oldObj, newObj interface{}) bool {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return true
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		return true
+	}
+	if newMeta.GetGeneration() == 0 || oldMeta.GetGeneration() != newMeta.GetGeneration() {
+		return true
+	}
+	return !reflect.DeepEqual(oldMeta.GetLabels(), newMeta.GetLabels()) ||
+		!reflect.DeepEqual(oldMeta.GetAnnotations(), newMeta.GetAnnotations())
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/k8s"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// SetKubeVersion moves the options to the Kubernetes version.
+// The images, binaries, etcd version, feature gates and runtime config derived from the old version
+// are derived again from the new one, the ones set explicitly are kept.
+func SetKubeVersion(conf *internalversion.KwokctlConfigurationOptions, kubeVersion string) error {
+	newVersion := version.AddPrefixV(kubeVersion)
+	newRelease := parseRelease(newVersion)
+	if newRelease == -1 {
+		return fmt.Errorf("invalid kube version %q", kubeVersion)
+	}
+	oldVersion := conf.KubeVersion
+	oldRelease := parseRelease(oldVersion)
+
+	images := []*string{
+		&conf.KubeApiserverImage,
+		&conf.KubeControllerManagerImage,
+		&conf.KubeSchedulerImage,
+		&conf.KubectlImage,
+		&conf.KindNodeImage,
+	}
+	for _, image := range images {
+		*image = replaceImageTag(*image, oldVersion, newVersion)
+	}
+
+	binaries := []*string{
+		&conf.KubeApiserverBinary,
+		&conf.KubeControllerManagerBinary,
+		&conf.KubeSchedulerBinary,
+		&conf.KubectlBinary,
+	}
+	for _, binary := range binaries {
+		*binary = replaceURIVersion(*binary, oldVersion, newVersion)
+	}
+
+	if oldRelease != -1 {
+		if conf.KubeFeatureGates != "" && conf.KubeFeatureGates == k8s.GetFeatureGates(oldRelease) {
+			conf.KubeFeatureGates = k8s.GetFeatureGates(newRelease)
+		}
+		if conf.KubeRuntimeConfig != "" && conf.KubeRuntimeConfig == k8s.GetRuntimeConfig(oldRelease) {
+			conf.KubeRuntimeConfig = k8s.GetRuntimeConfig(newRelease)
+		}
+
+		oldEtcdVersion := k8s.GetEtcdVersion(oldRelease)
+		if conf.EtcdVersion == oldEtcdVersion {
+			newEtcdVersion := k8s.GetEtcdVersion(newRelease)
+			conf.EtcdVersion = newEtcdVersion
+			conf.EtcdImage = replaceImageTag(conf.EtcdImage, oldEtcdVersion, newEtcdVersion)
+
+			// The etcd binaries are released without the suffix of the image
+			oldEtcdBinaryVersion := "v" + strings.TrimSuffix(oldEtcdVersion, "-0")
+			newEtcdBinaryVersion := "v" + strings.TrimSuffix(newEtcdVersion, "-0")
+			etcdBinaries := []*string{
+				&conf.EtcdBinaryTar,
+				&conf.EtcdBinary,
+				&conf.EtcdctlBinary,
+			}
+			for _, binary := range etcdBinaries {
+				*binary = replaceURIVersion(*binary, oldEtcdBinaryVersion, newEtcdBinaryVersion)
+				*binary = strings.ReplaceAll(*binary, "/etcd-"+oldEtcdBinaryVersion+"-", "/etcd-"+newEtcdBinaryVersion+"-")
+			}
+		}
+	}
+
+	conf.KubeVersion = newVersion
+	return nil
+}
+
+// replaceImageTag replaces the tag of the image if it is the old version.
+func replaceImageTag(image, oldVersion, newVersion string) string {
+	if !strings.HasSuffix(image, ":"+oldVersion) {
+		return image
+	}
+	return strings.TrimSuffix(image, oldVersion) + newVersion
+}
+
+// replaceURIVersion replaces the old version in the path segments of the URI,
+// e.g. https://dl.k8s.io/release/v1.30.0/bin/linux/amd64/kube-apiserver.
+func replaceURIVersion(uri, oldVersion, newVersion string) string {
+	uri = strings.ReplaceAll(uri, "/"+oldVersion+"/", "/"+newVersion+"/")
+	uri = strings.ReplaceAll(uri, "/"+oldVersion+"-", "/"+newVersion+"-")
+	return uri
+}
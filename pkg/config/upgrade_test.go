@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	configv1alpha1 "sigs.k8s.io/kwok/pkg/apis/config/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func defaultOptions(t *testing.T, kubeVersion string) internalversion.KwokctlConfigurationOptions {
+	conf, err := convertToInternalKwokctlConfiguration(&configv1alpha1.KwokctlConfiguration{
+		Options: configv1alpha1.KwokctlConfigurationOptions{
+			KubeVersion: kubeVersion,
+			Runtime:     "binary",
+			Mode:        configv1alpha1.ModeStableFeatureGateAndAPI,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conf.Options
+}
+
+func TestSetKubeVersion(t *testing.T) {
+	got := defaultOptions(t, "v1.29.0")
+	got.KubeSchedulerImage = "example.com/custom-scheduler:latest"
+
+	err := SetKubeVersion(&got, "1.31.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := defaultOptions(t, "v1.31.0")
+	want.KubeSchedulerImage = "example.com/custom-scheduler:latest"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SetKubeVersion() mismatch (-want +got):\n%s", diff)
+	}
+
+	err = SetKubeVersion(&got, "invalid")
+	if err == nil {
+		t.Errorf("SetKubeVersion() want error for invalid version")
+	}
+}
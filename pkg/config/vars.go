@@ -137,6 +137,19 @@ func setStageDefaults(config *v1alpha1.Stage) *v1alpha1.Stage {
 	return config
 }
 
+func convertToInternalScenario(config *configv1alpha1.Scenario) (*internalversion.Scenario, error) {
+	obj := setScenarioDefaults(config)
+	return internalversion.ConvertToInternalScenario(obj)
+}
+
+func setScenarioDefaults(config *configv1alpha1.Scenario) *configv1alpha1.Scenario {
+	if config == nil {
+		config = &configv1alpha1.Scenario{}
+	}
+	configv1alpha1.SetObjectDefaults_Scenario(config)
+	return config
+}
+
 func convertToInternalKwokConfiguration(config *configv1alpha1.KwokConfiguration) (*internalversion.KwokConfiguration, error) {
 	obj := setKwokConfigurationDefaults(config)
 	return internalversion.ConvertToInternalKwokConfiguration(obj)
@@ -268,6 +281,7 @@ func setKwokctlKubernetesConfig(conf *configv1alpha1.KwokctlConfigurationOptions
 
 	conf.KubeApiserverPort = envs.GetEnvWithPrefix("KUBE_APISERVER_PORT", conf.KubeApiserverPort)
 	conf.KubeApiserverInsecurePort = envs.GetEnvWithPrefix("KUBE_APISERVER_INSECURE_PORT", conf.KubeApiserverInsecurePort)
+	conf.KubeApiserverReplicas = envs.GetEnvWithPrefix("KUBE_APISERVER_REPLICAS", conf.KubeApiserverReplicas)
 
 	if conf.KubeFeatureGates == "" {
 		if conf.Mode == configv1alpha1.ModeStableFeatureGateAndAPI {
@@ -329,6 +343,11 @@ func setKwokctlKubernetesConfig(conf *configv1alpha1.KwokctlConfigurationOptions
 	}
 	conf.KubeApiserverImage = envs.GetEnvWithPrefix("KUBE_APISERVER_IMAGE", conf.KubeApiserverImage)
 
+	if conf.KubeApiserverLoadBalancerImage == "" {
+		conf.KubeApiserverLoadBalancerImage = joinImageURI(consts.LoadBalancerImagePrefix, "haproxy", consts.LoadBalancerVersion)
+	}
+	conf.KubeApiserverLoadBalancerImage = envs.GetEnvWithPrefix("KUBE_APISERVER_LOAD_BALANCER_IMAGE", conf.KubeApiserverLoadBalancerImage)
+
 	if conf.KubeControllerManagerImage == "" {
 		conf.KubeControllerManagerImage = joinImageURI(conf.KubeImagePrefix, "kube-controller-manager", conf.KubeVersion)
 	}
@@ -406,6 +425,7 @@ func setKwokctlEtcdConfig(conf *configv1alpha1.KwokctlConfigurationOptions) {
 	conf.EtcdImage = envs.GetEnvWithPrefix("ETCD_IMAGE", conf.EtcdImage)
 
 	conf.EtcdPort = envs.GetEnvWithPrefix("ETCD_PORT", conf.EtcdPort)
+	conf.EtcdReplicas = envs.GetEnvWithPrefix("ETCD_REPLICAS", conf.EtcdReplicas)
 
 	if conf.EtcdBinary == "" {
 		conf.EtcdBinary = conf.EtcdBinaryTar + "#etcd" + conf.BinSuffix
@@ -45,6 +45,9 @@ var (
 	KindBinaryPrefix    = "https://github.com/kubernetes-sigs/kind/releases/download"
 	KindNodeImagePrefix = "docker.io/kindest"
 
+	LoadBalancerVersion     = "v20230606-42a2262b"
+	LoadBalancerImagePrefix = "docker.io/kindest"
+
 	DashboardVersion      = "2.7.0"
 	DashboardBinaryPrefix = ""
 	DashboardImagePrefix  = "docker.io/kubernetesui"
@@ -71,6 +74,8 @@ var (
 const (
 	// RuntimeTypeBinary is the binary runtime.
 	RuntimeTypeBinary = "binary"
+	// RuntimeTypeEmbedded is the binary runtime with etcd and kwok-controller run in the kwokctl process.
+	RuntimeTypeEmbedded = "embedded"
 
 	// Container runtime type, will create a container for each component.
 
@@ -104,6 +109,7 @@ const (
 	ComponentEtcd                       = "etcd"
 	ComponentKubeApiserver              = "kube-apiserver"
 	ComponentKubeApiserverInsecureProxy = "kube-apiserver-insecure-proxy"
+	ComponentKubeApiserverLoadBalancer  = "kube-apiserver-load-balancer"
 	ComponentKubeControllerManager      = "kube-controller-manager"
 	ComponentKubeScheduler              = "kube-scheduler"
 	ComponentKwokController             = "kwok-controller"
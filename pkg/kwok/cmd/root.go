@@ -31,7 +31,6 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/component-base/tracing"
 	tracingapi "k8s.io/component-base/tracing/api/v1"
-	"k8s.io/utils/clock"
 
 	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
 	nodeheartbeat "sigs.k8s.io/kwok/kustomize/stage/node/heartbeat"
@@ -45,10 +44,12 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwok/server"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/clock"
 	"sigs.k8s.io/kwok/pkg/utils/envs"
 	"sigs.k8s.io/kwok/pkg/utils/format"
 	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
 	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 	"sigs.k8s.io/kwok/pkg/utils/version"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
@@ -99,6 +100,8 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().StringVar(&flags.Options.ServerAddress, "server-address", flags.Options.ServerAddress, "Address to expose the server on")
 	cmd.Flags().UintVar(&flags.Options.NodeLeaseDurationSeconds, "node-lease-duration-seconds", flags.Options.NodeLeaseDurationSeconds, "Duration of node lease seconds")
 	cmd.Flags().StringSliceVar(&flags.Options.EnableCRDs, "enable-crds", flags.Options.EnableCRDs, "List of CRDs to enable")
+	cmd.Flags().Int64Var(&flags.Options.RandomSeed, "random-seed", flags.Options.RandomSeed, "Seed of the random number generators used by stages, 0 means not reproducible")
+	cmd.Flags().Float64Var(&flags.Options.TimeScale, "time-scale", flags.Options.TimeScale, "Factor by which the time of the controller runs faster than the real time, stage delays and lease renewals are divided by it")
 	cmd.Flags().StringVar(&flags.Tracing.Endpoint, "tracing-endpoint", flags.Tracing.Endpoint, "Tracing endpoint")
 	cmd.Flags().Int32Var(&flags.Tracing.SamplingRatePerMillion, "tracing-sampling-rate-per-million", flags.Tracing.SamplingRatePerMillion, "Tracing sampling rate per million")
 
@@ -144,6 +147,15 @@ func runE(ctx context.Context, flags *flagpole) error {
 		}
 	}
 
+	if flags.Options.RandomSeed != 0 {
+		rand.Seed(flags.Options.RandomSeed)
+	}
+
+	if flags.Options.TimeScale <= 0 {
+		return fmt.Errorf("invalid time scale: %v", flags.Options.TimeScale)
+	}
+	clock.SetScale(flags.Options.TimeScale)
+
 	for _, crd := range flags.Options.EnableCRDs {
 		if _, ok := crdDefines[crd]; !ok {
 			return fmt.Errorf("invalid crd: %s", crd)
@@ -266,7 +278,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 	metrics := config.FilterWithTypeFromContext[*internalversion.Metric](ctx)
 	enableMetrics := len(metrics) != 0 || slices.Contains(flags.Options.EnableCRDs, v1alpha1.MetricKind)
 	ctr, err := controllers.NewController(controllers.Config{
-		Clock:                                 clock.RealClock{},
+		Clock:                                 clock.Default(),
 		DynamicClient:                         dynamicClient,
 		RESTClient:                            restClient,
 		RESTMapper:                            restMapper,
@@ -435,6 +447,11 @@ func startServer(ctx context.Context, flags *flagpole, ctr *controllers.Controll
 			return fmt.Errorf("failed to install metrics: %w", err)
 		}
 
+		err = svc.InstallStats(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to install stats: %w", err)
+		}
+
 		go func() {
 			err := svc.Run(ctx, serverAddress, flags.Options.TLSCertFile, flags.Options.TLSPrivateKeyFile)
 			if err != nil {
@@ -68,6 +68,8 @@ type Controller struct {
 	broadcaster record.EventBroadcaster
 	recorder    record.EventRecorder
 
+	stageStatusRecorder *StageStatusRecorder
+
 	nodeCacheGetter      informer.Getter[*corev1.Node]
 	podCacheGetter       informer.Getter[*corev1.Pod]
 	nodeLeaseCacheGetter informer.Getter[*coordinationv1.Lease]
@@ -433,6 +435,7 @@ func (c *Controller) initNodeController(ctx context.Context, lifecycle resources
 		PlayStageParallelism:                  c.conf.NodePlayStageParallelism,
 		FuncMap:                               c.conf.FuncMap,
 		Recorder:                              c.recorder,
+		StageStatusRecorder:                   c.stageStatusRecorder,
 		ReadOnlyFunc:                          c.readOnlyFunc,
 		EnableMetrics:                         c.conf.EnableMetrics,
 	})
@@ -465,10 +468,11 @@ func (c *Controller) initPodController(ctx context.Context, lifecycle resources.
 
 			return c.nodes.Get(nodeName)
 		},
-		FuncMap:       c.conf.FuncMap,
-		Recorder:      c.recorder,
-		ReadOnlyFunc:  c.readOnlyFunc,
-		EnableMetrics: c.conf.EnableMetrics,
+		FuncMap:             c.conf.FuncMap,
+		Recorder:            c.recorder,
+		StageStatusRecorder: c.stageStatusRecorder,
+		ReadOnlyFunc:        c.readOnlyFunc,
+		EnableMetrics:       c.conf.EnableMetrics,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create pods controller: %w", err)
@@ -520,6 +524,7 @@ func (c *Controller) initStageController(ctx context.Context, ref internalversio
 		PlayStageParallelism:                  1,
 		FuncMap:                               c.conf.FuncMap,
 		Recorder:                              c.recorder,
+		StageStatusRecorder:                   c.stageStatusRecorder,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create stage controller: %w", err)
@@ -540,6 +545,22 @@ func (c *Controller) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to init controller: %w", err)
 	}
 
+	statusConf := StageStatusRecorderConfig{
+		Clock: c.conf.Clock,
+	}
+	if len(c.conf.LocalStages) == 0 {
+		// only the stages of the apiserver have the status subresource
+		statusConf.TypedKwokClient = c.conf.TypedKwokClient
+	}
+	c.stageStatusRecorder, err = NewStageStatusRecorder(statusConf)
+	if err != nil {
+		return fmt.Errorf("failed to create stage status recorder: %w", err)
+	}
+	err = c.stageStatusRecorder.Start(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start stage status recorder: %w", err)
+	}
+
 	if len(c.conf.LocalStages) != 0 {
 		for ref, stage := range c.conf.LocalStages {
 			lifecycle, err := lifecycle.NewLifecycle(stage)
@@ -31,6 +31,7 @@ import (
 	"k8s.io/utils/clock"
 	netutils "k8s.io/utils/net"
 
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config/resources"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
@@ -39,6 +40,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
@@ -62,6 +64,7 @@ type NodeController struct {
 	delayQueueMapping                     maps.SyncMap[string, resourceStageJob[*corev1.Node]]
 	backoff                               wait.Backoff
 	recorder                              record.EventRecorder
+	stageStatusRecorder                   *StageStatusRecorder
 	readOnlyFunc                          func(nodeName string) bool
 	enableMetrics                         bool
 }
@@ -81,6 +84,7 @@ type NodeControllerConfig struct {
 	PlayStageParallelism                  uint
 	FuncMap                               gotpl.FuncMap
 	Recorder                              record.EventRecorder
+	StageStatusRecorder                   *StageStatusRecorder
 	ReadOnlyFunc                          func(nodeName string) bool
 	EnableMetrics                         bool
 }
@@ -126,6 +130,7 @@ func NewNodeController(conf NodeControllerConfig) (*NodeController, error) {
 		playStageParallelism:                  conf.PlayStageParallelism,
 		preprocessChan:                        make(chan *corev1.Node),
 		recorder:                              conf.Recorder,
+		stageStatusRecorder:                   conf.StageStatusRecorder,
 		readOnlyFunc:                          conf.ReadOnlyFunc,
 		enableMetrics:                         conf.EnableMetrics,
 	}
@@ -201,6 +206,7 @@ loop:
 				}
 			case informer.Deleted:
 				node := event.Object
+				rand.Release(string(node.UID))
 				if _, has := c.nodesSets.Load(node.Name); has {
 					c.deleteNodeInfo(node)
 
@@ -294,6 +300,7 @@ func (c *NodeController) preprocess(ctx context.Context, node *corev1.Node) erro
 		)
 		return nil
 	}
+	c.stageStatusRecorder.Matched(stage.Name())
 
 	now := c.clock.Now()
 	delay, _ := stage.Delay(ctx, data, now)
@@ -329,14 +336,20 @@ func (c *NodeController) playStageWorker(ctx context.Context) {
 		}
 		c.delayQueueMapping.Delete(node.Key)
 		needRetry, err := c.playStage(ctx, node.Resource, node.Stage)
+		c.stageStatusRecorder.Played(node.Stage.Name(), err)
 		if err != nil {
 			logger.Error("failed to apply stage", err,
 				"node", node.Key,
 				"stage", node.Stage.Name(),
 			)
 		}
+		retryPolicy := node.Stage.RetryPolicy()
 		if needRetry {
 			retryCount := atomic.AddUint64(node.RetryCount, 1) - 1
+			if retryExhausted(retryPolicy, retryCount+1) {
+				c.onRetryExhausted(ctx, node.Resource, node.Stage, err)
+				continue
+			}
 			logger.Info("retrying for failed job",
 				"node", node.Key,
 				"stage", node.Stage.Name(),
@@ -344,8 +357,38 @@ func (c *NodeController) playStageWorker(ctx context.Context) {
 			)
 			// for failed jobs, we re-push them into the queue with a lower weight
 			// and a backoff period to avoid blocking normal tasks
-			retryDelay := backoffDelayByStep(retryCount, c.backoff)
+			retryDelay := backoffDelayByStep(retryCount, retryBackoff(retryPolicy, c.backoff))
 			c.addStageJob(ctx, node, retryDelay, 1)
+		} else if err != nil && retryPolicy != nil {
+			// the error is permanent, retrying would not help
+			c.onRetryExhausted(ctx, node.Resource, node.Stage, err)
+		}
+	}
+}
+
+// onRetryExhausted takes the action of the retry policy when the retries of the stage are exhausted
+func (c *NodeController) onRetryExhausted(ctx context.Context, node *corev1.Node, stage *lifecycle.Stage, err error) {
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"node", node.Name,
+		"stage", stage.Name(),
+	)
+	logger.Warn("retries exhausted, give up applying stage", "err", err)
+
+	switch stage.RetryPolicy().OnExhausted {
+	case internalversion.StageRetryExhaustedActionEvent:
+		if c.recorder != nil {
+			c.recorder.Event(&corev1.ObjectReference{
+				Kind:      "Node",
+				UID:       node.UID,
+				Name:      node.Name,
+				Namespace: "",
+			}, corev1.EventTypeWarning, retryExhaustedReason, retryExhaustedMessage(stage, err))
+		}
+	case internalversion.StageRetryExhaustedActionDelete:
+		err := c.deleteResource(ctx, node)
+		if err != nil {
+			logger.Error("failed to delete node", err)
 		}
 	}
 }
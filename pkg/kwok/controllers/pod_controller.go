@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config/resources"
 	"sigs.k8s.io/kwok/pkg/kwok/cni"
 	"sigs.k8s.io/kwok/pkg/log"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
@@ -67,6 +69,7 @@ type PodController struct {
 	backoff                               wait.Backoff
 	delayQueueMapping                     maps.SyncMap[string, resourceStageJob[*corev1.Pod]]
 	recorder                              record.EventRecorder
+	stageStatusRecorder                   *StageStatusRecorder
 	readOnlyFunc                          func(nodeName string) bool
 	enableMetrics                         bool
 }
@@ -91,6 +94,7 @@ type PodControllerConfig struct {
 	PlayStageParallelism                  uint
 	FuncMap                               gotpl.FuncMap
 	Recorder                              record.EventRecorder
+	StageStatusRecorder                   *StageStatusRecorder
 	ReadOnlyFunc                          func(nodeName string) bool
 	EnableMetrics                         bool
 }
@@ -131,6 +135,7 @@ func NewPodController(conf PodControllerConfig) (*PodController, error) {
 		playStageParallelism:                  conf.PlayStageParallelism,
 		preprocessChan:                        make(chan *corev1.Pod),
 		recorder:                              conf.Recorder,
+		stageStatusRecorder:                   conf.StageStatusRecorder,
 		readOnlyFunc:                          conf.ReadOnlyFunc,
 		enableMetrics:                         conf.EnableMetrics,
 	}
@@ -229,6 +234,7 @@ func (c *PodController) preprocess(ctx context.Context, pod *corev1.Pod) error {
 		)
 		return nil
 	}
+	c.stageStatusRecorder.Matched(stage.Name())
 
 	now := c.clock.Now()
 	delay, _ := stage.Delay(ctx, data, now)
@@ -264,14 +270,20 @@ func (c *PodController) playStageWorker(ctx context.Context) {
 		}
 		c.delayQueueMapping.Delete(pod.Key)
 		needRetry, err := c.playStage(ctx, pod.Resource, pod.Stage)
+		c.stageStatusRecorder.Played(pod.Stage.Name(), err)
 		if err != nil {
 			logger.Error("failed to apply stage", err,
 				"pod", pod.Key,
 				"stage", pod.Stage.Name(),
 			)
 		}
+		retryPolicy := pod.Stage.RetryPolicy()
 		if needRetry {
 			retryCount := atomic.AddUint64(pod.RetryCount, 1) - 1
+			if retryExhausted(retryPolicy, retryCount+1) {
+				c.onRetryExhausted(ctx, pod.Resource, pod.Stage, err)
+				continue
+			}
 			logger.Info("retrying for failed job",
 				"pod", pod.Key,
 				"stage", pod.Stage.Name(),
@@ -279,8 +291,38 @@ func (c *PodController) playStageWorker(ctx context.Context) {
 			)
 			// for failed jobs, we re-push them into the queue with a lower weight
 			// and a backoff period to avoid blocking normal tasks
-			retryDelay := backoffDelayByStep(retryCount, c.backoff)
+			retryDelay := backoffDelayByStep(retryCount, retryBackoff(retryPolicy, c.backoff))
 			c.addStageJob(ctx, pod, retryDelay, 1)
+		} else if err != nil && retryPolicy != nil {
+			// the error is permanent, retrying would not help
+			c.onRetryExhausted(ctx, pod.Resource, pod.Stage, err)
+		}
+	}
+}
+
+// onRetryExhausted takes the action of the retry policy when the retries of the stage are exhausted
+func (c *PodController) onRetryExhausted(ctx context.Context, pod *corev1.Pod, stage *lifecycle.Stage, err error) {
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"pod", log.KObj(pod),
+		"stage", stage.Name(),
+	)
+	logger.Warn("retries exhausted, give up applying stage", "err", err)
+
+	switch stage.RetryPolicy().OnExhausted {
+	case internalversion.StageRetryExhaustedActionEvent:
+		if c.recorder != nil {
+			c.recorder.Event(&corev1.ObjectReference{
+				Kind:      "Pod",
+				UID:       pod.UID,
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			}, corev1.EventTypeWarning, retryExhaustedReason, retryExhaustedMessage(stage, err))
+		}
+	case internalversion.StageRetryExhaustedActionDelete:
+		err := c.deleteResource(ctx, pod)
+		if err != nil {
+			logger.Error("failed to delete pod", err)
 		}
 	}
 }
@@ -455,6 +497,7 @@ loop:
 				}
 			case informer.Deleted:
 				pod := event.Object
+				rand.Release(string(pod.UID))
 				if c.enableMetrics {
 					c.deletePodInfo(pod)
 				}
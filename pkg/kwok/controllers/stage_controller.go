@@ -33,6 +33,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config/resources"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
@@ -42,6 +43,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	"sigs.k8s.io/kwok/pkg/utils/maps"
 	"sigs.k8s.io/kwok/pkg/utils/queue"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
@@ -62,6 +64,7 @@ type StageController struct {
 	backoff                               wait.Backoff
 	delayQueueMapping                     maps.SyncMap[string, resourceStageJob[*unstructured.Unstructured]]
 	recorder                              record.EventRecorder
+	stageStatusRecorder                   *StageStatusRecorder
 }
 
 // StageControllerConfig is the configuration for the StageController
@@ -77,6 +80,7 @@ type StageControllerConfig struct {
 	PlayStageParallelism                  uint
 	FuncMap                               gotpl.FuncMap
 	Recorder                              record.EventRecorder
+	StageStatusRecorder                   *StageStatusRecorder
 }
 
 // NewStageController creates a new fake resources controller
@@ -113,6 +117,7 @@ func NewStageController(conf StageControllerConfig) (*StageController, error) {
 		playStageParallelism:                  conf.PlayStageParallelism,
 		preprocessChan:                        make(chan *unstructured.Unstructured),
 		recorder:                              conf.Recorder,
+		stageStatusRecorder:                   conf.StageStatusRecorder,
 	}
 
 	c.renderer = gotpl.NewRenderer(conf.FuncMap)
@@ -206,6 +211,7 @@ func (c *StageController) preprocess(ctx context.Context, resource *unstructured
 		)
 		return nil
 	}
+	c.stageStatusRecorder.Matched(stage.Name())
 
 	now := c.clock.Now()
 	delay, _ := stage.Delay(ctx, data, now)
@@ -242,14 +248,20 @@ func (c *StageController) playStageWorker(ctx context.Context) {
 		}
 		c.delayQueueMapping.Delete(resource.Key)
 		needRetry, err := c.playStage(ctx, resource.Resource, resource.Stage)
+		c.stageStatusRecorder.Played(resource.Stage.Name(), err)
 		if err != nil {
 			logger.Error("failed to apply stage", err,
 				"resource", resource.Key,
 				"stage", resource.Stage.Name(),
 			)
 		}
+		retryPolicy := resource.Stage.RetryPolicy()
 		if needRetry {
 			retryCount := atomic.AddUint64(resource.RetryCount, 1) - 1
+			if retryExhausted(retryPolicy, retryCount+1) {
+				c.onRetryExhausted(ctx, resource.Resource, resource.Stage, err)
+				continue
+			}
 			logger.Info("retrying for failed job",
 				"resource", resource.Key,
 				"stage", resource.Stage.Name(),
@@ -257,8 +269,39 @@ func (c *StageController) playStageWorker(ctx context.Context) {
 			)
 			// for failed jobs, we re-push them into the queue with a lower weight
 			// and a backoff period to avoid blocking normal tasks
-			retryDelay := backoffDelayByStep(retryCount, c.backoff)
+			retryDelay := backoffDelayByStep(retryCount, retryBackoff(retryPolicy, c.backoff))
 			c.addStageJob(ctx, resource, retryDelay, 1)
+		} else if err != nil && retryPolicy != nil {
+			// the error is permanent, retrying would not help
+			c.onRetryExhausted(ctx, resource.Resource, resource.Stage, err)
+		}
+	}
+}
+
+// onRetryExhausted takes the action of the retry policy when the retries of the stage are exhausted
+func (c *StageController) onRetryExhausted(ctx context.Context, resource *unstructured.Unstructured, stage *lifecycle.Stage, err error) {
+	logger := log.FromContext(ctx)
+	logger = logger.With(
+		"resource", log.KObj(resource),
+		"stage", stage.Name(),
+	)
+	logger.Warn("retries exhausted, give up applying stage", "err", err)
+
+	switch stage.RetryPolicy().OnExhausted {
+	case internalversion.StageRetryExhaustedActionEvent:
+		if c.recorder != nil {
+			c.recorder.Event(&corev1.ObjectReference{
+				APIVersion: resource.GetAPIVersion(),
+				Kind:       resource.GetKind(),
+				UID:        resource.GetUID(),
+				Name:       resource.GetName(),
+				Namespace:  resource.GetNamespace(),
+			}, corev1.EventTypeWarning, retryExhaustedReason, retryExhaustedMessage(stage, err))
+		}
+	case internalversion.StageRetryExhaustedActionDelete:
+		err := c.deleteResource(ctx, resource)
+		if err != nil {
+			logger.Error("failed to delete resource", err)
 		}
 	}
 }
@@ -418,6 +461,7 @@ loop:
 
 			case informer.Deleted:
 				resource := event.Object
+				rand.Release(string(resource.GetUID()))
 				if c.need(resource) {
 					// Cancel delay job
 					key := log.KObj(resource).String()
@@ -20,19 +20,25 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config/resources"
 	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
 	"sigs.k8s.io/kwok/pkg/utils/informer"
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	"sigs.k8s.io/kwok/pkg/utils/yaml"
@@ -130,3 +136,131 @@ func TestStageController(t *testing.T) {
 		t.Fatalf("expected phase %q, got %q", corev1.VolumeAvailable, got.Status.Phase)
 	}
 }
+
+func TestStageControllerRetry(t *testing.T) {
+	testCases := []struct {
+		name             string
+		err              error
+		expectedAttempts int64
+	}{
+		{
+			name:             "transient error is retried until exhausted",
+			err:              apierrors.NewServiceUnavailable("unavailable"),
+			expectedAttempts: 3,
+		},
+		{
+			name:             "permanent error is not retried",
+			err:              apierrors.NewForbidden(corev1.Resource("persistentvolumes"), "pv-0", fmt.Errorf("denied")),
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			scheme.AddKnownTypes(corev1.SchemeGroupVersion, &corev1.PersistentVolume{})
+			client := fake.NewSimpleDynamicClient(scheme,
+				&corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pv-0",
+					},
+					Status: corev1.PersistentVolumeStatus{
+						Phase: corev1.VolumePending,
+					},
+				},
+			)
+
+			var attempts atomic.Int64
+			client.PrependReactor("patch", "persistentvolumes", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				attempts.Add(1)
+				return true, nil, tc.err
+			})
+
+			gvr := corev1.SchemeGroupVersion.WithResource("persistentvolumes")
+
+			lc, _ := lifecycle.NewLifecycle([]*internalversion.Stage{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "pv-available",
+					},
+					Spec: internalversion.StageSpec{
+						Selector: &internalversion.StageSelector{
+							MatchExpressions: []internalversion.SelectorRequirement{
+								{
+									Key:      ".status.phase",
+									Operator: "NotIn",
+									Values:   []string{"Available"},
+								},
+							},
+						},
+						Next: internalversion.StageNext{
+							Patches: []internalversion.StagePatch{
+								{
+									Template:    `phase: Available`,
+									Subresource: "status",
+									Root:        "status",
+								},
+							},
+						},
+						RetryPolicy: &internalversion.StageRetryPolicy{
+							MaxAttempts: format.Ptr[int64](3),
+							Backoff: &internalversion.StageBackoff{
+								DurationMilliseconds: format.Ptr[int64](10),
+								Factor:               format.Ptr[float64](1),
+							},
+							OnExhausted: internalversion.StageRetryExhaustedActionEvent,
+						},
+					},
+				},
+			})
+			recorder := record.NewFakeRecorder(10)
+			patchMeta, _ := strategicpatch.NewPatchMetaFromStruct(corev1.PersistentVolume{})
+			controller, err := NewStageController(StageControllerConfig{
+				PlayStageParallelism: 1,
+				GVR:                  gvr,
+				DynamicClient:        client,
+				Schema:               patchMeta,
+				Lifecycle:            resources.NewStaticGetter(lc),
+				Recorder:             recorder,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			ctx = log.NewContext(ctx, log.NewLogger(os.Stderr, log.LevelDebug))
+			ctx, cancel := context.WithTimeout(ctx, 1000*time.Second)
+			t.Cleanup(func() {
+				cancel()
+				time.Sleep(time.Second)
+			})
+
+			resourceCh := make(chan informer.Event[*unstructured.Unstructured], 1)
+
+			pvInformer := informer.NewInformer[*unstructured.Unstructured, *unstructured.UnstructuredList](client.Resource(gvr))
+			err = pvInformer.Watch(ctx, informer.Option{}, resourceCh)
+			if err != nil {
+				t.Fatal(fmt.Errorf("watch resource error: %w", err))
+			}
+
+			err = controller.Start(ctx, resourceCh)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case event := <-recorder.Events:
+				if !strings.Contains(event, retryExhaustedReason) {
+					t.Fatalf("expected event with reason %q, got %q", retryExhaustedReason, event)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("expected an event when the retries are exhausted")
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			if got := attempts.Load(); got != tc.expectedAttempts {
+				t.Fatalf("expected %d attempts, got %d", tc.expectedAttempts, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/client/clientset/versioned"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// StageStatusRecorderConfig is the configuration for the StageStatusRecorder
+type StageStatusRecorderConfig struct {
+	Clock clock.Clock
+	// TypedKwokClient is used to write the status of the Stage objects,
+	// the status is not written if it is nil.
+	TypedKwokClient versioned.Interface
+	Registerer      prometheus.Registerer
+	SyncInterval    time.Duration
+}
+
+// StageStatusRecorder aggregates how often stages are matched and applied,
+// exports them as metrics and writes them back to the status of the Stage objects.
+type StageStatusRecorder struct {
+	clock           clock.Clock
+	typedKwokClient versioned.Interface
+	syncInterval    time.Duration
+
+	mut      sync.Mutex
+	statuses map[string]*stageStatus
+
+	matchedTotal    *prometheus.CounterVec
+	appliedTotal    *prometheus.CounterVec
+	failedTotal     *prometheus.CounterVec
+	lastAppliedTime *prometheus.GaugeVec
+}
+
+type stageStatus struct {
+	matched         int64
+	applied         int64
+	lastError       string
+	lastAppliedTime time.Time
+	dirty           bool
+}
+
+// NewStageStatusRecorder creates a new StageStatusRecorder
+func NewStageStatusRecorder(conf StageStatusRecorderConfig) (*StageStatusRecorder, error) {
+	if conf.Clock == nil {
+		conf.Clock = clock.RealClock{}
+	}
+	if conf.Registerer == nil {
+		conf.Registerer = prometheus.DefaultRegisterer
+	}
+	if conf.SyncInterval <= 0 {
+		conf.SyncInterval = 10 * time.Second
+	}
+
+	r := &StageStatusRecorder{
+		clock:           conf.Clock,
+		typedKwokClient: conf.TypedKwokClient,
+		syncInterval:    conf.SyncInterval,
+		statuses:        map[string]*stageStatus{},
+	}
+
+	var err error
+	r.matchedTotal, err = registerCollector(conf.Registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kwok_stage_matched_total",
+		Help: "Total number of times resources matched the stage",
+	}, []string{"stage"}))
+	if err != nil {
+		return nil, err
+	}
+	r.appliedTotal, err = registerCollector(conf.Registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kwok_stage_applied_total",
+		Help: "Total number of times the stage was applied",
+	}, []string{"stage"}))
+	if err != nil {
+		return nil, err
+	}
+	r.failedTotal, err = registerCollector(conf.Registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kwok_stage_failed_total",
+		Help: "Total number of times the stage failed to be applied",
+	}, []string{"stage"}))
+	if err != nil {
+		return nil, err
+	}
+	r.lastAppliedTime, err = registerCollector(conf.Registerer, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kwok_stage_last_applied_timestamp_seconds",
+		Help: "Last time the stage was applied in unix seconds",
+	}, []string{"stage"}))
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// registerCollector registers the collector, or returns the already registered one
+func registerCollector[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	err := registerer.Register(collector)
+	if err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+		return collector, fmt.Errorf("failed to register metrics: %w", err)
+	}
+	return collector, nil
+}
+
+// Start starts writing the status of the Stage objects periodically
+func (r *StageStatusRecorder) Start(ctx context.Context) error {
+	if r.typedKwokClient == nil {
+		return nil
+	}
+	err := r.restore(ctx)
+	if err != nil {
+		logger := log.FromContext(ctx)
+		logger.Warn("failed to restore stage status, the counters start from zero", "err", err)
+	}
+	go r.syncWorker(ctx)
+	return nil
+}
+
+// restore continues the counters from the status of the Stage objects,
+// so that they are not reset when the controller restarts
+func (r *StageStatusRecorder) restore(ctx context.Context) error {
+	list, err := r.typedKwokClient.KwokV1alpha1().Stages().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list stages: %w", err)
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	for _, stage := range list.Items {
+		status := r.get(stage.Name)
+		status.matched += stage.Status.Matched
+		status.applied += stage.Status.Applied
+		if status.lastError == "" {
+			status.lastError = stage.Status.LastError
+		}
+		if status.lastAppliedTime.IsZero() && stage.Status.LastAppliedTime != nil {
+			status.lastAppliedTime = stage.Status.LastAppliedTime.Time
+		}
+	}
+	return nil
+}
+
+// Matched records that a resource matched the stage
+func (r *StageStatusRecorder) Matched(stageName string) {
+	if r == nil {
+		return
+	}
+	r.matchedTotal.WithLabelValues(stageName).Inc()
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	status := r.get(stageName)
+	status.matched++
+	status.dirty = true
+}
+
+// Played records the result of applying the stage
+func (r *StageStatusRecorder) Played(stageName string, err error) {
+	if r == nil {
+		return
+	}
+	now := r.clock.Now()
+	if err != nil {
+		r.failedTotal.WithLabelValues(stageName).Inc()
+	} else {
+		r.appliedTotal.WithLabelValues(stageName).Inc()
+		r.lastAppliedTime.WithLabelValues(stageName).Set(float64(now.Unix()))
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	status := r.get(stageName)
+	if err != nil {
+		status.lastError = err.Error()
+	} else {
+		status.applied++
+		status.lastAppliedTime = now
+		status.lastError = ""
+	}
+	status.dirty = true
+}
+
+func (r *StageStatusRecorder) get(stageName string) *stageStatus {
+	status, ok := r.statuses[stageName]
+	if !ok {
+		status = &stageStatus{}
+		r.statuses[stageName] = status
+	}
+	return status
+}
+
+// dirtyStatuses returns the statuses changed since the last call
+func (r *StageStatusRecorder) dirtyStatuses() map[string]v1alpha1.StageStatus {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	out := map[string]v1alpha1.StageStatus{}
+	for name, status := range r.statuses {
+		if !status.dirty {
+			continue
+		}
+		status.dirty = false
+		s := v1alpha1.StageStatus{
+			Matched:   status.matched,
+			Applied:   status.applied,
+			LastError: status.lastError,
+		}
+		if !status.lastAppliedTime.IsZero() {
+			s.LastAppliedTime = &metav1.Time{Time: status.lastAppliedTime}
+		}
+		out[name] = s
+	}
+	return out
+}
+
+func (r *StageStatusRecorder) syncWorker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.clock.After(r.syncInterval):
+			r.sync(ctx)
+		}
+	}
+}
+
+func (r *StageStatusRecorder) sync(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for name, status := range r.dirtyStatuses() {
+		data, err := json.Marshal(map[string]any{
+			"status": stageStatusPatch(status),
+		})
+		if err != nil {
+			logger.Error("failed to marshal stage status", err, "stage", name)
+			continue
+		}
+		_, err = r.typedKwokClient.KwokV1alpha1().Stages().Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{}, "status")
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// the stage is not an object of the apiserver or has been deleted
+				r.mut.Lock()
+				delete(r.statuses, name)
+				r.mut.Unlock()
+				continue
+			}
+			logger.Error("failed to patch stage status", err, "stage", name)
+			r.mut.Lock()
+			if s, ok := r.statuses[name]; ok {
+				s.dirty = true
+			}
+			r.mut.Unlock()
+		}
+	}
+}
+
+// stageStatusPatch returns the merge patch of the status,
+// the last error is removed explicitly when it is cleared by a later success.
+func stageStatusPatch(status v1alpha1.StageStatus) map[string]any {
+	patch := map[string]any{
+		"matched":   status.Matched,
+		"applied":   status.Applied,
+		"lastError": nil,
+	}
+	if status.LastError != "" {
+		patch["lastError"] = status.LastError
+	}
+	if status.LastAppliedTime != nil {
+		patch["lastAppliedTime"] = status.LastAppliedTime
+	}
+	return patch
+}
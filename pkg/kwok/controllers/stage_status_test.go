@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/client/clientset/versioned/fake"
+)
+
+func TestStageStatusRecorder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(
+		&v1alpha1.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-ready",
+			},
+		},
+	)
+
+	r, err := NewStageStatusRecorder(StageStatusRecorderConfig{
+		Clock:           clocktesting.NewFakeClock(now),
+		TypedKwokClient: client,
+		Registerer:      prometheus.NewRegistry(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create stage status recorder: %v", err)
+	}
+
+	r.Matched("pod-ready")
+	r.Matched("pod-ready")
+	r.Played("pod-ready", nil)
+	r.Played("pod-ready", errors.New("failed to patch"))
+	r.Matched("pod-unknown")
+	r.sync(ctx)
+
+	stage, err := client.KwokV1alpha1().Stages().Get(ctx, "pod-ready", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get stage: %v", err)
+	}
+	status := stage.Status
+	if status.Matched != 2 || status.Applied != 1 || status.LastError != "failed to patch" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if status.LastAppliedTime == nil || !status.LastAppliedTime.Time.Equal(now) {
+		t.Errorf("unexpected last applied time: %v", status.LastAppliedTime)
+	}
+
+	if got := testutil.ToFloat64(r.matchedTotal.WithLabelValues("pod-ready")); got != 2 {
+		t.Errorf("unexpected matched total: %v", got)
+	}
+	if got := testutil.ToFloat64(r.appliedTotal.WithLabelValues("pod-ready")); got != 1 {
+		t.Errorf("unexpected applied total: %v", got)
+	}
+	if got := testutil.ToFloat64(r.failedTotal.WithLabelValues("pod-ready")); got != 1 {
+		t.Errorf("unexpected failed total: %v", got)
+	}
+
+	if len(r.dirtyStatuses()) != 0 {
+		t.Errorf("expected no dirty statuses after sync")
+	}
+	if _, ok := r.statuses["pod-unknown"]; ok {
+		t.Errorf("expected the status of unknown stage to be dropped")
+	}
+}
+
+func TestStageStatusRecorderRestore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(
+		&v1alpha1.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-ready",
+			},
+			Status: v1alpha1.StageStatus{
+				Matched:   10,
+				Applied:   9,
+				LastError: "failed to patch",
+			},
+		},
+	)
+
+	r, err := NewStageStatusRecorder(StageStatusRecorderConfig{
+		Clock:           clocktesting.NewFakeClock(now),
+		TypedKwokClient: client,
+		Registerer:      prometheus.NewRegistry(),
+	})
+	if err != nil {
+		t.Fatalf("failed to create stage status recorder: %v", err)
+	}
+	err = r.restore(ctx)
+	if err != nil {
+		t.Fatalf("failed to restore stage status: %v", err)
+	}
+
+	r.Matched("pod-ready")
+	r.Played("pod-ready", nil)
+	r.sync(ctx)
+
+	stage, err := client.KwokV1alpha1().Stages().Get(ctx, "pod-ready", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get stage: %v", err)
+	}
+	status := stage.Status
+	if status.Matched != 11 || status.Applied != 10 {
+		t.Errorf("expected the counters to continue from the status, got %+v", status)
+	}
+	if status.LastError != "" {
+		t.Errorf("expected the last error to be cleared after a success, got %q", status.LastError)
+	}
+}
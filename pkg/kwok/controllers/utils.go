@@ -32,6 +32,7 @@ import (
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/utils/lifecycle"
 	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
 	"sigs.k8s.io/kwok/pkg/utils/wait"
@@ -142,6 +143,44 @@ func backoffDelayByStep(steps uint64, c wait.Backoff) time.Duration {
 	return wait.Jitter(time.Duration(delay), c.Jitter)
 }
 
+// retryBackoff returns the backoff of the retry policy, unset fields are taken from the given backoff
+func retryBackoff(policy *internalversion.StageRetryPolicy, backoff wait.Backoff) wait.Backoff {
+	if policy == nil || policy.Backoff == nil {
+		return backoff
+	}
+	b := policy.Backoff
+	if b.DurationMilliseconds != nil {
+		backoff.Duration = time.Duration(*b.DurationMilliseconds) * time.Millisecond
+	}
+	if b.Factor != nil {
+		backoff.Factor = *b.Factor
+	}
+	if b.Jitter != nil {
+		backoff.Jitter = *b.Jitter
+	}
+	if b.CapMilliseconds != nil {
+		backoff.Cap = time.Duration(*b.CapMilliseconds) * time.Millisecond
+	}
+	return backoff
+}
+
+// retryExhaustedReason is the reason of the event sent when the retries of the stage are exhausted
+const retryExhaustedReason = "StageRetryExhausted"
+
+// retryExhaustedMessage returns the message of the event sent when the retries of the stage are exhausted
+func retryExhaustedMessage(stage *lifecycle.Stage, err error) string {
+	return fmt.Sprintf("Failed to apply stage %s after retries: %v", stage.Name(), err)
+}
+
+// retryExhausted determines if the attempts of the retry policy are exhausted,
+// attempts is the number of times the stage has been applied, including the first one.
+func retryExhausted(policy *internalversion.StageRetryPolicy, attempts uint64) bool {
+	if policy == nil || policy.MaxAttempts == nil || *policy.MaxAttempts <= 0 {
+		return false
+	}
+	return attempts >= uint64(*policy.MaxAttempts)
+}
+
 // shouldRetry determines if a certain error needs to be retried
 func shouldRetry(err error) bool {
 	// if apiserver is not reachable
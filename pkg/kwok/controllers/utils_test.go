@@ -23,9 +23,14 @@ import (
 	"reflect"
 	"syscall"
 	"testing"
+	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
 )
 
 func Test_parseCIDR(t *testing.T) {
@@ -188,3 +193,96 @@ func Test_shouldRetry(t *testing.T) {
 		})
 	}
 }
+
+func Test_retryBackoff(t *testing.T) {
+	backoff := defaultBackoff()
+	var testCases = []struct {
+		name     string
+		input    *internalversion.StageRetryPolicy
+		expected wait.Backoff
+	}{
+		{
+			name:     "no policy",
+			input:    nil,
+			expected: backoff,
+		},
+		{
+			name:     "no backoff",
+			input:    &internalversion.StageRetryPolicy{MaxAttempts: format.Ptr[int64](3)},
+			expected: backoff,
+		},
+		{
+			name: "partial backoff",
+			input: &internalversion.StageRetryPolicy{
+				Backoff: &internalversion.StageBackoff{
+					DurationMilliseconds: format.Ptr[int64](100),
+					Jitter:               format.Ptr[float64](0),
+				},
+			},
+			expected: wait.Backoff{Duration: 100 * time.Millisecond, Factor: 2.0, Jitter: 0, Cap: 32 * time.Minute},
+		},
+		{
+			name: "full backoff",
+			input: &internalversion.StageRetryPolicy{
+				Backoff: &internalversion.StageBackoff{
+					DurationMilliseconds: format.Ptr[int64](100),
+					Factor:               format.Ptr[float64](1.5),
+					Jitter:               format.Ptr[float64](0.1),
+					CapMilliseconds:      format.Ptr[int64](1000),
+				},
+			},
+			expected: wait.Backoff{Duration: 100 * time.Millisecond, Factor: 1.5, Jitter: 0.1, Cap: time.Second},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := retryBackoff(tc.input, backoff)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected: %v, got: %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func Test_retryExhausted(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		input    *internalversion.StageRetryPolicy
+		attempts uint64
+		expected bool
+	}{
+		{
+			name:     "no policy",
+			input:    nil,
+			attempts: 100,
+			expected: false,
+		},
+		{
+			name:     "unlimited",
+			input:    &internalversion.StageRetryPolicy{MaxAttempts: format.Ptr[int64](0)},
+			attempts: 100,
+			expected: false,
+		},
+		{
+			name:     "not exhausted",
+			input:    &internalversion.StageRetryPolicy{MaxAttempts: format.Ptr[int64](3)},
+			attempts: 2,
+			expected: false,
+		},
+		{
+			name:     "exhausted",
+			input:    &internalversion.StageRetryPolicy{MaxAttempts: format.Ptr[int64](3)},
+			attempts: 3,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if retryExhausted(tc.input, tc.attempts) != tc.expected {
+				t.Errorf("expected: %t", tc.expected)
+			}
+		})
+	}
+}
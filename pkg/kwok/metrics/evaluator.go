@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/kwok/pkg/utils/cel"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
@@ -198,6 +199,17 @@ func resultUniqueKey(node *corev1.Node, pod *corev1.Pod, container *corev1.Conta
 	return strings.Join(tmp, "/")
 }
 
+// randKey returns the key of the random number generator, which is the uid of the innermost resource
+func randKey(node *corev1.Node, pod *corev1.Pod) string {
+	if pod != nil {
+		return string(pod.UID)
+	}
+	if node != nil {
+		return string(node.UID)
+	}
+	return ""
+}
+
 func (e *Evaluator) evaluate(ctx context.Context, data Data) (cel.Val, error) {
 	var key string
 	if e.latestCacheVer != nil {
@@ -216,9 +228,11 @@ func (e *Evaluator) evaluate(ctx context.Context, data Data) (cel.Val, error) {
 	}
 
 	refVal, _, err := e.program.ContextEval(ctx, map[string]any{
-		"node":      data.Node,
-		"pod":       data.Pod,
-		"container": data.Container,
+		"node":              data.Node,
+		"pod":               data.Pod,
+		"container":         data.Container,
+		cel.RandKeyName:     randKey(data.Node, data.Pod),
+		cel.RandPurposeName: string(rand.PurposeMetricExpression),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate metric expression: %w", err)
@@ -48,9 +48,12 @@ func (s *Server) ExecInContainer(ctx context.Context, name string, uid types.UID
 		return err
 	}
 
-	// Currently only support local exec.
+	if execTarget.Script != nil {
+		return s.execScript(ctx, execTarget.Script, podName, podNamespace, container, cmd, out, errOut)
+	}
+
 	if execTarget.Local == nil {
-		return fmt.Errorf("not set local exec")
+		return fmt.Errorf("not set local or script exec")
 	}
 
 	// Set the environment variables.
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	utilsexec "k8s.io/utils/exec"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+// execScript responds to the command with the canned response of the script, nothing is run on the host.
+func (s *Server) execScript(ctx context.Context, script *internalversion.ExecTargetScript, podName, podNamespace, container string, cmd []string, out, errOut io.Writer) error {
+	if len(cmd) == 0 {
+		return fmt.Errorf("no command to exec")
+	}
+	resp, err := s.matchExecScript(script, cmd)
+	if err != nil {
+		return err
+	}
+	if errOut == nil {
+		// the stderr is merged into the stdout with tty
+		errOut = out
+	}
+	if resp == nil {
+		if errOut != nil {
+			_, _ = fmt.Fprintf(errOut, "%s: command not found\n", cmd[0])
+		}
+		return utilsexec.CodeExitError{
+			Err:  fmt.Errorf("command %q not found in script", strings.Join(cmd, " ")),
+			Code: 127,
+		}
+	}
+
+	if resp.DelayMilliseconds != nil && *resp.DelayMilliseconds > 0 {
+		t := time.NewTimer(time.Duration(*resp.DelayMilliseconds) * time.Millisecond)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+
+	var pod *corev1.Pod
+	if s.podCacheGetter != nil {
+		pod, _ = s.podCacheGetter.GetWithNamespace(podName, podNamespace)
+	}
+	renderer := gotpl.NewRenderer(gotpl.FuncMap{
+		"Container": func() string {
+			return container
+		},
+		"Command": func() []string {
+			return cmd
+		},
+	})
+
+	if resp.Stdout != "" && out != nil {
+		data, err := renderScriptOutput(renderer, resp.Stdout, pod)
+		if err != nil {
+			return fmt.Errorf("failed to render stdout: %w", err)
+		}
+		_, err = out.Write(data)
+		if err != nil {
+			return err
+		}
+	}
+	if resp.Stderr != "" && errOut != nil {
+		data, err := renderScriptOutput(renderer, resp.Stderr, pod)
+		if err != nil {
+			return fmt.Errorf("failed to render stderr: %w", err)
+		}
+		_, err = errOut.Write(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if resp.ExitCode != 0 {
+		return utilsexec.CodeExitError{
+			Err:  fmt.Errorf("command terminated with exit code %d", resp.ExitCode),
+			Code: int(resp.ExitCode),
+		}
+	}
+	return nil
+}
+
+// loadExecTargets compiles the regexes of the script rules of the exec targets,
+// so that an invalid one is reported when the exec targets are loaded instead of on every exec.
+func (s *Server) loadExecTargets(targets []internalversion.ExecTarget) error {
+	for _, target := range targets {
+		if target.Script == nil {
+			continue
+		}
+		for i, rule := range target.Script.Rules {
+			if rule.Regex == "" {
+				continue
+			}
+			_, err := s.compileExecScriptRegex(rule.Regex)
+			if err != nil {
+				return fmt.Errorf("invalid regex %q of script rule %d: %w", rule.Regex, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) compileExecScriptRegex(expr string) (*regexp.Regexp, error) {
+	if re, ok := s.execScriptRegexps.Load(expr); ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	s.execScriptRegexps.Store(expr, re)
+	return re, nil
+}
+
+// matchExecScript returns the response of the first rule that matches the command,
+// or the default response if no rule matches.
+func (s *Server) matchExecScript(script *internalversion.ExecTargetScript, cmd []string) (*internalversion.ExecScriptResponse, error) {
+	for i, rule := range script.Rules {
+		switch {
+		case len(rule.Command) != 0:
+			if !equalArgs(rule.Command, cmd) {
+				continue
+			}
+		case len(rule.Prefix) != 0:
+			if len(cmd) < len(rule.Prefix) || !equalArgs(rule.Prefix, cmd[:len(rule.Prefix)]) {
+				continue
+			}
+		case rule.Regex != "":
+			re, err := s.compileExecScriptRegex(rule.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex %q of script rule %d: %w", rule.Regex, i, err)
+			}
+			if !re.MatchString(strings.Join(cmd, " ")) {
+				continue
+			}
+		default:
+			// a rule without a matcher matches all commands
+		}
+		return &script.Rules[i].Response, nil
+	}
+	return script.Default, nil
+}
+
+// renderScriptOutput renders the output and keeps its surrounding whitespace,
+// which the renderer trims but is significant for the output of a command.
+func renderScriptOutput(renderer gotpl.Renderer, text string, pod *corev1.Pod) ([]byte, error) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return []byte(text), nil
+	}
+	data, err := renderer.ToText(trimmed, pod)
+	if err != nil {
+		return nil, err
+	}
+	start := strings.Index(text, trimmed)
+	buf := make([]byte, 0, len(text)-len(trimmed)+len(data))
+	buf = append(buf, text[:start]...)
+	buf = append(buf, data...)
+	buf = append(buf, text[start+len(trimmed):]...)
+	return buf, nil
+}
+
+func equalArgs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilsexec "k8s.io/utils/exec"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_execScript(t *testing.T) {
+	script := &internalversion.ExecTargetScript{
+		Rules: []internalversion.ExecScriptRule{
+			{
+				Command: []string{"cat", "/etc/hostname"},
+				Response: internalversion.ExecScriptResponse{
+					Stdout: "{{ Container }}\n",
+				},
+			},
+			{
+				Prefix: []string{"ls"},
+				Response: internalversion.ExecScriptResponse{
+					Stdout: "bin\netc\n",
+				},
+			},
+			{
+				Regex: `^sh -c exit \d+$`,
+				Response: internalversion.ExecScriptResponse{
+					Stderr:   "failed\n",
+					ExitCode: 2,
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		script     *internalversion.ExecTargetScript
+		cmd        []string
+		wantStdout string
+		wantStderr string
+		wantCode   int
+	}{
+		{
+			name:       "exact command",
+			script:     script,
+			cmd:        []string{"cat", "/etc/hostname"},
+			wantStdout: "app\n",
+		},
+		{
+			name:       "prefix",
+			script:     script,
+			cmd:        []string{"ls", "-l", "/"},
+			wantStdout: "bin\netc\n",
+		},
+		{
+			name:       "regex with exit code",
+			script:     script,
+			cmd:        []string{"sh", "-c", "exit", "3"},
+			wantStderr: "failed\n",
+			wantCode:   2,
+		},
+		{
+			name:       "not found",
+			script:     script,
+			cmd:        []string{"cat", "/etc/hosts"},
+			wantStderr: "cat: command not found\n",
+			wantCode:   127,
+		},
+		{
+			name: "default",
+			script: &internalversion.ExecTargetScript{
+				Default: &internalversion.ExecScriptResponse{
+					Stdout: "{{ index Command 0 }}\n",
+				},
+			},
+			cmd:        []string{"whoami"},
+			wantStdout: "whoami\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			stdout := bytes.NewBuffer(nil)
+			stderr := bytes.NewBuffer(nil)
+			err := s.execScript(context.Background(), tt.script, "pod", "default", "app", tt.cmd, stdout, stderr)
+			code := 0
+			if err != nil {
+				var exitErr utilsexec.CodeExitError
+				if !errors.As(err, &exitErr) {
+					t.Fatalf("execScript() unexpected error = %v", err)
+				}
+				code = exitErr.ExitStatus()
+			}
+			if code != tt.wantCode {
+				t.Errorf("execScript() exit code = %d, want %d", code, tt.wantCode)
+			}
+			if got := stdout.String(); got != tt.wantStdout {
+				t.Errorf("execScript() stdout = %q, want %q", got, tt.wantStdout)
+			}
+			if got := stderr.String(); got != tt.wantStderr {
+				t.Errorf("execScript() stderr = %q, want %q", got, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_execScriptEmptyCommand(t *testing.T) {
+	s := &Server{}
+	script := &internalversion.ExecTargetScript{
+		Default: &internalversion.ExecScriptResponse{},
+	}
+	err := s.execScript(context.Background(), script, "pod", "default", "app", nil, bytes.NewBuffer(nil), bytes.NewBuffer(nil))
+	if err == nil {
+		t.Errorf("execScript() expected an error for an empty command")
+	}
+}
+
+func TestNewServerInvalidExecScriptRegex(t *testing.T) {
+	execs := []*internalversion.Exec{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "default",
+			},
+			Spec: internalversion.ExecSpec{
+				Execs: []internalversion.ExecTarget{
+					{
+						Script: &internalversion.ExecTargetScript{
+							Rules: []internalversion.ExecScriptRule{
+								{Regex: `^sh -c (exit`},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	_, err := NewServer(Config{Execs: execs})
+	if err == nil {
+		t.Fatalf("NewServer() expected an error for an invalid regex")
+	}
+
+	execs[0].Spec.Execs[0].Script.Rules[0].Regex = `^sh -c exit \d+$`
+	s, err := NewServer(Config{Execs: execs})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	if _, ok := s.execScriptRegexps.Load(`^sh -c exit \d+$`); !ok {
+		t.Errorf("NewServer() expected the regex to be compiled")
+	}
+}
@@ -17,14 +17,21 @@ limitations under the License.
 package server
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/emicklei/go-restful/v3"
+	"github.com/nxadm/tail"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +43,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/slices"
+	utilstail "sigs.k8s.io/kwok/pkg/utils/tail"
 )
 
 // GetContainerLogs returns logs for a container in a pod.
@@ -46,11 +54,25 @@ func (s *Server) GetContainerLogs(ctx context.Context, podName, podNamespace, co
 		return err
 	}
 
-	opts := crilogs.NewLogOptions(logOptions, time.Now())
+	if log.Generator != nil && !logOptions.Previous {
+		return s.generateLogs(ctx, log.Generator, podName, podNamespace, container, logOptions, stdout)
+	}
+
 	logsFile := log.LogsFile
 	if logOptions.Previous {
 		logsFile = log.PreviousLogsFile
 	}
+	if logsFile != "" {
+		plain, err := isPlainLogs(logsFile)
+		if err != nil {
+			return err
+		}
+		if plain {
+			return readPlainLogs(ctx, logsFile, logOptions, stdout)
+		}
+	}
+
+	opts := crilogs.NewLogOptions(logOptions, time.Now())
 	return readLogs(ctx, logsFile, opts, stdout, stderr)
 }
 
@@ -157,3 +179,136 @@ var errUnavailable = status.Error(codes.Unavailable, "Unavailable")
 func (runtimeServiceStub) ContainerStatus(ctx context.Context, containerID string, verbose bool) (*runtimeapi.ContainerStatusResponse, error) {
 	return nil, errUnavailable
 }
+
+// isPlainLogs returns whether the first line of the logs file is neither in the CRI format
+// "<timestamp> <stream> <tag> <log>" nor in the docker json-file format, which are read by crilogs.ReadLogs.
+func isPlainLogs(logsFile string) (bool, error) {
+	f, err := os.Open(logsFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to open logs file %q: %w", logsFile, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read logs file %q: %w", logsFile, err)
+	}
+	line = strings.TrimSuffix(line, "\n")
+	if line == "" {
+		return false, nil
+	}
+
+	parts := strings.SplitN(line, " ", 4)
+	if len(parts) >= 3 && isLogStream(parts[1]) {
+		if _, err := time.Parse(crilogs.RFC3339NanoLenient, parts[0]); err == nil {
+			return false, nil
+		}
+	}
+
+	var jsonLog struct {
+		Stream string `json:"stream"`
+	}
+	if json.Unmarshal([]byte(line), &jsonLog) == nil && isLogStream(jsonLog.Stream) {
+		return false, nil
+	}
+	return true, nil
+}
+
+func isLogStream(stream string) bool {
+	return stream == "stdout" || stream == "stderr"
+}
+
+// readPlainLogs writes the lines of a logs file in no log format to stdout as they are.
+// There is no timestamp in the lines, so the lines already in the file are timestamped with
+// the modification time of the file, and the followed lines with the time they are read,
+// that is, --since selects either all or none of the lines already in the file.
+func readPlainLogs(ctx context.Context, logsFile string, logOptions *corev1.PodLogOptions, stdout io.Writer) error {
+	f, err := os.Open(logsFile)
+	if err != nil {
+		return fmt.Errorf("failed to open logs file %q: %w", logsFile, err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat logs file %q: %w", logsFile, err)
+	}
+	var tailLines int64 = -1
+	if logOptions.TailLines != nil {
+		tailLines = *logOptions.TailLines
+	}
+	start, err := utilstail.FindTailLineStartIndex(f, tailLines)
+	_ = f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to tail %d lines of logs file %q: %w", tailLines, logsFile, err)
+	}
+
+	t, err := tail.TailFile(logsFile, tail.Config{
+		Location: &tail.SeekInfo{
+			Offset: start,
+			Whence: io.SeekStart,
+		},
+		MustExist:     true,
+		ReOpen:        logOptions.Follow,
+		Follow:        logOptions.Follow,
+		CompleteLines: true,
+		Logger:        tail.DiscardingLogger,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tail logs file %q: %w", logsFile, err)
+	}
+	defer func() {
+		_ = t.Stop()
+	}()
+
+	// the timestamps are the real time of the file, not the time of the simulated clock
+	var since time.Time
+	if logOptions.SinceSeconds != nil {
+		since = time.Now().Add(-time.Duration(*logOptions.SinceSeconds) * time.Second)
+	} else if logOptions.SinceTime != nil {
+		since = logOptions.SinceTime.Time
+	}
+	var remain int64 = math.MaxInt64
+	if logOptions.LimitBytes != nil {
+		remain = *logOptions.LimitBytes
+	}
+
+	offset := start
+	for remain > 0 {
+		var line *tail.Line
+		select {
+		case <-ctx.Done():
+			return nil
+		case line = <-t.Lines:
+		}
+		if line == nil {
+			return nil
+		}
+		if line.Err != nil {
+			return fmt.Errorf("failed to read logs file %q: %w", logsFile, line.Err)
+		}
+
+		ts := line.Time
+		if offset < fi.Size() {
+			ts = fi.ModTime()
+		}
+		offset += int64(len(line.Text)) + 1
+		if ts.Before(since) {
+			continue
+		}
+
+		data := line.Text + "\n"
+		if logOptions.Timestamps {
+			data = ts.Format(crilogs.RFC3339NanoFixed) + " " + data
+		}
+		if int64(len(data)) > remain {
+			data = data[:remain]
+		}
+		n, err := io.WriteString(stdout, data)
+		remain -= int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"maps"
+	"math/rand"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/cel"
+	"sigs.k8s.io/kwok/pkg/utils/clock"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+const (
+	celLogsPodName       = "pod"
+	celLogsContainerName = "container"
+	celLogsLineName      = "line"
+	celLogsTimeName      = "time"
+)
+
+// defaultLogsBacklogLines is the number of the latest lines written
+// when none of tail, since and limitBytes is set, the lines since the container started are unbounded otherwise.
+const defaultLogsBacklogLines = 10000
+
+// newLogsCELEnvironment returns the environment used to compile the CEL expressions of the log generator,
+// Rand() of the CEL expression returns the random numbers of the line being evaluated.
+func newLogsCELEnvironment(curRand func() *rand.Rand) (*cel.Environment, error) {
+	funcs := maps.Clone(cel.DefaultFuncs)
+	funcs["Rand"] = []any{
+		func(_, _ string) float64 {
+			return curRand().Float64()
+		},
+	}
+	return cel.NewEnvironment(cel.EnvironmentConfig{
+		Types:       cel.DefaultTypes,
+		Conversions: cel.DefaultConversions,
+		Funcs:       funcs,
+		Methods:     cel.FuncsToMethods(cel.DefaultFuncs),
+		Vars: map[string]any{
+			celLogsPodName:       corev1.Pod{},
+			celLogsContainerName: "",
+			celLogsLineName:      int64(0),
+			celLogsTimeName:      time.Time{},
+		},
+	})
+}
+
+// logGenerator generates the lines of a container,
+// the line i is generated at start + i * interval and is the same for each request.
+type logGenerator struct {
+	pod       *corev1.Pod
+	container string
+	format    internalversion.LogFormat
+	start     time.Time
+	interval  time.Duration
+	seed      int64
+
+	render func(ctx context.Context, line int64, t time.Time, r *rand.Rand) (string, error)
+}
+
+func (s *Server) newLogGenerator(conf *internalversion.LogGenerator, podName, podNamespace, container string) (*logGenerator, error) {
+	var pod *corev1.Pod
+	if s.podCacheGetter != nil {
+		pod, _ = s.podCacheGetter.GetWithNamespace(podName, podNamespace)
+	}
+	if pod == nil {
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: podNamespace,
+			},
+		}
+	}
+
+	linesPerSecond := 1.0
+	if conf.LinesPerSecond != nil {
+		linesPerSecond = *conf.LinesPerSecond
+	}
+	if linesPerSecond <= 0 {
+		return nil, fmt.Errorf("invalid lines per second %v", linesPerSecond)
+	}
+	interval := time.Duration(float64(time.Second) / linesPerSecond)
+	if interval <= 0 {
+		interval = 1
+	}
+
+	h := fnv.New64a()
+	if pod.UID != "" {
+		_, _ = h.Write([]byte(pod.UID))
+	} else {
+		_, _ = h.Write([]byte(podNamespace + "/" + podName))
+	}
+	_, _ = h.Write([]byte("/" + container))
+	seed := int64(h.Sum64())
+	if conf.Seed != nil {
+		seed ^= *conf.Seed
+	}
+
+	g := &logGenerator{
+		pod:       pod,
+		container: container,
+		format:    conf.Format,
+		start:     containerStartTime(pod, container),
+		interval:  interval,
+		seed:      seed,
+	}
+
+	switch {
+	case conf.Expression != "":
+		var curRand *rand.Rand
+		env, err := newLogsCELEnvironment(func() *rand.Rand {
+			return curRand
+		})
+		if err != nil {
+			return nil, err
+		}
+		program, err := env.Compile(conf.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile log expression: %w", err)
+		}
+		g.render = func(ctx context.Context, line int64, t time.Time, r *rand.Rand) (string, error) {
+			curRand = r
+			val, _, err := program.ContextEval(ctx, map[string]any{
+				celLogsPodName:       pod,
+				celLogsContainerName: container,
+				celLogsLineName:      line,
+				celLogsTimeName:      t,
+			})
+			if err != nil {
+				return "", fmt.Errorf("failed to evaluate log expression: %w", err)
+			}
+			return cel.AsString(val)
+		}
+	case conf.Template != "":
+		var (
+			curLine int64
+			curTime time.Time
+			curRand *rand.Rand
+		)
+		renderer := gotpl.NewRenderer(gotpl.FuncMap{
+			"Container": func() string {
+				return container
+			},
+			"Line": func() int64 {
+				return curLine
+			},
+			"Time": func() time.Time {
+				return curTime
+			},
+			"Rand": func() float64 {
+				return curRand.Float64()
+			},
+			"RandInt": func(n int) int {
+				if n <= 0 {
+					return 0
+				}
+				return curRand.Intn(n)
+			},
+			"RandChoice": func(items ...any) any {
+				if len(items) == 0 {
+					return nil
+				}
+				return items[curRand.Intn(len(items))]
+			},
+		})
+		g.render = func(ctx context.Context, line int64, t time.Time, r *rand.Rand) (string, error) {
+			curLine, curTime, curRand = line, t, r
+			data, err := renderer.ToText(conf.Template, pod)
+			if err != nil {
+				return "", fmt.Errorf("failed to render log template: %w", err)
+			}
+			return string(data), nil
+		}
+	default:
+		return nil, fmt.Errorf("neither template nor expression is set for the log generator")
+	}
+	return g, nil
+}
+
+// containerStartTime returns the time the container started, the lines are generated from this time.
+func containerStartTime(pod *corev1.Pod, container string) time.Time {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container && status.State.Running != nil {
+			return status.State.Running.StartedAt.Time
+		}
+	}
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	if !pod.CreationTimestamp.IsZero() {
+		return pod.CreationTimestamp.Time
+	}
+	return clock.Now()
+}
+
+// timeOf returns the time of the line.
+func (g *logGenerator) timeOf(line int64) time.Time {
+	return g.start.Add(time.Duration(line) * g.interval)
+}
+
+// lineAt returns the first line generated at or after the time.
+func (g *logGenerator) lineAt(t time.Time) int64 {
+	d := t.Sub(g.start)
+	if d <= 0 {
+		return 0
+	}
+	return int64((d + g.interval - 1) / g.interval)
+}
+
+// line returns the line i with a trailing newline.
+func (g *logGenerator) line(ctx context.Context, i int64) (time.Time, []byte, error) {
+	t := g.timeOf(i)
+	//nolint:gosec
+	r := rand.New(rand.NewSource(g.seed + i*-7046029254386353131))
+	text, err := g.render(ctx, i, t, r)
+	if err != nil {
+		return t, nil, err
+	}
+
+	if g.format != internalversion.LogFormatJSON {
+		return t, append([]byte(text), '\n'), nil
+	}
+
+	ts := t.Format(time.RFC3339Nano)
+	var data []byte
+	var obj map[string]any
+	if json.Unmarshal([]byte(text), &obj) == nil && obj != nil {
+		if _, ok := obj["time"]; !ok {
+			obj["time"] = ts
+		}
+		data, err = json.Marshal(obj)
+	} else {
+		data, err = json.Marshal(struct {
+			Time string `json:"time"`
+			Msg  string `json:"msg"`
+		}{
+			Time: ts,
+			Msg:  text,
+		})
+	}
+	if err != nil {
+		return t, nil, err
+	}
+	return t, append(data, '\n'), nil
+}
+
+// generateLogs writes the lines generated until now, and keeps generating if follow is set.
+func (s *Server) generateLogs(ctx context.Context, conf *internalversion.LogGenerator, podName, podNamespace, container string, logOptions *corev1.PodLogOptions, stdout io.Writer) error {
+	g, err := s.newLogGenerator(conf, podName, podNamespace, container)
+	if err != nil {
+		return err
+	}
+	return g.writeLogs(ctx, logOptions, stdout)
+}
+
+// writeLogs writes the lines selected by the log options.
+func (g *logGenerator) writeLogs(ctx context.Context, logOptions *corev1.PodLogOptions, stdout io.Writer) error {
+	now := clock.Now()
+	next := g.lineAt(now.Add(1))
+
+	first := int64(0)
+	if logOptions.SinceSeconds != nil {
+		first = g.lineAt(now.Add(-time.Duration(*logOptions.SinceSeconds) * time.Second))
+	} else if logOptions.SinceTime != nil {
+		first = g.lineAt(logOptions.SinceTime.Time)
+	}
+	if logOptions.TailLines != nil {
+		first = max(first, next-*logOptions.TailLines)
+	} else if logOptions.SinceSeconds == nil && logOptions.SinceTime == nil && logOptions.LimitBytes == nil {
+		first = max(first, next-defaultLogsBacklogLines)
+	}
+
+	var limit int64 = -1
+	if logOptions.LimitBytes != nil {
+		limit = *logOptions.LimitBytes
+	}
+
+	write := func(i int64) (bool, error) {
+		t, data, err := g.line(ctx, i)
+		if err != nil {
+			return false, err
+		}
+		if logOptions.Timestamps {
+			data = append([]byte(t.Format(time.RFC3339Nano)+" "), data...)
+		}
+		if limit >= 0 && int64(len(data)) >= limit {
+			_, err = stdout.Write(data[:limit])
+			return false, err
+		}
+		_, err = stdout.Write(data)
+		if err != nil {
+			return false, err
+		}
+		if limit >= 0 {
+			limit -= int64(len(data))
+		}
+		return true, nil
+	}
+
+	for i := first; i < next; i++ {
+		ok, err := write(i)
+		if err != nil || !ok {
+			return err
+		}
+	}
+	if !logOptions.Follow {
+		return nil
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for i := next; ; i++ {
+		if d := g.timeOf(i).Sub(clock.Now()); d > 0 {
+			timer.Reset(d)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-timer.C:
+			}
+		} else if ctx.Err() != nil {
+			return nil
+		}
+		ok, err := write(i)
+		if err != nil || !ok {
+			return err
+		}
+	}
+}
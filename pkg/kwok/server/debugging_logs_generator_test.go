@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+func Test_logGenerator(t *testing.T) {
+	tests := []struct {
+		name       string
+		conf       internalversion.LogGenerator
+		opts       corev1.PodLogOptions
+		wantLines  int
+		wantPrefix string
+		check      func(t *testing.T, line string)
+	}{
+		{
+			name: "template",
+			conf: internalversion.LogGenerator{
+				Template:       `{{ .metadata.name }} {{ Container }} {{ Line }}`,
+				LinesPerSecond: format.Ptr(10.0),
+			},
+			wantLines:  101,
+			wantPrefix: "pod app 0",
+		},
+		{
+			name: "expression with tail",
+			conf: internalversion.LogGenerator{
+				Expression:     `pod.metadata.name + " " + string(line) + (time > timestamp("2000-01-01T00:00:00Z") ? "" : " old")`,
+				LinesPerSecond: format.Ptr(10.0),
+			},
+			opts: corev1.PodLogOptions{
+				TailLines: format.Ptr[int64](3),
+			},
+			wantLines:  3,
+			wantPrefix: "pod 98",
+		},
+		{
+			name: "since seconds",
+			conf: internalversion.LogGenerator{
+				Template: `{{ Line }}`,
+			},
+			opts: corev1.PodLogOptions{
+				SinceSeconds: format.Ptr[int64](2),
+			},
+			wantLines:  2,
+			wantPrefix: "9",
+		},
+		{
+			name: "json",
+			conf: internalversion.LogGenerator{
+				Template: `{{ RandChoice "a" "b" }}`,
+				Format:   internalversion.LogFormatJSON,
+			},
+			wantLines: 11,
+			check: func(t *testing.T, line string) {
+				var obj map[string]string
+				if err := json.Unmarshal([]byte(line), &obj); err != nil {
+					t.Fatalf("invalid json line %q: %v", line, err)
+				}
+				if obj["time"] == "" || (obj["msg"] != "a" && obj["msg"] != "b") {
+					t.Errorf("unexpected json line %q", line)
+				}
+			},
+		},
+		{
+			name: "expression with rand",
+			conf: internalversion.LogGenerator{
+				Expression: `Rand() < 1.0 ? "rand" : "out of range"`,
+			},
+			wantLines:  11,
+			wantPrefix: "rand",
+			check: func(t *testing.T, line string) {
+				if line != "rand" {
+					t.Errorf("unexpected line %q", line)
+				}
+			},
+		},
+		{
+			name: "limit bytes",
+			conf: internalversion.LogGenerator{
+				Template: `hello`,
+			},
+			opts: corev1.PodLogOptions{
+				LimitBytes: format.Ptr[int64](15),
+			},
+			wantLines:  3,
+			wantPrefix: "hello",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{}
+			g, err := s.newLogGenerator(&tt.conf, "pod", "default", "app")
+			if err != nil {
+				t.Fatal(err)
+			}
+			g.start = time.Now().Add(-10*time.Second - time.Millisecond)
+
+			out := bytes.NewBuffer(nil)
+			err = g.writeLogs(context.Background(), &tt.opts, out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+			if len(lines) != tt.wantLines {
+				t.Fatalf("got %d lines, want %d: %q", len(lines), tt.wantLines, out.String())
+			}
+			if !strings.HasPrefix(lines[0], tt.wantPrefix) {
+				t.Errorf("got first line %q, want prefix %q", lines[0], tt.wantPrefix)
+			}
+			if tt.check != nil {
+				for _, line := range lines {
+					tt.check(t, line)
+				}
+			}
+
+			again := bytes.NewBuffer(nil)
+			_ = g.writeLogs(context.Background(), &tt.opts, again)
+			if !strings.HasPrefix(again.String(), out.String()[:len(lines[0])]) {
+				t.Errorf("lines are not reproducible: %q != %q", again.String(), out.String())
+			}
+		})
+	}
+}
+
+func Test_logGeneratorDefaultBacklog(t *testing.T) {
+	s := &Server{}
+	g, err := s.newLogGenerator(&internalversion.LogGenerator{
+		Template:       `{{ Line }}`,
+		LinesPerSecond: format.Ptr(1000.0),
+	}, "pod", "default", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.start = time.Now().Add(-20 * time.Second)
+
+	out := bytes.NewBuffer(nil)
+	err = g.writeLogs(context.Background(), &corev1.PodLogOptions{}, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != defaultLogsBacklogLines {
+		t.Fatalf("got %d lines, want %d", len(lines), defaultLogsBacklogLines)
+	}
+	if lines[0] == "0" {
+		t.Errorf("got the first line of the container, want the latest lines only")
+	}
+}
+
+func Test_logGeneratorFollow(t *testing.T) {
+	s := &Server{}
+	g, err := s.newLogGenerator(&internalversion.LogGenerator{
+		Template:       `{{ Line }}`,
+		LinesPerSecond: format.Ptr(100.0),
+	}, "pod", "default", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	out := bytes.NewBuffer(nil)
+	err = g.writeLogs(ctx, &corev1.PodLogOptions{Follow: true}, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(out.String(), "\n"); n < 10 {
+		t.Errorf("got %d lines in follow mode, want at least 10", n)
+	}
+}
@@ -17,12 +17,20 @@ limitations under the License.
 package server
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"path"
 	"reflect"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crilogs "k8s.io/cri-client/pkg/logs"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/format"
 )
 
 func Test_findLogInLogs(t *testing.T) {
@@ -237,3 +245,171 @@ func Test_getPodLogs(t *testing.T) {
 		})
 	}
 }
+
+func Test_readLogs(t *testing.T) {
+	logsFile := path.Join(t.TempDir(), "logs.log")
+	err := os.WriteFile(logsFile, []byte(
+		"2016-10-06T00:00:00Z stdout F line 1\n"+
+			"2016-10-06T00:00:01Z stderr F line 2\n"+
+			"2016-10-06T00:00:02Z stdout P line \n"+
+			"2016-10-06T00:00:02Z stdout F 3\n",
+	), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		logOptions corev1.PodLogOptions
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "all",
+			wantStdout: "line 1\nline 3\n",
+			wantStderr: "line 2\n",
+		},
+		{
+			name: "since time",
+			logOptions: corev1.PodLogOptions{
+				SinceTime: &metav1.Time{Time: time.Date(2016, 10, 6, 0, 0, 1, 0, time.UTC)},
+			},
+			wantStdout: "line 3\n",
+			wantStderr: "line 2\n",
+		},
+		{
+			name: "timestamps",
+			logOptions: corev1.PodLogOptions{
+				Timestamps: true,
+				SinceTime:  &metav1.Time{Time: time.Date(2016, 10, 6, 0, 0, 2, 0, time.UTC)},
+			},
+			wantStdout: "2016-10-06T00:00:02.000000000Z line 3\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := bytes.NewBuffer(nil)
+			stderr := bytes.NewBuffer(nil)
+			err := readLogs(context.Background(), logsFile, crilogs.NewLogOptions(&tt.logOptions, time.Now()), stdout, stderr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := stdout.String(); got != tt.wantStdout {
+				t.Errorf("readLogs() stdout = %q, want %q", got, tt.wantStdout)
+			}
+			if got := stderr.String(); got != tt.wantStderr {
+				t.Errorf("readLogs() stderr = %q, want %q", got, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_isPlainLogs(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{
+			name: "cri",
+			data: "2016-10-06T00:00:00Z stdout F line 1\n",
+			want: false,
+		},
+		{
+			name: "docker json-file",
+			data: `{"log":"line 1\n","stream":"stdout","time":"2016-10-06T00:00:00Z"}` + "\n",
+			want: false,
+		},
+		{
+			name: "empty",
+			data: "",
+			want: false,
+		},
+		{
+			name: "plain",
+			data: "line 1\n",
+			want: true,
+		},
+		{
+			name: "json",
+			data: `{"msg":"line 1"}` + "\n",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logsFile := path.Join(t.TempDir(), "logs.log")
+			err := os.WriteFile(logsFile, []byte(tt.data), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := isPlainLogs(logsFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("isPlainLogs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readPlainLogs(t *testing.T) {
+	logsFile := path.Join(t.TempDir(), "logs.log")
+	err := os.WriteFile(logsFile, []byte("line 1\nline 2\nline 3\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		logOptions corev1.PodLogOptions
+		want       string
+	}{
+		{
+			name: "all",
+			want: "line 1\nline 2\nline 3\n",
+		},
+		{
+			name: "tail lines",
+			logOptions: corev1.PodLogOptions{
+				TailLines: format.Ptr[int64](2),
+			},
+			want: "line 2\nline 3\n",
+		},
+		{
+			name: "since seconds",
+			logOptions: corev1.PodLogOptions{
+				SinceSeconds: format.Ptr[int64](3600),
+			},
+			// the lines are timestamped with the modification time of the file
+			want: "line 1\nline 2\nline 3\n",
+		},
+		{
+			name: "since time after the modification time",
+			logOptions: corev1.PodLogOptions{
+				SinceTime: &metav1.Time{Time: time.Now().Add(time.Hour)},
+			},
+			want: "",
+		},
+		{
+			name: "limit bytes",
+			logOptions: corev1.PodLogOptions{
+				LimitBytes: format.Ptr[int64](10),
+			},
+			want: "line 1\nlin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := bytes.NewBuffer(nil)
+			err := readPlainLogs(context.Background(), logsFile, &tt.logOptions, stdout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := stdout.String(); got != tt.want {
+				t.Errorf("readPlainLogs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -53,6 +53,10 @@ func (s *Server) PortForward(ctx context.Context, name string, uid types.UID, po
 		return err
 	}
 
+	if forward.Responder != nil {
+		return s.respondForward(ctx, forward.Responder, podName, podNamespace, port, stream)
+	}
+
 	if len(forward.Command) > 0 {
 		return exec.Exec(exec.WithReadWriter(ctx, stream), forward.Command[0], forward.Command[1:]...)
 	}
@@ -78,7 +82,7 @@ func (s *Server) PortForward(ctx context.Context, name string, uid types.UID, po
 		return utilsnet.Tunnel(ctx, stream, dial, buf1, buf2)
 	}
 
-	return errors.New("no target or command")
+	return errors.New("no target, command or responder")
 }
 
 // getPortForward handles a new restful port forward request. It determines the
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+)
+
+// respondForward responds to the forwarded connection with the built-in responder.
+func (s *Server) respondForward(ctx context.Context, responder *internalversion.ForwardResponder, podName, podNamespace string, port int32, stream io.ReadWriter) error {
+	pod := s.getPodForResponder(podName, podNamespace)
+	switch {
+	case responder.HTTP != nil:
+		return respondHTTP(ctx, responder.HTTP, pod, port, stream)
+	case responder.TCP != nil:
+		return respondTCP(ctx, responder.TCP, pod, port, stream)
+	default:
+		return errors.New("no http or tcp responder")
+	}
+}
+
+// respondHTTP serves the requests of the connection with the routes until the connection is closed.
+func respondHTTP(ctx context.Context, responder *internalversion.ForwardHTTPResponder, pod *corev1.Pod, port int32, stream io.ReadWriter) error {
+	var req *http.Request
+	renderer := gotpl.NewRenderer(gotpl.FuncMap{
+		"Port": func() int32 {
+			return port
+		},
+		"Method": func() string {
+			return req.Method
+		},
+		"Path": func() string {
+			return req.URL.Path
+		},
+		"Query": func(key string) string {
+			return req.URL.Query().Get(key)
+		},
+	})
+
+	r := bufio.NewReader(stream)
+	for ctx.Err() == nil {
+		var err error
+		req, err = http.ReadRequest(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read http request: %w", err)
+		}
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+
+		resp := &http.Response{
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Request:    req,
+			Header:     http.Header{},
+			Close:      req.Close,
+		}
+
+		route := matchHTTPRoute(responder.Routes, req)
+		if route == nil {
+			resp.StatusCode = http.StatusNotFound
+			resp.Header.Set("Content-Type", "text/plain; charset=utf-8")
+			resp.Body = io.NopCloser(strings.NewReader("404 page not found\n"))
+			resp.ContentLength = int64(len("404 page not found\n"))
+		} else {
+			body, err := renderScriptOutput(renderer, route.Body, pod)
+			if err != nil {
+				return fmt.Errorf("failed to render http body: %w", err)
+			}
+			resp.StatusCode = http.StatusOK
+			if route.StatusCode != 0 {
+				resp.StatusCode = int(route.StatusCode)
+			}
+			for _, header := range route.Headers {
+				resp.Header.Add(header.Name, header.Value)
+			}
+			if resp.Header.Get("Content-Type") == "" && len(body) != 0 {
+				resp.Header.Set("Content-Type", http.DetectContentType(body))
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			resp.ContentLength = int64(len(body))
+		}
+		resp.Status = strconv.Itoa(resp.StatusCode) + " " + http.StatusText(resp.StatusCode)
+		if req.Method == http.MethodHead {
+			resp.Body = nil
+		}
+
+		err = resp.Write(stream)
+		if err != nil {
+			return fmt.Errorf("failed to write http response: %w", err)
+		}
+		if resp.Close {
+			return nil
+		}
+	}
+	return nil
+}
+
+// matchHTTPRoute returns the first route that matches the request.
+func matchHTTPRoute(routes []internalversion.ForwardHTTPRoute, req *http.Request) *internalversion.ForwardHTTPRoute {
+	for i, route := range routes {
+		if route.Method != "" && !strings.EqualFold(route.Method, req.Method) {
+			continue
+		}
+		if route.Path != "" {
+			if strings.HasSuffix(route.Path, "/") {
+				if !strings.HasPrefix(req.URL.Path, route.Path) {
+					continue
+				}
+			} else if req.URL.Path != route.Path {
+				continue
+			}
+		}
+		return &routes[i]
+	}
+	return nil
+}
+
+// respondTCP writes the banner to the connection, and echoes it if echo is set.
+func respondTCP(ctx context.Context, responder *internalversion.ForwardTCPResponder, pod *corev1.Pod, port int32, stream io.ReadWriter) error {
+	if responder.Banner != "" {
+		renderer := gotpl.NewRenderer(gotpl.FuncMap{
+			"Port": func() int32 {
+				return port
+			},
+		})
+		banner, err := renderScriptOutput(renderer, responder.Banner, pod)
+		if err != nil {
+			return fmt.Errorf("failed to render tcp banner: %w", err)
+		}
+		_, err = stream.Write(banner)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !responder.Echo {
+		return nil
+	}
+	// hide the ReaderFrom and WriterTo of the stream, which can not copy to itself
+	_, err := io.Copy(struct{ io.Writer }{stream}, struct{ io.Reader }{stream})
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// getPodForResponder returns the pod to render the responses with,
+// if the pod is not in the cache, only its name and namespace are set.
+func (s *Server) getPodForResponder(podName, podNamespace string) *corev1.Pod {
+	if s.podCacheGetter != nil {
+		pod, ok := s.podCacheGetter.GetWithNamespace(podName, podNamespace)
+		if ok && pod != nil {
+			return pod
+		}
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: podNamespace,
+		},
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func Test_respondForwardHTTP(t *testing.T) {
+	responder := &internalversion.ForwardResponder{
+		HTTP: &internalversion.ForwardHTTPResponder{
+			Routes: []internalversion.ForwardHTTPRoute{
+				{
+					Method: http.MethodGet,
+					Path:   "/healthz",
+					Body:   "ok\n",
+				},
+				{
+					Path:       "/api/",
+					StatusCode: http.StatusCreated,
+					Headers: []internalversion.ForwardHTTPHeader{
+						{Name: "Content-Type", Value: "application/json"},
+					},
+					Body: `{"pod": "{{ .metadata.name }}", "path": "{{ Path }}", "port": {{ Port }}}`,
+				},
+			},
+		},
+	}
+
+	s := &Server{}
+	client, server := net.Pipe()
+	go func() {
+		_ = s.respondForward(context.Background(), responder, "pod", "default", 8080, server)
+		_ = server.Close()
+	}()
+	defer func() {
+		_ = client.Close()
+	}()
+
+	tests := []struct {
+		method      string
+		path        string
+		wantCode    int
+		wantBody    string
+		wantContent string
+	}{
+		{
+			method:      http.MethodGet,
+			path:        "/healthz",
+			wantCode:    http.StatusOK,
+			wantBody:    "ok\n",
+			wantContent: "text/plain; charset=utf-8",
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/api/v1",
+			wantCode:    http.StatusCreated,
+			wantBody:    `{"pod": "pod", "path": "/api/v1", "port": 8080}`,
+			wantContent: "application/json",
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/healthz",
+			wantCode: http.StatusNotFound,
+			wantBody: "404 page not found\n",
+		},
+	}
+
+	r := bufio.NewReader(client)
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "http://localhost"+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = req.Write(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := http.ReadResponse(r, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("got status code %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("got body %q, want %q", body, tt.wantBody)
+			}
+			if tt.wantContent != "" && resp.Header.Get("Content-Type") != tt.wantContent {
+				t.Errorf("got content type %q, want %q", resp.Header.Get("Content-Type"), tt.wantContent)
+			}
+		})
+	}
+}
+
+func Test_respondForwardTCP(t *testing.T) {
+	responder := &internalversion.ForwardResponder{
+		TCP: &internalversion.ForwardTCPResponder{
+			Banner: "hello {{ .metadata.namespace }}/{{ .metadata.name }}\n",
+			Echo:   true,
+		},
+	}
+
+	s := &Server{}
+	client, server := net.Pipe()
+	go func() {
+		_ = s.respondForward(context.Background(), responder, "pod", "default", 22, server)
+		_ = server.Close()
+	}()
+	defer func() {
+		_ = client.Close()
+	}()
+
+	r := bufio.NewReader(client)
+	banner, err := r.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if banner != "hello default/pod\n" {
+		t.Errorf("got banner %q", banner)
+	}
+
+	_, err = client.Write([]byte("ping\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo, err := r.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if echo != "ping\n" {
+		t.Errorf("got echo %q", echo)
+	}
+}
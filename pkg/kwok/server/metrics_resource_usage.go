@@ -94,7 +94,7 @@ func (s *Server) nodeResourceCumulativeUsage(resourceName, nodeName string) floa
 	}
 
 	now := time.Now()
-	key := nodeName
+	key := fmt.Sprintf("%s/%s", resourceName, nodeName)
 	s.cumulativesMut.Lock()
 	defer s.cumulativesMut.Unlock()
 	c, ok := s.cumulatives[key]
@@ -22,6 +22,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"sync"
 	"time"
 
@@ -77,6 +78,9 @@ type Server struct {
 
 	metricsUpdateHandler maps.SyncMap[string, *metrics.UpdateHandler]
 
+	// execScriptRegexps is the compiled regexes of the script rules of the exec targets
+	execScriptRegexps maps.SyncMap[string, *regexp.Regexp]
+
 	cumulatives    map[string]cumulative
 	cumulativesMut sync.Mutex
 
@@ -150,6 +154,19 @@ func NewServer(conf Config) (*Server, error) {
 		}),
 	}
 
+	for _, exec := range conf.ClusterExecs {
+		err := s.loadExecTargets(exec.Spec.Execs)
+		if err != nil {
+			return nil, fmt.Errorf("cluster exec %s: %w", exec.Name, err)
+		}
+	}
+	for _, exec := range conf.Execs {
+		err := s.loadExecTargets(exec.Spec.Execs)
+		if err != nil {
+			return nil, fmt.Errorf("exec %s/%s: %w", exec.Namespace, exec.Name, err)
+		}
+	}
+
 	return s, nil
 }
 
@@ -225,6 +242,11 @@ func (s *Server) initWatchCRD(ctx context.Context) ([]resources.Starter, error)
 							logger.Error("failed to convert to internal cluster exec", err, "obj", obj)
 							return nil, false
 						}
+						err = s.loadExecTargets(r.Spec.Execs)
+						if err != nil {
+							logger.Error("failed to load cluster exec", err, "obj", obj)
+							return nil, false
+						}
 						return r, true
 					})
 				},
@@ -248,6 +270,11 @@ func (s *Server) initWatchCRD(ctx context.Context) ([]resources.Starter, error)
 							logger.Error("failed to convert to internal exec", err, "obj", obj)
 							return nil, false
 						}
+						err = s.loadExecTargets(r.Spec.Execs)
+						if err != nil {
+							logger.Error("failed to load exec", err, "obj", obj)
+							return nil, false
+						}
 						return r, true
 					})
 				},
@@ -0,0 +1,251 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	statsv1alpha1 "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+
+	"sigs.k8s.io/kwok/pkg/kwok/metrics"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+// The resource names of the ResourceUsage that are reported in the summary,
+// the network usages are in bytes per second.
+const (
+	statsResourceCPU              = string(corev1.ResourceCPU)
+	statsResourceMemory           = string(corev1.ResourceMemory)
+	statsResourceEphemeralStorage = string(corev1.ResourceEphemeralStorage)
+	statsResourceNetworkReceive   = "network-receive"
+	statsResourceNetworkTransmit  = "network-transmit"
+
+	statsInterfaceName = "eth0"
+)
+
+// InstallStats registers the kubelet stats summary handler.
+func (s *Server) InstallStats(ctx context.Context) error {
+	if s.env == nil {
+		err := s.initCEL()
+		if err != nil {
+			return err
+		}
+	}
+
+	ws := new(restful.WebService)
+	ws.Path("/stats")
+	ws.Route(ws.GET("/summary").
+		To(s.getStatsSummary).
+		Operation("getStatsSummary"))
+	ws.Route(ws.GET("/nodes/{nodeName}/stats/summary").
+		To(s.getStatsSummary).
+		Operation("getStatsSummary"))
+	s.restfulCont.Add(ws)
+	return nil
+}
+
+// getStatsSummary handles the stats summary request of a node,
+// the node is the nodeName of the path or the query, or the only node if there is one.
+func (s *Server) getStatsSummary(req *restful.Request, resp *restful.Response) {
+	nodeName := req.PathParameter("nodeName")
+	if nodeName == "" {
+		nodeName = req.QueryParameter("nodeName")
+	}
+	if nodeName == "" {
+		nodes := s.dataSource.ListNodes()
+		if len(nodes) != 1 {
+			_ = resp.WriteError(http.StatusBadRequest, fmt.Errorf("nodeName is required when there are %d nodes", len(nodes)))
+			return
+		}
+		nodeName = nodes[0]
+	}
+
+	summary, err := s.statsSummary(nodeName)
+	if err != nil {
+		_ = resp.WriteError(http.StatusNotFound, err)
+		return
+	}
+	_ = resp.WriteAsJson(summary)
+}
+
+// statsSummary returns the stats summary of the node from the resource usages of its pods.
+func (s *Server) statsSummary(nodeName string) (*statsv1alpha1.Summary, error) {
+	node, ok := s.nodeCacheGetter.Get(nodeName)
+	if !ok {
+		return nil, fmt.Errorf("node %q not found", nodeName)
+	}
+
+	now := metav1.NewTime(time.Now())
+	summary := &statsv1alpha1.Summary{
+		Node: statsv1alpha1.NodeStats{
+			NodeName:  node.Name,
+			StartTime: node.CreationTimestamp,
+		},
+		Pods: []statsv1alpha1.PodStats{},
+	}
+
+	var nodeCPU, nodeMemory, nodeStorage float64
+	pods, _ := s.dataSource.ListPods(nodeName)
+	for _, pi := range pods {
+		pod, ok := s.podCacheGetter.GetWithNamespace(pi.Name, pi.Namespace)
+		if !ok {
+			continue
+		}
+		podStats := s.podStats(node, pod, now)
+		summary.Pods = append(summary.Pods, podStats)
+
+		nodeCPU += float64(*podStats.CPU.UsageNanoCores) / float64(time.Second)
+		nodeMemory += float64(*podStats.Memory.WorkingSetBytes)
+		nodeStorage += float64(*podStats.EphemeralStorage.UsedBytes)
+	}
+
+	summary.Node.CPU = &statsv1alpha1.CPUStats{
+		Time:                 now,
+		UsageNanoCores:       toUint64(nodeCPU * float64(time.Second)),
+		UsageCoreNanoSeconds: toUint64(s.nodeResourceCumulativeUsage(statsResourceCPU, nodeName) * float64(time.Second)),
+	}
+	summary.Node.Memory = memoryStats(nodeMemory, node.Status.Allocatable.Memory(), now)
+	summary.Node.Fs = fsStats(nodeStorage, node.Status.Capacity.StorageEphemeral(), now)
+	summary.Node.Network = networkStats(
+		s.nodeResourceCumulativeUsage(statsResourceNetworkReceive, nodeName),
+		s.nodeResourceCumulativeUsage(statsResourceNetworkTransmit, nodeName),
+		now,
+	)
+	return summary, nil
+}
+
+// podStats returns the stats of the pod and its containers.
+func (s *Server) podStats(node *corev1.Node, pod *corev1.Pod, now metav1.Time) statsv1alpha1.PodStats {
+	podStats := statsv1alpha1.PodStats{
+		PodRef: statsv1alpha1.PodReference{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			UID:       string(pod.UID),
+		},
+		StartTime:  pod.CreationTimestamp,
+		Containers: []statsv1alpha1.ContainerStats{},
+	}
+	if pod.Status.StartTime != nil {
+		podStats.StartTime = *pod.Status.StartTime
+	}
+
+	data := metrics.Data{
+		Node: node,
+		Pod:  pod,
+	}
+	var podCPU, podCPUCumulative, podMemory, podStorage, podRx, podTx float64
+	for _, c := range pod.Spec.Containers {
+		c := c
+		data.Container = &c
+
+		cpu := s.evaluateContainerResourceUsage(statsResourceCPU, data)
+		cpuCumulative := s.containerResourceCumulativeUsage(statsResourceCPU, pod.Namespace, pod.Name, c.Name)
+		memory := s.evaluateContainerResourceUsage(statsResourceMemory, data)
+		storage := s.evaluateContainerResourceUsage(statsResourceEphemeralStorage, data)
+		podCPU += cpu
+		podCPUCumulative += cpuCumulative
+		podMemory += memory
+		podStorage += storage
+		podRx += s.containerResourceCumulativeUsage(statsResourceNetworkReceive, pod.Namespace, pod.Name, c.Name)
+		podTx += s.containerResourceCumulativeUsage(statsResourceNetworkTransmit, pod.Namespace, pod.Name, c.Name)
+
+		startTime := podStats.StartTime
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == c.Name && status.State.Running != nil {
+				startTime = status.State.Running.StartedAt
+			}
+		}
+		podStats.Containers = append(podStats.Containers, statsv1alpha1.ContainerStats{
+			Name:      c.Name,
+			StartTime: startTime,
+			CPU: &statsv1alpha1.CPUStats{
+				Time:                 now,
+				UsageNanoCores:       toUint64(cpu * float64(time.Second)),
+				UsageCoreNanoSeconds: toUint64(cpuCumulative * float64(time.Second)),
+			},
+			Memory: memoryStats(memory, c.Resources.Limits.Memory(), now),
+			Rootfs: fsStats(storage, node.Status.Capacity.StorageEphemeral(), now),
+		})
+	}
+
+	podStats.CPU = &statsv1alpha1.CPUStats{
+		Time:                 now,
+		UsageNanoCores:       toUint64(podCPU * float64(time.Second)),
+		UsageCoreNanoSeconds: toUint64(podCPUCumulative * float64(time.Second)),
+	}
+	podStats.Memory = memoryStats(podMemory, nil, now)
+	podStats.EphemeralStorage = fsStats(podStorage, node.Status.Capacity.StorageEphemeral(), now)
+	podStats.Network = networkStats(podRx, podTx, now)
+	return podStats
+}
+
+func memoryStats(used float64, limit *resource.Quantity, now metav1.Time) *statsv1alpha1.MemoryStats {
+	stats := &statsv1alpha1.MemoryStats{
+		Time:            now,
+		UsageBytes:      toUint64(used),
+		WorkingSetBytes: toUint64(used),
+		RSSBytes:        toUint64(used),
+	}
+	if limit != nil && limit.Value() > 0 {
+		stats.AvailableBytes = toUint64(float64(limit.Value()) - used)
+	}
+	return stats
+}
+
+func fsStats(used float64, capacity *resource.Quantity, now metav1.Time) *statsv1alpha1.FsStats {
+	stats := &statsv1alpha1.FsStats{
+		Time:      now,
+		UsedBytes: toUint64(used),
+	}
+	if capacity != nil && capacity.Value() > 0 {
+		stats.CapacityBytes = toUint64(float64(capacity.Value()))
+		stats.AvailableBytes = toUint64(float64(capacity.Value()) - used)
+	}
+	return stats
+}
+
+func networkStats(rx, tx float64, now metav1.Time) *statsv1alpha1.NetworkStats {
+	return &statsv1alpha1.NetworkStats{
+		Time: now,
+		InterfaceStats: statsv1alpha1.InterfaceStats{
+			Name:    statsInterfaceName,
+			RxBytes: toUint64(rx),
+			TxBytes: toUint64(tx),
+		},
+		Interfaces: []statsv1alpha1.InterfaceStats{
+			{
+				Name:    statsInterfaceName,
+				RxBytes: toUint64(rx),
+				TxBytes: toUint64(tx),
+			},
+		},
+	}
+}
+
+func toUint64(v float64) *uint64 {
+	if v <= 0 {
+		return format.Ptr[uint64](0)
+	}
+	return format.Ptr(uint64(v))
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+type fakeGetter[T metav1.Object] []T
+
+func (g fakeGetter[T]) Get(name string) (T, bool) {
+	return g.GetWithNamespace(name, "")
+}
+
+func (g fakeGetter[T]) GetWithNamespace(name, namespace string) (T, bool) {
+	for _, o := range g {
+		if o.GetName() == name && o.GetNamespace() == namespace {
+			return o, true
+		}
+	}
+	var t T
+	return t, false
+}
+
+func (g fakeGetter[T]) List() []T {
+	return g
+}
+
+type fakeDataSource struct {
+	pods map[string][]log.ObjectRef
+}
+
+func (d fakeDataSource) ListPods(nodeName string) ([]log.ObjectRef, bool) {
+	pods, ok := d.pods[nodeName]
+	return pods, ok
+}
+
+func (d fakeDataSource) ListNodes() []string {
+	nodes := []string{}
+	for name := range d.pods {
+		nodes = append(nodes, name)
+	}
+	return nodes
+}
+
+func (d fakeDataSource) StartedContainersTotal(nodeName string) int64 {
+	return 0
+}
+
+func TestStatsSummary(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node0",
+		},
+		Status: corev1.NodeStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+			},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod0",
+			Namespace: "default",
+			UID:       "uid0",
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node0",
+			Containers: []corev1.Container{
+				{Name: "app"},
+				{Name: "sidecar"},
+			},
+		},
+	}
+
+	s, err := NewServer(Config{
+		ClusterResourceUsages: []*internalversion.ClusterResourceUsage{
+			{
+				Spec: internalversion.ClusterResourceUsageSpec{
+					Usages: []internalversion.ResourceUsageContainer{
+						{
+							Usage: map[string]internalversion.ResourceUsageValue{
+								"cpu": {
+									Value: format.Ptr(resource.MustParse("100m")),
+								},
+								"memory": {
+									Expression: format.Ptr(`Quantity("10Mi")`),
+								},
+								"ephemeral-storage": {
+									Value: format.Ptr(resource.MustParse("1Mi")),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSource: fakeDataSource{
+			pods: map[string][]log.ObjectRef{
+				"node0": {log.KObj(pod)},
+			},
+		},
+		NodeCacheGetter: fakeGetter[*corev1.Node]{node},
+		PodCacheGetter:  fakeGetter[*corev1.Pod]{pod},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.ctx = context.Background()
+	err = s.InstallStats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := s.statsSummary("node0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Node.NodeName != "node0" {
+		t.Errorf("got node name %q", summary.Node.NodeName)
+	}
+	if got := *summary.Node.CPU.UsageNanoCores; got != 200_000_000 {
+		t.Errorf("got node cpu %d, want %d", got, 200_000_000)
+	}
+	if got := *summary.Node.Memory.WorkingSetBytes; got != 20*1024*1024 {
+		t.Errorf("got node memory %d, want %d", got, 20*1024*1024)
+	}
+	if got := *summary.Node.Memory.AvailableBytes; got != 1024*1024*1024-20*1024*1024 {
+		t.Errorf("got node available memory %d", got)
+	}
+	if got := *summary.Node.Fs.UsedBytes; got != 2*1024*1024 {
+		t.Errorf("got node fs used %d, want %d", got, 2*1024*1024)
+	}
+
+	if len(summary.Pods) != 1 {
+		t.Fatalf("got %d pods, want 1", len(summary.Pods))
+	}
+	podStats := summary.Pods[0]
+	if podStats.PodRef.Name != "pod0" || podStats.PodRef.UID != "uid0" {
+		t.Errorf("got pod ref %v", podStats.PodRef)
+	}
+	if len(podStats.Containers) != 2 {
+		t.Fatalf("got %d containers, want 2", len(podStats.Containers))
+	}
+	if got := *podStats.Containers[0].CPU.UsageNanoCores; got != 100_000_000 {
+		t.Errorf("got container cpu %d, want %d", got, 100_000_000)
+	}
+	if got := *podStats.Containers[1].Memory.WorkingSetBytes; got != 10*1024*1024 {
+		t.Errorf("got container memory %d, want %d", got, 10*1024*1024)
+	}
+	if got := *podStats.EphemeralStorage.UsedBytes; got != 2*1024*1024 {
+		t.Errorf("got pod ephemeral storage %d, want %d", got, 2*1024*1024)
+	}
+
+	_, err = s.statsSummary("node1")
+	if err == nil {
+		t.Errorf("expected error for unknown node")
+	}
+}
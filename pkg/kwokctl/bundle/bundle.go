@@ -0,0 +1,395 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/image"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// ManifestName is the name of the manifest in the bundle
+const ManifestName = "manifest.json"
+
+// Artifact types
+const (
+	ArtifactTypeBinary = "binary"
+	ArtifactTypeImage  = "image"
+)
+
+// Manifest describes the content of a bundle
+type Manifest struct {
+	// Artifacts is the list of artifacts in the bundle
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// Artifact is an artifact in the bundle
+type Artifact struct {
+	// Name is the URL of the binary, or the name of the image
+	Name string `json:"name"`
+	// Type is the type of the artifact, binary or image
+	Type string `json:"type"`
+	// Path is the path of the artifact in the bundle, relative to the cache directory
+	Path string `json:"path"`
+	// Digest is the sha256 digest of the artifact
+	Digest string `json:"digest"`
+}
+
+// entry is an artifact to be written to the bundle
+type entry struct {
+	Artifact
+	// file is the path of the artifact on the local filesystem
+	file string
+}
+
+// Create downloads the binaries and pulls the images into the bundle dest.
+// The binaries are cached in the cacheDir in the same layout as they are restored by Load.
+func Create(ctx context.Context, dest, cacheDir string, binaries, images []string, quiet bool) error {
+	logger := log.FromContext(ctx)
+
+	entries := make([]entry, 0, len(binaries)+len(images))
+	for _, binary := range binaries {
+		src := binary
+		mode := os.FileMode(0750)
+		if s := strings.SplitN(binary, "#", 2); len(s) == 2 {
+			// the binary is extracted from the archive by the runtime
+			src = s[0]
+			mode = 0644
+		}
+
+		cache, err := file.Download(ctx, cacheDir, src, mode, quiet)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", src, err)
+		}
+		rel, err := filepath.Rel(cacheDir, cache)
+		if err != nil || !filepath.IsLocal(rel) {
+			logger.Warn("Skip local binary, it is not downloaded by the runtime",
+				"binary", binary,
+			)
+			continue
+		}
+		entries = append(entries, entry{
+			Artifact: Artifact{
+				Name: binary,
+				Type: ArtifactTypeBinary,
+				Path: filepath.ToSlash(rel),
+			},
+			file: cache,
+		})
+	}
+
+	if len(images) != 0 {
+		tmpDir, err := os.MkdirTemp("", "kwokctl-bundle-")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = os.RemoveAll(tmpDir)
+			if err != nil {
+				logger.Error("Failed to remove temporary directory", err)
+			}
+		}()
+
+		for _, img := range images {
+			tarball := image.BundledTarballPath(tmpDir, img)
+			err = os.MkdirAll(filepath.Dir(tarball), 0750)
+			if err != nil {
+				return err
+			}
+			err = image.Pull(ctx, path.Join(cacheDir, "blobs"), img, tarball, quiet)
+			if err != nil {
+				return fmt.Errorf("failed to pull %s: %w", img, err)
+			}
+			rel, err := filepath.Rel(tmpDir, tarball)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry{
+				Artifact: Artifact{
+					Name: img,
+					Type: ArtifactTypeImage,
+					Path: filepath.ToSlash(rel),
+				},
+				file: tarball,
+			})
+		}
+	}
+
+	return writeBundle(ctx, dest, entries)
+}
+
+func writeBundle(ctx context.Context, dest string, entries []entry) (err error) {
+	logger := log.FromContext(ctx)
+
+	manifest := Manifest{
+		Artifacts: make([]Artifact, 0, len(entries)),
+	}
+	for i := range entries {
+		entries[i].Digest, err = digestFile(entries[i].file)
+		if err != nil {
+			return err
+		}
+		manifest.Artifacts = append(manifest.Artifacts, entries[i].Artifact)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dest), 0750)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(dest+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(dest + ".tmp")
+		}
+	}()
+
+	w := file.Compress(dest, f)
+	tw := tar.NewWriter(w)
+
+	// the manifest is always the first entry, so that Load can verify the artifacts while reading
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     ManifestName,
+		Mode:     0644,
+		Size:     int64(len(data)),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		err = writeFile(tw, e.Path, e.file)
+		if err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", e.Name, err)
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(dest+".tmp", dest)
+	if err != nil {
+		return err
+	}
+	logger.Info("Bundle is created",
+		"bundle", dest,
+		"artifacts", len(entries),
+	)
+	return nil
+}
+
+func writeFile(tw *tar.Writer, name, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(fi.Mode().Perm()),
+		Size:     fi.Size(),
+		ModTime:  fi.ModTime(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// Load restores the artifacts of the bundle src into the cacheDir,
+// the runtime then uses them instead of downloading or pulling.
+func Load(ctx context.Context, src, cacheDir string) error {
+	logger := log.FromContext(ctx)
+
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	r, err := file.Decompress(src, f)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil {
+		return fmt.Errorf("failed to read bundle %s: %w", src, err)
+	}
+	if hdr.Name != ManifestName {
+		return fmt.Errorf("bundle %s: the first entry must be %s, got %s", src, ManifestName, hdr.Name)
+	}
+	var manifest Manifest
+	err = json.NewDecoder(tr).Decode(&manifest)
+	if err != nil {
+		return fmt.Errorf("failed to decode manifest of bundle %s: %w", src, err)
+	}
+
+	artifacts := make(map[string]Artifact, len(manifest.Artifacts))
+	for _, artifact := range manifest.Artifacts {
+		if !filepath.IsLocal(filepath.FromSlash(artifact.Path)) {
+			return fmt.Errorf("bundle %s: invalid path %q of %s", src, artifact.Path, artifact.Name)
+		}
+		artifacts[artifact.Path] = artifact
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read bundle %s: %w", src, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		artifact, ok := artifacts[hdr.Name]
+		if !ok {
+			return fmt.Errorf("bundle %s: %s is not in the manifest", src, hdr.Name)
+		}
+		delete(artifacts, hdr.Name)
+
+		dest := path.Join(cacheDir, filepath.FromSlash(artifact.Path))
+		if artifact.Type == ArtifactTypeImage {
+			// the runtime only skips pulling the images found here
+			dest = image.BundledTarballPath(cacheDir, artifact.Name)
+		}
+		err = extractFile(tr, dest, os.FileMode(hdr.Mode).Perm(), artifact.Digest)
+		if err != nil {
+			return fmt.Errorf("failed to load %s from bundle %s: %w", artifact.Name, src, err)
+		}
+		logger.Debug("Loaded artifact",
+			"type", artifact.Type,
+			"name", artifact.Name,
+		)
+	}
+
+	if len(artifacts) != 0 {
+		missing := make([]string, 0, len(artifacts))
+		for _, artifact := range artifacts {
+			missing = append(missing, artifact.Name)
+		}
+		return fmt.Errorf("bundle %s: missing artifacts %v", src, missing)
+	}
+
+	logger.Info("Bundle is loaded",
+		"bundle", src,
+		"artifacts", len(manifest.Artifacts),
+	)
+	return nil
+}
+
+func extractFile(r io.Reader, dest string, mode os.FileMode, digest string) (err error) {
+	err = os.MkdirAll(filepath.Dir(dest), 0750)
+	if err != nil {
+		return err
+	}
+
+	tmp := dest + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+		}
+	}()
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), r)
+	if err != nil {
+		return err
+	}
+	if got := "sha256:" + hex.EncodeToString(h.Sum(nil)); got != digest {
+		return fmt.Errorf("digest mismatch: %s != %s", got, digest)
+	}
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, dest)
+}
+
+func digestFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBundle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	binary := filepath.Join(dir, "src", "kube-apiserver")
+	tarball := filepath.Join(dir, "src", "etcd.tar")
+	if err := os.MkdirAll(filepath.Dir(binary), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(binary, []byte("binary"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tarball, []byte("image"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []entry{
+		{
+			Artifact: Artifact{
+				Name: "https://dl.k8s.io/release/v1.31.0/bin/linux/amd64/kube-apiserver",
+				Type: ArtifactTypeBinary,
+				Path: "https/dl.k8s.io/release/v1.31.0/bin/linux/amd64/kube-apiserver",
+			},
+			file: binary,
+		},
+		{
+			Artifact: Artifact{
+				Name: "registry.k8s.io/etcd:3.5.15-0",
+				Type: ArtifactTypeImage,
+				Path: "bundle/registry.k8s.io/etcd:3.5.15-0.tar",
+			},
+			file: tarball,
+		},
+	}
+
+	for _, name := range []string{"bundle.tar", "bundle.tar.gz"} {
+		t.Run(name, func(t *testing.T) {
+			bundle := filepath.Join(dir, name)
+			if err := writeBundle(ctx, bundle, entries); err != nil {
+				t.Fatalf("writeBundle() error = %v", err)
+			}
+
+			cacheDir := filepath.Join(dir, "cache-"+name)
+			if err := Load(ctx, bundle, cacheDir); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			for _, e := range entries {
+				want, err := os.ReadFile(e.file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				dest := filepath.Join(cacheDir, filepath.FromSlash(e.Path))
+				got, err := os.ReadFile(dest)
+				if err != nil {
+					t.Fatalf("artifact %s is not loaded: %v", e.Name, err)
+				}
+				if string(got) != string(want) {
+					t.Errorf("artifact %s: got %q, want %q", e.Name, got, want)
+				}
+			}
+
+			fi, err := os.Stat(filepath.Join(cacheDir, filepath.FromSlash(entries[0].Path)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fi.Mode().Perm()&0100 == 0 {
+				t.Errorf("binary is not executable: %v", fi.Mode())
+			}
+		})
+	}
+}
+
+func TestLoadDigestMismatch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	src := filepath.Join(dir, "kubectl")
+	if err := os.WriteFile(src, []byte("payload"), 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := filepath.Join(dir, "bundle.tar")
+	err := writeBundle(ctx, bundle, []entry{
+		{
+			Artifact: Artifact{
+				Name: "https://dl.k8s.io/kubectl",
+				Type: ArtifactTypeBinary,
+				Path: "https/dl.k8s.io/kubectl",
+			},
+			file: src,
+		},
+	})
+	if err != nil {
+		t.Fatalf("writeBundle() error = %v", err)
+	}
+
+	// tamper with the artifact after the manifest is written
+	data, err := os.ReadFile(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = []byte(strings.Replace(string(data), "payload", "PAYLOAD", 1))
+	if err := os.WriteFile(bundle, data, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	cacheDir := filepath.Join(dir, "cache")
+	err = Load(ctx, bundle, cacheDir)
+	if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Fatalf("Load() error = %v, want digest mismatch", err)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "https", "dl.k8s.io", "kubectl")); err == nil {
+		t.Errorf("artifact with mismatched digest is loaded")
+	}
+}
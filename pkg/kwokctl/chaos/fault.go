@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// Fault is the kind of fault injected into a component.
+type Fault string
+
+const (
+	// FaultKill stops the component and starts it again immediately.
+	FaultKill Fault = "kill"
+	// FaultPause freezes the component for the duration, the connections to it are kept open.
+	FaultPause Fault = "pause"
+	// FaultRestartLoop kills the component at every interval for the duration.
+	FaultRestartLoop Fault = "restart-loop"
+	// FaultSlowStart stops the component and starts it again after the duration.
+	FaultSlowStart Fault = "slow-start"
+)
+
+// Faults is the list of all faults.
+var Faults = []Fault{
+	FaultKill,
+	FaultPause,
+	FaultRestartLoop,
+	FaultSlowStart,
+}
+
+// Target is the cluster where the faults are injected.
+type Target interface {
+	// StartComponent start cluster component
+	StartComponent(ctx context.Context, name string) error
+	// StopComponent stop cluster component
+	StopComponent(ctx context.Context, name string) error
+}
+
+// Pauser is a Target that can freeze a component without stopping it.
+type Pauser interface {
+	// PauseComponent freezes the component until it is unpaused
+	PauseComponent(ctx context.Context, name string) error
+	// UnpauseComponent resumes the component that was paused
+	UnpauseComponent(ctx context.Context, name string) error
+}
+
+// Injection is a fault injected into a component.
+type Injection struct {
+	// Component is the name of the component.
+	Component string `json:"component"`
+	// Fault is the kind of fault.
+	Fault Fault `json:"fault"`
+	// Duration is how long the fault lasts, it is ignored by kill.
+	Duration metav1.Duration `json:"duration,omitempty"`
+	// Interval is the interval between the kills of restart-loop.
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// Validate checks if the injection is valid.
+func (i Injection) Validate() error {
+	if i.Component == "" {
+		return fmt.Errorf("component is required")
+	}
+	switch i.Fault {
+	case FaultKill:
+	case FaultPause, FaultSlowStart:
+		if i.Duration.Duration <= 0 {
+			return fmt.Errorf("fault %s requires a positive duration", i.Fault)
+		}
+	case FaultRestartLoop:
+		if i.Duration.Duration <= 0 || i.Interval.Duration <= 0 {
+			return fmt.Errorf("fault %s requires a positive duration and interval", i.Fault)
+		}
+	default:
+		return fmt.Errorf("unknown fault %q, must be one of %v", i.Fault, Faults)
+	}
+	return nil
+}
+
+// Inject injects the fault into the component and returns when the fault is over.
+// A paused or stopped component is always recovered, even if the context is canceled.
+func Inject(ctx context.Context, target Target, inj Injection) error {
+	err := inj.Validate()
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", inj.Component, "fault", inj.Fault)
+	ctx = log.NewContext(ctx, logger)
+
+	switch inj.Fault {
+	case FaultKill:
+		return kill(ctx, target, inj.Component)
+	case FaultPause:
+		return pause(ctx, target, inj.Component, inj.Duration.Duration)
+	case FaultRestartLoop:
+		return restartLoop(ctx, target, inj.Component, inj.Duration.Duration, inj.Interval.Duration)
+	case FaultSlowStart:
+		return slowStart(ctx, target, inj.Component, inj.Duration.Duration)
+	}
+	return nil
+}
+
+func kill(ctx context.Context, target Target, name string) error {
+	logger := log.FromContext(ctx)
+	logger.Info("Killing component")
+	err := target.StopComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	return target.StartComponent(context.WithoutCancel(ctx), name)
+}
+
+func pause(ctx context.Context, target Target, name string, duration time.Duration) error {
+	pauser, ok := target.(Pauser)
+	if !ok {
+		return fmt.Errorf("runtime does not support pausing component %s", name)
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("Pausing component", "duration", duration)
+	err := pauser.PauseComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	sleep(ctx, duration)
+	logger.Info("Unpausing component")
+	return pauser.UnpauseComponent(context.WithoutCancel(ctx), name)
+}
+
+func restartLoop(ctx context.Context, target Target, name string, duration, interval time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	for {
+		err := kill(ctx, target, name)
+		if err != nil {
+			return err
+		}
+		if !sleep(ctx, interval) {
+			return nil
+		}
+	}
+}
+
+func slowStart(ctx context.Context, target Target, name string, duration time.Duration) error {
+	logger := log.FromContext(ctx)
+	logger.Info("Stopping component", "delay", duration)
+	err := target.StopComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	sleep(ctx, duration)
+	logger.Info("Starting component")
+	return target.StartComponent(context.WithoutCancel(ctx), name)
+}
+
+// sleep waits for the duration and returns false if the context is done before.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
+)
+
+// errDropped is returned by a read whose data is dropped.
+var errDropped = errors.New("dropped by chaos proxy")
+
+// ProxyConfig is the configuration of a Proxy.
+type ProxyConfig struct {
+	// Target is the address the connections are forwarded to.
+	Target string
+	// Latency is added to every chunk of data in both directions.
+	Latency time.Duration
+	// Jitter is a random extra latency in [0, Jitter).
+	Jitter time.Duration
+	// DropRate is the probability in [0, 1] of dropping a chunk of data.
+	// A TCP stream cannot lose data silently, so the connection is reset instead.
+	DropRate float64
+	// Seed is the seed of the jitter and the drops.
+	Seed int64
+}
+
+// Proxy is a TCP proxy that injects latency and drops between the clients and the target.
+type Proxy struct {
+	conf   ProxyConfig
+	rand   rand.Rand
+	dialer net.Dialer
+}
+
+// NewProxy creates a new Proxy.
+func NewProxy(conf ProxyConfig) (*Proxy, error) {
+	if conf.Target == "" {
+		return nil, fmt.Errorf("no target for proxy")
+	}
+	if conf.DropRate < 0 || conf.DropRate > 1 {
+		return nil, fmt.Errorf("drop rate %v must be in [0, 1]", conf.DropRate)
+	}
+	if conf.Latency < 0 || conf.Jitter < 0 {
+		return nil, fmt.Errorf("latency and jitter must not be negative")
+	}
+	return &Proxy{
+		conf: conf,
+		rand: rand.New(conf.Seed),
+		dialer: net.Dialer{
+			Timeout: 5 * time.Second,
+		},
+	}, nil
+}
+
+// Serve accepts connections on the listener and forwards them until the context is canceled.
+func (p *Proxy) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go p.handle(ctx, conn)
+	}
+}
+
+func (p *Proxy) handle(ctx context.Context, conn net.Conn) {
+	logger := log.FromContext(ctx)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	target, err := p.dialer.DialContext(ctx, "tcp", p.conf.Target)
+	if err != nil {
+		logger.Warn("Failed to dial target", "target", p.conf.Target, "err", err)
+		return
+	}
+	defer func() {
+		_ = target.Close()
+	}()
+
+	// Tunnel waits for both directions, so the end of either side cancels
+	// the context to make the other side done as well.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	err = utilsnet.Tunnel(ctx,
+		&faultyConn{Conn: conn, proxy: p, done: cancel},
+		&faultyConn{Conn: target, proxy: p, done: cancel},
+		make([]byte, 32*1024), make([]byte, 32*1024),
+	)
+	if err != nil {
+		logger.Debug("Failed tunneling", "target", p.conf.Target, "err", err)
+	}
+}
+
+// delay returns the latency of a chunk of data.
+func (p *Proxy) delay() time.Duration {
+	d := p.conf.Latency
+	if p.conf.Jitter > 0 {
+		d += time.Duration(p.rand.Int63n(int64(p.conf.Jitter)))
+	}
+	return d
+}
+
+// drop returns true if a chunk of data should be dropped.
+func (p *Proxy) drop() bool {
+	return p.conf.DropRate > 0 && p.rand.Float64() < p.conf.DropRate
+}
+
+// faultyConn is a net.Conn that delays or drops the data read from it.
+type faultyConn struct {
+	net.Conn
+	proxy *Proxy
+	done  func()
+}
+
+func (c *faultyConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err != nil {
+		c.done()
+		return n, err
+	}
+	if c.proxy.drop() {
+		c.done()
+		return 0, errDropped
+	}
+	if d := c.proxy.delay(); d > 0 {
+		time.Sleep(d)
+	}
+	return n, nil
+}
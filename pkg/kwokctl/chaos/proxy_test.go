@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+func startEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", utilsnet.LocalAddress+":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() {
+					_ = conn.Close()
+				}()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func startProxy(t *testing.T, conf ProxyConfig) string {
+	proxy, err := NewProxy(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", utilsnet.LocalAddress+":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		_ = proxy.Serve(ctx, listener)
+	}()
+	return listener.Addr().String()
+}
+
+func echo(t *testing.T, addr string) (string, time.Duration, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	start := time.Now()
+	_, err = conn.Write([]byte("ping"))
+	if err != nil {
+		return "", 0, err
+	}
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	return string(buf), time.Since(start), err
+}
+
+func TestProxyLatency(t *testing.T) {
+	latency := 50 * time.Millisecond
+	addr := startProxy(t, ProxyConfig{
+		Target:  startEchoServer(t),
+		Latency: latency,
+	})
+
+	got, elapsed, err := echo(t, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "ping" {
+		t.Errorf("echo() = %q, want %q", got, "ping")
+	}
+	// The latency is added in both directions
+	if elapsed < 2*latency {
+		t.Errorf("echo() took %v, want at least %v", elapsed, 2*latency)
+	}
+}
+
+func TestProxyDrop(t *testing.T) {
+	addr := startProxy(t, ProxyConfig{
+		Target:   startEchoServer(t),
+		DropRate: 1,
+	})
+
+	_, _, err := echo(t, addr)
+	if err == nil {
+		t.Errorf("echo() want error when all data is dropped")
+	}
+}
+
+func TestNewProxy(t *testing.T) {
+	_, err := NewProxy(ProxyConfig{Target: "127.0.0.1:1", DropRate: 2})
+	if err == nil {
+		t.Errorf("NewProxy() want error for drop rate out of range")
+	}
+	_, err = NewProxy(ProxyConfig{})
+	if err == nil {
+		t.Errorf("NewProxy() want error for empty target")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+// RedirectArgs replaces the old address with the new one in the args of the components other than the target,
+// and returns the names of the changed components, which have to be restarted to connect to the new address.
+// The address is either a host:port or a path, and is only replaced where it is the whole value,
+// an item of a comma-separated value, or the host of a URL.
+func RedirectArgs(components []internalversion.Component, target string, oldAddress, newAddress string) []string {
+	var changed []string
+	for i := range components {
+		component := &components[i]
+		if component.Name == target {
+			continue
+		}
+		args := slices.Map(component.Args, func(arg string) string {
+			return redirectArg(arg, oldAddress, newAddress)
+		})
+		if slices.Equal(args, component.Args) {
+			continue
+		}
+		component.Args = args
+		changed = append(changed, component.Name)
+	}
+	return changed
+}
+
+// redirectArg replaces the old address with the new one in the value of the arg "--key=value".
+func redirectArg(arg string, oldAddress, newAddress string) string {
+	key, value, ok := strings.Cut(arg, "=")
+	if !ok {
+		return arg
+	}
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = redirectAddress(item, oldAddress, newAddress)
+	}
+	return key + "=" + strings.Join(items, ",")
+}
+
+func redirectAddress(item string, oldAddress, newAddress string) string {
+	if item == oldAddress {
+		return newAddress
+	}
+	u, err := url.Parse(item)
+	if err != nil || u.Host != oldAddress {
+		return item
+	}
+	prefix := u.Scheme + "://" + oldAddress
+	rest, ok := strings.CutPrefix(item, prefix)
+	if !ok {
+		return item
+	}
+	return u.Scheme + "://" + newAddress + rest
+}
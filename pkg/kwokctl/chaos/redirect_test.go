@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestRedirectArgs(t *testing.T) {
+	components := []internalversion.Component{
+		{
+			Name: "etcd",
+			Args: []string{"--listen-client-urls=http://127.0.0.1:2379"},
+		},
+		{
+			Name: "kube-apiserver",
+			Args: []string{"--etcd-servers=http://127.0.0.1:2379", "--secure-port=6443"},
+		},
+		{
+			Name: "kwok-controller",
+			Args: []string{"--kubeconfig=/kubeconfig.yaml"},
+		},
+		{
+			Name: "prometheus",
+			Args: []string{"--web.listen-address=127.0.0.1:23790", "--targets=http://127.0.0.1:23790/metrics"},
+		},
+	}
+
+	changed := RedirectArgs(components, "etcd", "127.0.0.1:2379", "127.0.0.1:12379")
+	if diff := cmp.Diff([]string{"kube-apiserver"}, changed); diff != "" {
+		t.Errorf("unexpected changed components (-want +got):\n%s", diff)
+	}
+
+	want := []internalversion.Component{
+		{
+			Name: "etcd",
+			Args: []string{"--listen-client-urls=http://127.0.0.1:2379"},
+		},
+		{
+			Name: "kube-apiserver",
+			Args: []string{"--etcd-servers=http://127.0.0.1:12379", "--secure-port=6443"},
+		},
+		{
+			Name: "kwok-controller",
+			Args: []string{"--kubeconfig=/kubeconfig.yaml"},
+		},
+		{
+			Name: "prometheus",
+			Args: []string{"--web.listen-address=127.0.0.1:23790", "--targets=http://127.0.0.1:23790/metrics"},
+		},
+	}
+	if diff := cmp.Diff(want, components); diff != "" {
+		t.Errorf("unexpected components (-want +got):\n%s", diff)
+	}
+}
+
+func Test_redirectArg(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{
+			name: "address",
+			arg:  "--etcd=127.0.0.1:2379",
+			want: "--etcd=127.0.0.1:12379",
+		},
+		{
+			name: "urls",
+			arg:  "--etcd-servers=http://127.0.0.1:2379,http://127.0.0.1:2381/path",
+			want: "--etcd-servers=http://127.0.0.1:12379,http://127.0.0.1:2381/path",
+		},
+		{
+			name: "longer port",
+			arg:  "--etcd-servers=http://127.0.0.1:23790,127.0.0.1:23791",
+			want: "--etcd-servers=http://127.0.0.1:23790,127.0.0.1:23791",
+		},
+		{
+			name: "no value",
+			arg:  "--debug",
+			want: "--debug",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redirectArg(tt.arg, "127.0.0.1:2379", "127.0.0.1:12379"); got != tt.want {
+				t.Errorf("redirectArg() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
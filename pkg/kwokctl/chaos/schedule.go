@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// Schedule is a list of injections that run in order.
+type Schedule struct {
+	// Steps is the list of injections.
+	Steps []Step `json:"steps"`
+}
+
+// Step is an injection at an offset from the start of the schedule.
+type Step struct {
+	// At is the offset from the start of the schedule.
+	At metav1.Duration `json:"at"`
+
+	Injection `json:",inline"`
+}
+
+// LoadSchedule reads a scripted schedule in YAML and sorts its steps by offset.
+func LoadSchedule(r io.Reader) (*Schedule, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	schedule := &Schedule{}
+	err = yaml.UnmarshalStrict(data, schedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schedule: %w", err)
+	}
+	for i, step := range schedule.Steps {
+		err = step.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i, err)
+		}
+	}
+	slices.SortStableFunc(schedule.Steps, func(a, b Step) int {
+		return cmp.Compare(a.At.Duration, b.At.Duration)
+	})
+	return schedule, nil
+}
+
+// RandomConfig is the configuration of a random schedule.
+type RandomConfig struct {
+	// Components is the list of components to pick from.
+	Components []string
+	// Faults is the list of faults to pick from.
+	Faults []Fault
+	// Count is the number of injections.
+	Count int
+	// Interval is the offset between two injections.
+	Interval time.Duration
+	// Duration is the duration of each injection.
+	Duration time.Duration
+	// Seed is the seed of the random picks, the same seed gives the same schedule.
+	Seed int64
+}
+
+// RandomSchedule returns a schedule that picks a random component and fault at every interval.
+func RandomSchedule(conf RandomConfig) (*Schedule, error) {
+	if len(conf.Components) == 0 {
+		return nil, fmt.Errorf("no components to pick from")
+	}
+	if len(conf.Faults) == 0 {
+		conf.Faults = Faults
+	}
+
+	//nolint:gosec
+	r := rand.New(rand.NewSource(conf.Seed))
+	schedule := &Schedule{
+		Steps: make([]Step, 0, conf.Count),
+	}
+	for i := 0; i < conf.Count; i++ {
+		step := Step{
+			At: metav1.Duration{Duration: time.Duration(i) * conf.Interval},
+			Injection: Injection{
+				Component: conf.Components[r.Intn(len(conf.Components))],
+				Fault:     conf.Faults[r.Intn(len(conf.Faults))],
+				Duration:  metav1.Duration{Duration: conf.Duration},
+			},
+		}
+		if step.Fault == FaultRestartLoop {
+			// Restart a few times within the duration
+			step.Interval = metav1.Duration{Duration: conf.Duration / 3}
+		}
+		err := step.Validate()
+		if err != nil {
+			return nil, err
+		}
+		schedule.Steps = append(schedule.Steps, step)
+	}
+	return schedule, nil
+}
+
+// Run runs the steps of the schedule in order.
+// A step starts at its offset, or when the previous step is over if that is later.
+func Run(ctx context.Context, target Target, schedule *Schedule) error {
+	logger := log.FromContext(ctx)
+	start := time.Now()
+	for i, step := range schedule.Steps {
+		wait := step.At.Duration - time.Since(start)
+		if wait > 0 && !sleep(ctx, wait) {
+			return ctx.Err()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		logger.Info("Run step", "step", i, "at", step.At.Duration)
+		err := Inject(ctx, target, step.Injection)
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeTarget struct {
+	mut   sync.Mutex
+	calls []string
+}
+
+func (f *fakeTarget) record(call string) error {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	f.calls = append(f.calls, call)
+	return nil
+}
+
+func (f *fakeTarget) StartComponent(_ context.Context, name string) error {
+	return f.record("start " + name)
+}
+
+func (f *fakeTarget) StopComponent(_ context.Context, name string) error {
+	return f.record("stop " + name)
+}
+
+func (f *fakeTarget) PauseComponent(_ context.Context, name string) error {
+	return f.record("pause " + name)
+}
+
+func (f *fakeTarget) UnpauseComponent(_ context.Context, name string) error {
+	return f.record("unpause " + name)
+}
+
+func TestLoadSchedule(t *testing.T) {
+	schedule, err := LoadSchedule(strings.NewReader(`
+steps:
+- at: 2s
+  component: etcd
+  fault: pause
+  duration: 1s
+- at: 0s
+  component: kube-apiserver
+  fault: kill
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Step{
+		{
+			Injection: Injection{Component: "kube-apiserver", Fault: FaultKill},
+		},
+		{
+			At:        metav1.Duration{Duration: 2 * time.Second},
+			Injection: Injection{Component: "etcd", Fault: FaultPause, Duration: metav1.Duration{Duration: time.Second}},
+		},
+	}
+	if !reflect.DeepEqual(schedule.Steps, want) {
+		t.Errorf("LoadSchedule() = %v, want %v", schedule.Steps, want)
+	}
+
+	_, err = LoadSchedule(strings.NewReader(`
+steps:
+- component: etcd
+  fault: pause
+`))
+	if err == nil {
+		t.Errorf("LoadSchedule() want error for pause without duration")
+	}
+}
+
+func TestRandomSchedule(t *testing.T) {
+	conf := RandomConfig{
+		Components: []string{"etcd", "kube-apiserver"},
+		Count:      10,
+		Interval:   time.Second,
+		Duration:   3 * time.Second,
+		Seed:       1,
+	}
+	got1, err := RandomSchedule(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got2, err := RandomSchedule(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got1, got2) {
+		t.Errorf("RandomSchedule() is not reproducible with the same seed")
+	}
+	if len(got1.Steps) != conf.Count {
+		t.Fatalf("RandomSchedule() got %d steps, want %d", len(got1.Steps), conf.Count)
+	}
+	for i, step := range got1.Steps {
+		if step.At.Duration != time.Duration(i)*conf.Interval {
+			t.Errorf("step %d at %v, want %v", i, step.At.Duration, time.Duration(i)*conf.Interval)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	target := &fakeTarget{}
+	schedule := &Schedule{
+		Steps: []Step{
+			{
+				Injection: Injection{Component: "etcd", Fault: FaultKill},
+			},
+			{
+				At:        metav1.Duration{Duration: 10 * time.Millisecond},
+				Injection: Injection{Component: "etcd", Fault: FaultPause, Duration: metav1.Duration{Duration: time.Millisecond}},
+			},
+			{
+				Injection: Injection{Component: "kube-apiserver", Fault: FaultSlowStart, Duration: metav1.Duration{Duration: time.Millisecond}},
+			},
+		},
+	}
+	err := Run(context.Background(), target, schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"stop etcd",
+		"start etcd",
+		"pause etcd",
+		"unpause etcd",
+		"stop kube-apiserver",
+		"start kube-apiserver",
+	}
+	if !reflect.DeepEqual(target.calls, want) {
+		t.Errorf("Run() calls = %v, want %v", target.calls, want)
+	}
+}
+
+func TestRunRecoversOnCancel(t *testing.T) {
+	target := &fakeTarget{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := Inject(ctx, target, Injection{Component: "etcd", Fault: FaultPause, Duration: metav1.Duration{Duration: time.Hour}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"pause etcd", "unpause etcd"}
+	if !reflect.DeepEqual(target.calls, want) {
+		t.Errorf("Inject() calls = %v, want %v", target.calls, want)
+	}
+}
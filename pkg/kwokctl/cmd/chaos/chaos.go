@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chaos contains a parent command which injects faults into one of cluster.
+package chaos
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/chaos/inject"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/chaos/proxy"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/chaos/run"
+)
+
+// NewCommand returns a new cobra.Command for cluster chaos
+func NewCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "chaos [command]",
+		Short: "Chaos [inject, run, proxy] one of cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(inject.NewCommand(ctx))
+	cmd.AddCommand(run.NewCommand(ctx))
+	cmd.AddCommand(proxy.NewCommand(ctx))
+	return cmd
+}
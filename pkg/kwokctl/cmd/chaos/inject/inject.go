@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inject provides a command to inject a fault into a component of a cluster.
+package inject
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/chaos"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+
+	Fault    string
+	Duration time.Duration
+	Interval time.Duration
+}
+
+// NewCommand returns a new cobra.Command for injecting a fault.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "inject [component]",
+		Short: "Inject a fault into a component of the cluster and wait until it is over",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&flags.Fault, "fault", string(chaos.FaultKill), "Fault to inject (kill, pause, restart-loop, slow-start)")
+	cmd.Flags().DurationVar(&flags.Duration, "duration", 30*time.Second, "How long the fault lasts, ignored by kill")
+	cmd.Flags().DurationVar(&flags.Interval, "interval", 5*time.Second, "Interval between the kills of restart-loop")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, component string) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	start := time.Now()
+	err = chaos.Inject(ctx, rt, chaos.Injection{
+		Component: component,
+		Fault:     chaos.Fault(flags.Fault),
+		Duration:  metav1.Duration{Duration: flags.Duration},
+		Interval:  metav1.Duration{Duration: flags.Interval},
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info("Fault is over",
+		"elapsed", time.Since(start),
+	)
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package proxy provides a command to put a faulty TCP proxy in front of a component.
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/chaos"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// proxyKubeconfigName is the name of the kubeconfig pointing to the proxy in front of kube-apiserver.
+const proxyKubeconfigName = "kubeconfig.chaos-proxy.yaml"
+
+type flagpole struct {
+	Name string
+
+	Port     uint32
+	Latency  time.Duration
+	Jitter   time.Duration
+	DropRate float64
+	Seed     int64
+}
+
+// NewCommand returns a new cobra.Command for the faulty proxy.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   "proxy [kube-apiserver, etcd]",
+		Short: "Serve a TCP proxy injecting latency and drops in front of a component of a binary cluster, and redirect its clients to the proxy",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args[0])
+		},
+	}
+	cmd.Flags().Uint32Var(&flags.Port, "port", 0, "Port of the proxy, default to an unused port")
+	cmd.Flags().DurationVar(&flags.Latency, "latency", 0, "Latency added to each chunk of data in both directions")
+	cmd.Flags().DurationVar(&flags.Jitter, "jitter", 0, "Random extra latency up to the jitter")
+	cmd.Flags().Float64Var(&flags.DropRate, "drop-rate", 0, "Probability of dropping a chunk of data, which resets the connection")
+	cmd.Flags().Int64Var(&flags.Seed, "seed", 0, "Seed of the jitter and drops, default to the current time")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, component string) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name, "component", component)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if conf.Options.Runtime != consts.RuntimeTypeBinary {
+		return fmt.Errorf("proxy only supports the %s runtime, but got %s", consts.RuntimeTypeBinary, conf.Options.Runtime)
+	}
+
+	port := flags.Port
+	if port == 0 {
+		port, err = utilsnet.GetUnusedPort(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+	address := utilsnet.LocalAddress + ":" + format.String(port)
+
+	// The clients of etcd have its address in the args,
+	// and the clients of kube-apiserver have the kubeconfig in the args,
+	// so they are redirected to the proxy by replacing them.
+	var target, oldArg, newArg string
+	switch component {
+	case consts.ComponentKubeApiserver:
+		target = utilsnet.LocalAddress + ":" + format.String(conf.Options.KubeApiserverPort)
+		oldArg = path.Join(workdir, runtime.InHostKubeconfigName)
+		newArg = path.Join(workdir, proxyKubeconfigName)
+		kubeconfig, err := os.ReadFile(oldArg)
+		if err != nil {
+			return err
+		}
+		kubeconfig = bytes.ReplaceAll(kubeconfig, []byte("https://"+target), []byte("https://"+address))
+		err = os.WriteFile(newArg, kubeconfig, 0640)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = os.Remove(newArg)
+		}()
+	case consts.ComponentEtcd:
+		target = utilsnet.LocalAddress + ":" + format.String(conf.Options.EtcdPort)
+		oldArg = target
+		newArg = address
+	default:
+		return fmt.Errorf("proxy only supports %s and %s, but got %s", consts.ComponentKubeApiserver, consts.ComponentEtcd, component)
+	}
+
+	seed := flags.Seed
+	if seed == 0 && (flags.Jitter > 0 || flags.DropRate > 0) {
+		seed = time.Now().UnixNano()
+		logger.Info("Proxy is seeded by the current time, pass it by --seed to reproduce the jitter and drops",
+			"seed", seed,
+		)
+	}
+	proxy, err := chaos.NewProxy(chaos.ProxyConfig{
+		Target:   target,
+		Latency:  flags.Latency,
+		Jitter:   flags.Jitter,
+		DropRate: flags.DropRate,
+		Seed:     seed,
+	})
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- proxy.Serve(ctx, listener)
+	}()
+
+	// The config is changed only in memory, so the clients are back to the component
+	// when the cluster is restarted even if the proxy is not exited gracefully.
+	redirected := conf.DeepCopy()
+	clients := chaos.RedirectArgs(redirected.Components, component, oldArg, newArg)
+	if len(clients) == 0 {
+		logger.Warn("No clients of the component are found to redirect to the proxy")
+	}
+	err = restartClients(ctx, rt, redirected, clients)
+	if err != nil {
+		logger.Error("Failed to redirect the clients to the proxy", err)
+	}
+	defer func() {
+		err := restartClients(context.WithoutCancel(ctx), rt, conf, clients)
+		if err != nil {
+			logger.Error("Failed to redirect the clients back to the component", err)
+		}
+	}()
+
+	logger.Info("Proxy is serving, the clients are redirected to see the faults",
+		"address", address,
+		"target", target,
+		"clients", clients,
+	)
+	return <-serveErr
+}
+
+// restartClients restarts the clients with the config.
+func restartClients(ctx context.Context, rt runtime.Runtime, conf *internalversion.KwokctlConfiguration, clients []string) error {
+	err := rt.SetConfig(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, client := range clients {
+		err = rt.StopComponent(ctx, client)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", client, err))
+			continue
+		}
+		err = rt.StartComponent(ctx, client)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to start %s: %w", client, err))
+		}
+	}
+	return errors.Join(errs...)
+}
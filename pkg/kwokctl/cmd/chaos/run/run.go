@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package run provides a command to run a schedule of faults against a cluster.
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/chaos"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+)
+
+type flagpole struct {
+	Name string
+
+	Schedule string
+
+	Components []string
+	Faults     []string
+	Count      int
+	Interval   time.Duration
+	Duration   time.Duration
+	Seed       int64
+}
+
+// NewCommand returns a new cobra.Command for running a schedule of faults.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "run",
+		Short: "Run a scripted schedule passed by --schedule, or a random one against --components",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Schedule, "schedule", "", "Path to the YAML file of the scripted schedule")
+	cmd.Flags().StringSliceVar(&flags.Components, "components", nil, "Components to pick randomly from")
+	cmd.Flags().StringSliceVar(&flags.Faults, "faults", nil, "Faults to pick randomly from, default to all faults")
+	cmd.Flags().IntVar(&flags.Count, "count", 10, "Number of random faults")
+	cmd.Flags().DurationVar(&flags.Interval, "interval", time.Minute, "Interval between random faults")
+	cmd.Flags().DurationVar(&flags.Duration, "duration", 30*time.Second, "How long each random fault lasts")
+	cmd.Flags().Int64Var(&flags.Seed, "seed", 0, "Seed of the random schedule, default to the current time")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	schedule, err := loadSchedule(ctx, flags)
+	if err != nil {
+		return err
+	}
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	start := time.Now()
+	logger.Info("Schedule is running", "steps", len(schedule.Steps))
+	err = chaos.Run(ctx, rt, schedule)
+	if err != nil {
+		return err
+	}
+	logger.Info("Schedule is over",
+		"elapsed", time.Since(start),
+	)
+	return nil
+}
+
+func loadSchedule(ctx context.Context, flags *flagpole) (*chaos.Schedule, error) {
+	if flags.Schedule != "" {
+		if len(flags.Components) != 0 {
+			return nil, fmt.Errorf("--schedule and --components are mutually exclusive")
+		}
+		f, err := os.Open(flags.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		return chaos.LoadSchedule(f)
+	}
+
+	if len(flags.Components) == 0 {
+		return nil, fmt.Errorf("either --schedule or --components is required")
+	}
+	seed := flags.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+		logger := log.FromContext(ctx)
+		logger.Info("Random schedule is seeded by the current time, pass it by --seed to reproduce the schedule",
+			"seed", seed,
+		)
+	}
+	return chaos.RandomSchedule(chaos.RandomConfig{
+		Components: flags.Components,
+		Faults: slices.Map(flags.Faults, func(f string) chaos.Fault {
+			return chaos.Fault(f)
+		}),
+		Count:    flags.Count,
+		Interval: flags.Interval,
+		Duration: flags.Duration,
+		Seed:     seed,
+	})
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster contains a command to clone a cluster.
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// cloneSnapshotName is the name of the etcd snapshot of the source cluster in the workdir of the clone.
+const cloneSnapshotName = "clone.db"
+
+type flagpole struct {
+	Name       string
+	From       string
+	Wait       time.Duration
+	Kubeconfig string
+}
+
+// NewCommand returns a new cobra.Command for cluster cloning
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	flags.Kubeconfig = path.RelFromHome(kubeconfig.GetRecommendedKubeconfigPath())
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "cluster",
+		Short: "Clones a running cluster passed by --from into a new cluster passed by --name",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.From, "from", "", "Name of the cluster to clone")
+	cmd.Flags().DurationVar(&flags.Wait, "wait", 0, "Wait for the cluster to be ready")
+	cmd.Flags().StringVar(&flags.Kubeconfig, "kubeconfig", flags.Kubeconfig, "The path to the kubeconfig file will be added to the newly created cluster and set to current-context")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.From == "" {
+		return fmt.Errorf("--from is required")
+	}
+	if flags.From == flags.Name {
+		return fmt.Errorf("cannot clone cluster %q into itself", flags.From)
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name, "from", flags.From)
+	ctx = log.NewContext(ctx, logger)
+
+	var err error
+	if flags.Kubeconfig != "" {
+		flags.Kubeconfig, err = path.Expand(flags.Kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	src, err := runtime.DefaultRegistry.Load(ctx, config.ClusterName(flags.From), path.Join(config.ClustersDir, flags.From))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist", "cluster", flags.From)
+		}
+		return err
+	}
+	srcConf, err := src.Config(ctx)
+	if err != nil {
+		return err
+	}
+	err = checkClonable(srcConf)
+	if err != nil {
+		return err
+	}
+	if ready, err := src.Ready(ctx); !src.IsDryRun() && (err != nil || !ready) {
+		return fmt.Errorf("cluster %q is not ready, only a running cluster can be cloned", flags.From)
+	}
+
+	// The other objects saved with the source, e.g. stages, are saved with the clone too.
+	objs, err := config.Load(ctx, src.GetWorkdirPath(runtime.ConfigName))
+	if err != nil {
+		return err
+	}
+	ctx = config.NewContext(ctx, config.FilterWithoutType[*internalversion.KwokctlConfiguration](objs))
+
+	buildRuntime, ok := runtime.DefaultRegistry.Get(srcConf.Options.Runtime)
+	if !ok {
+		return fmt.Errorf("runtime %q not found", srcConf.Options.Runtime)
+	}
+	rt, err := buildRuntime(name, workdir)
+	if err != nil {
+		return fmt.Errorf("runtime %v not available: %w", srcConf.Options.Runtime, err)
+	}
+	err = rt.Available(ctx)
+	if err != nil {
+		return fmt.Errorf("runtime %v not available: %w", srcConf.Options.Runtime, err)
+	}
+	if _, err = rt.Config(ctx); err == nil {
+		return fmt.Errorf("cluster %q already exists", flags.Name)
+	}
+
+	conf := srcConf.DeepCopy()
+	conf.Components = nil
+	conf.Status = internalversion.KwokctlConfigurationStatus{}
+	err = resetPorts(ctx, &conf.Options)
+	if err != nil {
+		return err
+	}
+
+	cleanUp := func() {
+		subCtx := context.Background()
+		err := rt.Uninstall(subCtx)
+		if err != nil {
+			logger.Error("Failed to clean up cluster", err)
+		} else {
+			logger.Info("Cluster is cleaned up")
+		}
+	}
+	// The components may be started partially, so they are stopped before cleaning up
+	stopAndCleanUp := func() {
+		subCtx := context.Background()
+		err := rt.Down(subCtx)
+		if err != nil {
+			logger.Error("Failed to stop cluster", err)
+		}
+		cleanUp()
+	}
+	err = rt.SetConfig(ctx, conf)
+	if err != nil {
+		logger.Error("Failed to set config", err)
+		cleanUp()
+		return err
+	}
+	err = rt.Save(ctx)
+	if err != nil {
+		logger.Error("Failed to save config", err)
+		cleanUp()
+		return err
+	}
+
+	// Create the cluster
+	start := time.Now()
+	logger.Info("Cluster is creating")
+	err = rt.Install(ctx)
+	if err != nil {
+		logger.Error("Failed to setup config", err)
+		cleanUp()
+		return err
+	}
+	logger.Info("Cluster is created",
+		"elapsed", time.Since(start),
+	)
+
+	// Take the snapshot as late as possible, so the clone is close to the source
+	snapshotPath := rt.GetWorkdirPath(cloneSnapshotName)
+	err = src.SnapshotSave(ctx, snapshotPath)
+	if err != nil {
+		logger.Error("Failed to save snapshot", err)
+		cleanUp()
+		return err
+	}
+	defer func() {
+		if rt.IsDryRun() {
+			return
+		}
+		err := file.Remove(snapshotPath)
+		if err != nil {
+			logger.Warn("Failed to remove snapshot", "path", snapshotPath, "err", err)
+		}
+	}()
+
+	if flags.Kubeconfig != "" {
+		err = rt.AddContext(ctx, flags.Kubeconfig)
+		if err != nil {
+			logger.Error("Failed to add context to kubeconfig", err,
+				"kubeconfig", flags.Kubeconfig,
+			)
+		}
+	}
+
+	// Start the cluster
+	start = time.Now()
+	logger.Info("Cluster is starting")
+	err = rt.Up(ctx)
+	if err != nil {
+		stopAndCleanUp()
+		return fmt.Errorf("failed to start cluster %q: %w", name, err)
+	}
+	logger.Info("Cluster is started",
+		"elapsed", time.Since(start),
+	)
+
+	start = time.Now()
+	logger.Info("Cluster is restoring the snapshot")
+	err = rt.SnapshotRestore(ctx, snapshotPath)
+	if err != nil {
+		stopAndCleanUp()
+		return fmt.Errorf("failed to restore snapshot of %q: %w", flags.From, err)
+	}
+	logger.Info("Cluster is restored",
+		"elapsed", time.Since(start),
+	)
+
+	// Wait for cluster to be ready
+	if flags.Wait > 0 {
+		start = time.Now()
+		logger.Info("Waiting for cluster to be ready")
+		err = rt.WaitReady(ctx, flags.Wait)
+		if err != nil {
+			logger.Error("Failed to wait for cluster to be ready", err,
+				"elapsed", time.Since(start),
+			)
+		} else {
+			logger.Info("Cluster is ready",
+				"elapsed", time.Since(start),
+			)
+		}
+	}
+	return nil
+}
+
+// checkClonable checks if the cluster can be cloned,
+// the snapshot of the source can not be restored to a clone with multiple etcd members.
+func checkClonable(conf *internalversion.KwokctlConfiguration) error {
+	if conf.Options.EtcdReplicas > 1 {
+		return fmt.Errorf("cloning a cluster with %d etcd members is not supported", conf.Options.EtcdReplicas)
+	}
+	return nil
+}
+
+// resetPorts gives the clone its own ports.
+// The ports picked by the runtime are cleared so that it picks fresh ones,
+// and the other ports given to the host are replaced by unused ones.
+func resetPorts(ctx context.Context, options *internalversion.KwokctlConfigurationOptions) error {
+	picked := []*uint32{
+		&options.KubeApiserverPort,
+	}
+	given := []*uint32{
+		&options.KubeApiserverInsecurePort,
+		&options.PrometheusPort,
+		&options.JaegerPort,
+		&options.DashboardPort,
+	}
+
+	// Only the runtimes running the components natively pick the ports of the other components
+	others := []*uint32{
+		&options.EtcdPeerPort,
+		&options.EtcdPort,
+		&options.KwokControllerPort,
+		&options.JaegerOtlpGrpcPort,
+		&options.KubeControllerManagerPort,
+		&options.KubeSchedulerPort,
+		&options.MetricsServerPort,
+	}
+	if components.GetRuntimeMode(options.Runtime) == components.RuntimeModeNative {
+		picked = append(picked, others...)
+	} else {
+		given = append(given, others...)
+	}
+
+	for _, port := range picked {
+		*port = 0
+	}
+
+	used := runtime.GetUsedPorts(ctx)
+	for _, port := range given {
+		if *port == 0 {
+			continue
+		}
+		p, err := net.GetUnusedPort(ctx, used)
+		if err != nil {
+			return err
+		}
+		used.Insert(p)
+		*port = p
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+func Test_checkClonable(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    internalversion.KwokctlConfigurationOptions
+		wantErr bool
+	}{
+		{
+			name: "single etcd member",
+			conf: internalversion.KwokctlConfigurationOptions{EtcdReplicas: 1},
+		},
+		{
+			name: "multiple kube-apiserver replicas",
+			conf: internalversion.KwokctlConfigurationOptions{EtcdReplicas: 1, KubeApiserverReplicas: 3},
+		},
+		{
+			name:    "multiple etcd members",
+			conf:    internalversion.KwokctlConfigurationOptions{EtcdReplicas: 3},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &internalversion.KwokctlConfiguration{Options: tt.conf}
+			if err := checkClonable(conf); (err != nil) != tt.wantErr {
+				t.Errorf("checkClonable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_resetPorts(t *testing.T) {
+	tests := []struct {
+		runtime string
+		picked  bool
+	}{
+		{
+			runtime: consts.RuntimeTypeBinary,
+			picked:  true,
+		},
+		{
+			runtime: consts.RuntimeTypeEmbedded,
+			picked:  true,
+		},
+		{
+			runtime: consts.RuntimeTypeDocker,
+			picked:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runtime, func(t *testing.T) {
+			options := internalversion.KwokctlConfigurationOptions{
+				Runtime:                   tt.runtime,
+				KubeApiserverPort:         32766,
+				KubeApiserverInsecurePort: 32765,
+				EtcdPort:                  32764,
+				KwokControllerPort:        32763,
+			}
+			err := resetPorts(context.Background(), &options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if options.KubeApiserverPort != 0 {
+				t.Errorf("expected the port of kube-apiserver to be picked by the runtime, got %d", options.KubeApiserverPort)
+			}
+			if options.KubeApiserverInsecurePort == 0 {
+				t.Errorf("expected the insecure port of kube-apiserver to be replaced by an unused one")
+			}
+			for _, port := range []uint32{options.EtcdPort, options.KwokControllerPort} {
+				if tt.picked && port != 0 {
+					t.Errorf("expected the port to be picked by the runtime, got %d", port)
+				}
+				if !tt.picked && port == 0 {
+					t.Errorf("expected the port to be replaced by an unused one")
+				}
+			}
+		})
+	}
+}
@@ -25,12 +25,19 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/bundle"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/kwokctl/template"
 	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
 	"sigs.k8s.io/kwok/pkg/utils/kubeconfig"
 	"sigs.k8s.io/kwok/pkg/utils/path"
 )
@@ -41,6 +48,9 @@ type flagpole struct {
 	Wait       time.Duration
 	Kubeconfig string
 	ExtraArgs  []string
+	FromBundle string
+	Template   string
+	Params     []string
 
 	*internalversion.KwokctlConfiguration
 }
@@ -57,12 +67,22 @@ func NewCommand(ctx context.Context) *cobra.Command {
 		Short: "Creates a cluster",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags.Name = config.DefaultCluster
-			return runE(cmd.Context(), flags)
+			ctx := cmd.Context()
+			var tpl *template.Template
+			if flags.Template != "" {
+				var err error
+				ctx, tpl, err = applyTemplate(ctx, cmd.Flags(), flags)
+				if err != nil {
+					return err
+				}
+			}
+			return runE(ctx, flags, tpl)
 		},
 	}
 
 	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverPort, "kube-apiserver-port", flags.Options.KubeApiserverPort, `Port of the apiserver (default random)`)
 	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverInsecurePort, "kube-apiserver-insecure-port", flags.Options.KubeApiserverInsecurePort, `Insecure port of the apiserver`)
+	cmd.Flags().Uint32Var(&flags.Options.KubeApiserverReplicas, "kube-apiserver-replicas", flags.Options.KubeApiserverReplicas, `Number of kube-apiserver replicas, more than one are served behind a load balancer, only for binary and docker/podman/nerdctl runtime`)
 	cmd.Flags().Uint32Var(&flags.Options.PrometheusPort, "prometheus-port", flags.Options.PrometheusPort, `Port to expose Prometheus metrics`)
 	cmd.Flags().Uint32Var(&flags.Options.JaegerPort, "jaeger-port", flags.Options.JaegerPort, `Port to expose Jaeger UI`)
 	cmd.Flags().BoolVar(&flags.Options.SecurePort, "secure-port", flags.Options.SecurePort, `The apiserver port on which to serve HTTPS with authentication and authorization, is not available before Kubernetes 1.13.0`)
@@ -81,6 +101,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 '${KWOK_KUBE_IMAGE_PREFIX}/etcd:${KWOK_ETCD_VERSION}'
 `)
 	cmd.Flags().Uint32Var(&flags.Options.EtcdPort, "etcd-port", flags.Options.EtcdPort, `Port of etcd given to the host. The behavior is unstable for kind/kind-podman runtime and may be modified in the future`)
+	cmd.Flags().Uint32Var(&flags.Options.EtcdReplicas, "etcd-replicas", flags.Options.EtcdReplicas, `Number of etcd members, only for binary and docker/podman/nerdctl runtime`)
 	cmd.Flags().StringVar(&flags.Options.KubeApiserverImage, "kube-apiserver-image", flags.Options.KubeApiserverImage, `Image of kube-apiserver, only for docker/podman/nerdctl runtime
 '${KWOK_KUBE_IMAGE_PREFIX}/kube-apiserver:${KWOK_KUBE_VERSION}'
 `)
@@ -150,6 +171,9 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().UintVar(&flags.Options.NodeLeaseDurationSeconds, "node-lease-duration-seconds", flags.Options.NodeLeaseDurationSeconds, "Duration of node lease in seconds")
 	cmd.Flags().Float64Var(&flags.Options.HeartbeatFactor, "heartbeat-factor", flags.Options.HeartbeatFactor, "Scale factor for all about heartbeat")
 	cmd.Flags().StringVar(&flags.Options.EtcdQuotaBackendSize, "etcd-quota-backend-size", flags.Options.EtcdQuotaBackendSize, "Quota backend size for etcd")
+	cmd.Flags().StringVar(&flags.FromBundle, "from-bundle", flags.FromBundle, "Load binaries and images from a bundle created by 'get artifacts --bundle' instead of downloading them")
+	cmd.Flags().StringVar(&flags.Template, "template", flags.Template, "Path to a cluster template, a directory or a tarball of configurations rendered with the parameters")
+	cmd.Flags().StringArrayVar(&flags.Params, "param", flags.Params, "Parameter to update of the template, e.g. 'nodes=500' or '.nodes = 500'")
 	cmd.Flags().StringArrayVar(&flags.ExtraArgs, "extra-args", flags.ExtraArgs, "Pass a single extra arg key-value pair to the component in the format `component=key=value`")
 
 	return cmd
@@ -199,7 +223,7 @@ func mutationComponentPatches(flags *flagpole) {
 	flags.KwokctlConfiguration.ComponentsPatches = append(flags.KwokctlConfiguration.ComponentsPatches, componentPatches...)
 }
 
-func runE(ctx context.Context, flags *flagpole) error {
+func runE(ctx context.Context, flags *flagpole, tpl *template.Template) error {
 	name := config.ClusterName(flags.Name)
 	workdir := path.Join(config.ClustersDir, flags.Name)
 
@@ -286,6 +310,12 @@ func runE(ctx context.Context, flags *flagpole) error {
 				logger.Info("Cluster is cleaned up")
 			}
 		}
+		if flags.FromBundle != "" {
+			err = loadBundle(ctx, rt, flags.FromBundle, flags.Options.CacheDir)
+			if err != nil {
+				return err
+			}
+		}
 		err = rt.SetConfig(ctx, flags.KwokctlConfiguration)
 		if err != nil {
 			logger.Error("Failed to set config", err)
@@ -359,6 +389,13 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return fmt.Errorf("failed to init crs %q: %w", name, err)
 	}
 
+	if tpl != nil && len(tpl.Scale) != 0 {
+		err = scaleTemplate(ctx, rt, tpl)
+		if err != nil {
+			return fmt.Errorf("failed to scale resources of template %q: %w", name, err)
+		}
+	}
+
 	// Wait for cluster to be ready
 	if flags.Wait > 0 {
 		start = time.Now()
@@ -383,5 +420,102 @@ func runE(ctx context.Context, flags *flagpole) error {
 Thanks for using kwok!
 `, name)
 	}
+	return runtime.WaitHosted(gctx, rt)
+}
+
+func loadBundle(ctx context.Context, rt runtime.Runtime, src, cacheDir string) error {
+	src, err := path.Expand(src)
+	if err != nil {
+		return err
+	}
+	if rt.IsDryRun() {
+		dryrun.PrintMessage("# Load bundle %s into %s", src, cacheDir)
+		return nil
+	}
+	return bundle.Load(ctx, src, cacheDir)
+}
+
+// applyTemplate renders the template into the configurations of the context,
+// the KwokctlConfiguration of the template replaces the loaded one, but the flags set on the command line still take precedence.
+func applyTemplate(ctx context.Context, fs *pflag.FlagSet, flags *flagpole) (context.Context, *template.Template, error) {
+	src, err := path.Expand(flags.Template)
+	if err != nil {
+		return nil, nil, err
+	}
+	tpl, err := template.Render(ctx, src, flags.Params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	confs := config.FilterWithType[*internalversion.KwokctlConfiguration](tpl.Objects)
+	if len(confs) > 1 {
+		return nil, nil, fmt.Errorf("template %s has more than one KwokctlConfiguration", flags.Template)
+	}
+	if len(confs) == 1 {
+		type changed struct {
+			flag  *pflag.Flag
+			value string
+			slice []string
+		}
+		var changes []changed
+		fs.Visit(func(f *pflag.Flag) {
+			c := changed{flag: f, value: f.Value.String()}
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				c.slice = sv.GetSlice()
+			}
+			changes = append(changes, c)
+		})
+
+		*flags.KwokctlConfiguration = *confs[0]
+
+		for _, c := range changes {
+			if sv, ok := c.flag.Value.(pflag.SliceValue); ok {
+				err = sv.Replace(c.slice)
+			} else {
+				err = c.flag.Value.Set(c.value)
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to set flag --%s over template: %w", c.flag.Name, err)
+			}
+		}
+	}
+
+	objs := config.FilterWithoutType[*internalversion.KwokctlConfiguration](config.GetFromContext(ctx))
+	objs = append(objs, config.FilterWithoutType[*internalversion.KwokctlConfiguration](tpl.Objects)...)
+	objs = append(objs, flags.KwokctlConfiguration)
+	return config.NewContext(ctx, objs), tpl, nil
+}
+
+// scaleTemplate creates the resources declared by the template
+func scaleTemplate(ctx context.Context, rt runtime.Runtime, tpl *template.Template) error {
+	clientset, err := client.NewClientset("", rt.GetWorkdirPath(runtime.InHostKubeconfigName))
+	if err != nil {
+		return err
+	}
+
+	krcs := config.FilterWithTypeFromContext[*internalversion.KwokctlResource](ctx)
+	for _, step := range tpl.Scale {
+		krc, err := scale.GetResource(ctx, krcs, step.Resource)
+		if err != nil {
+			return err
+		}
+		parameters, err := expression.NewParameters(ctx, krc.Parameters, step.Params)
+		if err != nil {
+			return err
+		}
+		err = scale.Scale(ctx, clientset, scale.Config{
+			Parameters:   parameters,
+			Template:     krc.Template,
+			Children:     krc.Children,
+			Name:         step.Name,
+			Namespace:    step.Namespace,
+			Replicas:     step.Replicas,
+			SerialLength: 6,
+			DryRun:       rt.IsDryRun(),
+		})
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
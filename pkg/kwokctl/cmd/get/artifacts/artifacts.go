@@ -28,14 +28,18 @@ import (
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/bundle"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/printers"
 )
 
 type flagpole struct {
 	Name   string
 	Filter string
+	Output string
+	Bundle string
 
 	*internalversion.KwokctlConfiguration
 }
@@ -56,10 +60,25 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	}
 	cmd.Flags().StringVar(&flags.Options.Runtime, "runtime", flags.Options.Runtime, fmt.Sprintf("Runtime of the cluster (%s)", strings.Join(runtime.DefaultRegistry.List(), " or ")))
 	cmd.Flags().StringVar(&flags.Filter, "filter", flags.Filter, "Filter the list of (binary or image)")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "name", "Output format (name, json, yaml, jsonpath=<template>)")
+	cmd.Flags().StringVar(&flags.Bundle, "bundle", flags.Bundle, "Download the artifacts into a bundle at the given path (.tar or .tar.gz), for use with 'create cluster --from-bundle'")
+	cmd.Flags().BoolVar(&flags.Options.QuietPull, "quiet-pull", flags.Options.QuietPull, "Pull without printing progress information")
 	return cmd
 }
 
+// artifact is an artifact for the machine-readable output
+type artifact struct {
+	// Name is the URL or path of the binary, or the name of the image
+	Name string `json:"name"`
+	// Type is the type of the artifact, binary or image
+	Type string `json:"type"`
+}
+
 func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Output != "name" && !printers.IsObjectOutput(flags.Output) {
+		return fmt.Errorf("unknown output format %q", flags.Output)
+	}
+
 	name := config.ClusterName(flags.Name)
 	workdir := path.Join(config.ClustersDir, flags.Name)
 
@@ -76,7 +95,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 	if err != nil {
 		return err
 	}
-	artifacts := []string{}
+	artifacts := []artifact{}
 
 	_, err = rt.Config(ctx)
 	if err != nil {
@@ -90,17 +109,43 @@ func runE(ctx context.Context, flags *flagpole) error {
 		if err != nil {
 			return err
 		}
-		artifacts = append(artifacts, binaries...)
+		for _, binary := range binaries {
+			artifacts = append(artifacts, artifact{Name: binary, Type: "binary"})
+		}
 	}
 	if flags.Filter == "" || flags.Filter == "image" {
 		images, err := rt.ListImages(ctx)
 		if err != nil {
 			return err
 		}
-		artifacts = append(artifacts, images...)
+		for _, image := range images {
+			artifacts = append(artifacts, artifact{Name: image, Type: "image"})
+		}
+	}
+
+	if flags.Bundle != "" {
+		flags.Bundle, err = path.Expand(flags.Bundle)
+		if err != nil {
+			return err
+		}
+		var binaries, images []string
+		for _, artifact := range artifacts {
+			if artifact.Type == "image" {
+				images = append(images, artifact.Name)
+			} else {
+				binaries = append(binaries, artifact.Name)
+			}
+		}
+		return bundle.Create(ctx, flags.Bundle, flags.Options.CacheDir, binaries, images, flags.Options.QuietPull)
 	}
 
-	sort.Strings(artifacts)
+	sort.Slice(artifacts, func(i, j int) bool {
+		return artifacts[i].Name < artifacts[j].Name
+	})
+
+	if printers.IsObjectOutput(flags.Output) {
+		return printers.PrintObject(os.Stdout, flags.Output, artifacts)
+	}
 
 	if len(artifacts) == 0 {
 		if flags.Filter == "" {
@@ -114,7 +159,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 		}
 	} else {
 		for _, artifact := range artifacts {
-			_, _ = fmt.Println(artifact)
+			_, _ = fmt.Println(artifact.Name)
 		}
 	}
 	return nil
@@ -46,23 +46,34 @@ func NewCommand(ctx context.Context) *cobra.Command {
 			return runE(cmd.Context(), flags)
 		},
 	}
-	cmd.Flags().StringVarP(&flags.Output, "output", "o", "name", "Output format (name, wide)")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "name", "Output format (name, wide, json, yaml, jsonpath=<template>)")
 	return cmd
 }
 
 func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Output != "name" && flags.Output != "wide" && !printers.IsObjectOutput(flags.Output) {
+		return fmt.Errorf("unknown output format %q", flags.Output)
+	}
+
 	clusters, err := runtime.ListClusters(ctx)
 	if err != nil {
 		return err
 	}
+
+	if printers.IsObjectOutput(flags.Output) {
+		infos := make([]runtime.ClusterInfo, 0, len(clusters))
+		for _, cluster := range clusters {
+			infos = append(infos, inspectCluster(ctx, cluster))
+		}
+		return printers.PrintObject(os.Stdout, flags.Output, infos)
+	}
+
 	if len(clusters) == 0 {
 		if log.IsTerminal() {
 			_, _ = fmt.Fprintf(os.Stderr, "No clusters found\n")
 		}
 	} else {
 		switch flags.Output {
-		default:
-			return fmt.Errorf("unknown output format %q", flags.Output)
 		case "name":
 			for _, cluster := range clusters {
 				_, _ = fmt.Println(cluster)
@@ -73,48 +84,8 @@ func runE(ctx context.Context, flags *flagpole) error {
 			}
 
 			for _, cluster := range clusters {
-				var readyMsg = "0/0"
-				var count int
-				workdir := path.Join(config.ClustersDir, cluster)
-				rt, err := runtime.DefaultRegistry.Load(ctx, cluster, workdir)
-				if err != nil {
-					records = append(records, []string{cluster, readyMsg, "Failed:" + err.Error()})
-					continue
-				}
-
-				components, err := rt.ListComponents(ctx)
-				if err == nil {
-					for _, component := range components {
-						s, _ := rt.InspectComponent(ctx, component.Name)
-						if s == runtime.ComponentStatusReady {
-							count++
-						}
-					}
-					readyMsg = fmt.Sprintf("%d/%d", count, len(components))
-				}
-
-				if len(components) == 0 {
-					records = append(records, []string{cluster, readyMsg, "Unknown"})
-					continue
-				}
-
-				if count == 0 {
-					records = append(records, []string{cluster, readyMsg, "Stopped"})
-					continue
-				}
-
-				ready, err := rt.Ready(ctx)
-				if err != nil {
-					records = append(records, []string{cluster, readyMsg, "Error:" + err.Error()})
-					continue
-				}
-
-				if !ready {
-					records = append(records, []string{cluster, readyMsg, "NotReady"})
-					continue
-				}
-
-				records = append(records, []string{cluster, readyMsg, "Ready"})
+				info := inspectCluster(ctx, cluster)
+				records = append(records, []string{info.Name, info.Ready, info.Status})
 			}
 
 			w := printers.NewTablePrinter(os.Stdout)
@@ -126,3 +97,16 @@ func runE(ctx context.Context, flags *flagpole) error {
 	}
 	return nil
 }
+
+func inspectCluster(ctx context.Context, cluster string) runtime.ClusterInfo {
+	workdir := path.Join(config.ClustersDir, cluster)
+	rt, err := runtime.DefaultRegistry.Load(ctx, cluster, workdir)
+	if err != nil {
+		return runtime.ClusterInfo{
+			Name:   cluster,
+			Ready:  "0/0",
+			Status: "Failed:" + err.Error(),
+		}
+	}
+	return runtime.InspectClusterInfo(ctx, cluster, rt)
+}
@@ -49,7 +49,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 			return runE(cmd.Context(), flags)
 		},
 	}
-	cmd.Flags().StringVarP(&flags.Output, "output", "o", "name", "Output format (name, wide)")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "name", "Output format (name, wide, json, yaml, jsonpath=<template>)")
 	return cmd
 }
 
@@ -74,34 +74,27 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
-	switch flags.Output {
+	switch {
 	default:
 		return fmt.Errorf("unknown output format %q", flags.Output)
-	case "name":
+	case printers.IsObjectOutput(flags.Output):
+		infos := make([]runtime.ComponentInfo, 0, len(components))
+		for _, component := range components {
+			infos = append(infos, runtime.InspectComponentInfo(ctx, rt, component))
+		}
+		return printers.PrintObject(os.Stdout, flags.Output, infos)
+	case flags.Output == "name":
 		for _, component := range components {
 			fmt.Println(component.Name)
 		}
-	case "wide":
+	case flags.Output == "wide":
 		records := [][]string{
 			{"NAME", "STATUS"},
 		}
 
 		for _, component := range components {
-			s, err := rt.InspectComponent(ctx, component.Name)
-			if err != nil {
-				records = append(records, []string{component.Name, "Error:" + err.Error()})
-				continue
-			}
-			switch s {
-			default:
-				records = append(records, []string{component.Name, "Unknown"})
-			case runtime.ComponentStatusReady:
-				records = append(records, []string{component.Name, "Ready"})
-			case runtime.ComponentStatusRunning:
-				records = append(records, []string{component.Name, "NotReady"})
-			case runtime.ComponentStatusStopped:
-				records = append(records, []string{component.Name, "Stopped"})
-			}
+			info := runtime.InspectComponentInfo(ctx, rt, component)
+			records = append(records, []string{info.Name, info.Status})
 		}
 
 		w := printers.NewTablePrinter(os.Stdout)
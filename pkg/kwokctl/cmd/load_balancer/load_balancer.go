@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package load_balancer implements the `load-balancer` command
+package load_balancer
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/log"
+	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+)
+
+type flagpole struct {
+	Bind     string
+	Backends []string
+}
+
+// NewCommand returns a new cobra.Command for the load balancer in front of kube-apiserver replicas.
+// It is run by the binary runtime as a component, and is not intended to be used directly.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:   cobra.NoArgs,
+		Use:    "load-balancer",
+		Short:  "Run a TCP load balancer",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Bind, "bind", "", "Address to listen on")
+	cmd.Flags().StringSliceVar(&flags.Backends, "backend", nil, "Address of the backend, can be specified multiple times")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.Bind == "" {
+		return fmt.Errorf("--bind is required")
+	}
+
+	lb, err := utilsnet.NewLoadBalancer(flags.Backends)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", flags.Bind)
+	if err != nil {
+		return err
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("Load balancer is serving",
+		"address", flags.Bind,
+		"backends", flags.Backends,
+	)
+	return lb.Serve(ctx, listener)
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/chaos"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/clone"
 	conf "sigs.k8s.io/kwok/pkg/kwokctl/cmd/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/create"
 	del "sigs.k8s.io/kwok/pkg/kwokctl/cmd/delete"
@@ -31,12 +33,16 @@ import (
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/get"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/hack"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/kubectl"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/load_balancer"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/logs"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/port_forward"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/scale"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/scenario"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/start"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/stop"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/supervise"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/upgrade"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/utils/version"
 )
@@ -62,18 +68,24 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(
 		conf.NewCommand(ctx),
 		create.NewCommand(ctx),
+		clone.NewCommand(ctx),
+		upgrade.NewCommand(ctx),
 		del.NewCommand(ctx),
 		get.NewCommand(ctx),
 		start.NewCommand(ctx),
 		stop.NewCommand(ctx),
+		supervise.NewCommand(ctx),
 		kubectl.NewCommand(ctx),
 		etcdctl.NewCommand(ctx),
 		logs.NewCommand(ctx),
 		scale.NewCommand(ctx),
+		scenario.NewCommand(ctx),
+		chaos.NewCommand(ctx),
 		snapshot.NewCommand(ctx),
 		export.NewCommand(ctx),
 		hack.NewCommand(ctx),
 		port_forward.NewCommand(ctx),
+		load_balancer.NewCommand(ctx),
 	)
 	return cmd
 }
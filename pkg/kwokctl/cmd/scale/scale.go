@@ -20,13 +20,12 @@ package scale
 import (
 	"context"
 	"errors"
-	"fmt"
 	"os"
 	"path"
+	"time"
 
 	"github.com/spf13/cobra"
 
-	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
@@ -35,7 +34,6 @@ import (
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
-	"sigs.k8s.io/kwok/pkg/utils/slices"
 )
 
 type flagpole struct {
@@ -45,6 +43,16 @@ type flagpole struct {
 	Namespace    string
 	Replicas     uint64
 	Params       []string
+
+	Rate          float64
+	Ramp          time.Duration
+	BatchSize     int
+	BatchInterval time.Duration
+
+	WaveMin      int
+	WavePeriod   time.Duration
+	WaveInterval time.Duration
+	WaveCycles   int
 }
 
 // NewCommand returns a new cobra.Command for scale resource.
@@ -64,6 +72,14 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.Flags().IntVar(&flags.SerialLength, "serial-length", 6, "Length of serial number")
 	cmd.Flags().StringVarP(&flags.Namespace, "namespace", "n", flags.Namespace, "Namespace of resource to scale")
 	cmd.Flags().StringArrayVar(&flags.Params, "param", flags.Params, "Parameter to update")
+	cmd.Flags().Float64Var(&flags.Rate, "rate", 0, "Maximum number of resources created or deleted per second, 0 means no limit")
+	cmd.Flags().DurationVar(&flags.Ramp, "ramp", 0, "Duration over which the creation or deletion is spread linearly")
+	cmd.Flags().IntVar(&flags.BatchSize, "batch-size", 0, "Number of resources created or deleted in each batch, requires --batch-interval")
+	cmd.Flags().DurationVar(&flags.BatchInterval, "batch-interval", 0, "Interval between batches")
+	cmd.Flags().DurationVar(&flags.WavePeriod, "wave-period", 0, "Period of oscillating the replicas between --wave-min and --replicas, 0 means no wave")
+	cmd.Flags().IntVar(&flags.WaveMin, "wave-min", 0, "Minimum number of replicas in wave mode")
+	cmd.Flags().DurationVar(&flags.WaveInterval, "wave-interval", 0, "Interval between scaling in wave mode, defaults to a tenth of --wave-period")
+	cmd.Flags().IntVar(&flags.WaveCycles, "wave-cycles", 0, "Number of cycles in wave mode, 0 means until interrupted")
 	return cmd
 }
 
@@ -95,25 +111,9 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 	}
 
 	krcs := config.FilterWithTypeFromContext[*internalversion.KwokctlResource](ctx)
-	krc, ok := slices.Find(krcs, func(krc *internalversion.KwokctlResource) bool {
-		return krc.Name == resourceKind
-	})
-	if !ok {
-		var resourceData string
-		switch resourceKind {
-		default:
-			return fmt.Errorf("resource %s is not exists", resourceKind)
-		case "pod":
-			resourceData = resource.DefaultPod
-		case "node":
-			resourceData = resource.DefaultNode
-		}
-
-		logger.Info("No resource found, use default resource", "resource", resourceKind)
-		krc, err = config.UnmarshalWithType[*internalversion.KwokctlResource](resourceData)
-		if err != nil {
-			return err
-		}
+	krc, err := scale.GetResource(ctx, krcs, resourceKind)
+	if err != nil {
+		return err
 	}
 
 	parameters, err := expression.NewParameters(ctx, krc.Parameters, flags.Params)
@@ -121,15 +121,39 @@ func runE(ctx context.Context, flags *flagpole, args []string) error {
 		return err
 	}
 
-	err = scale.Scale(ctx, clientset, scale.Config{
-		Parameters:   parameters,
-		Template:     krc.Template,
-		Name:         resourceName,
-		Namespace:    flags.Namespace,
-		Replicas:     int(flags.Replicas),
-		SerialLength: flags.SerialLength,
-		DryRun:       dryrun.DryRun,
-	})
+	conf := scale.Config{
+		Parameters:    parameters,
+		Template:      krc.Template,
+		Children:      krc.Children,
+		Name:          resourceName,
+		Namespace:     flags.Namespace,
+		Replicas:      int(flags.Replicas),
+		SerialLength:  flags.SerialLength,
+		DryRun:        dryrun.DryRun,
+		Rate:          flags.Rate,
+		Ramp:          flags.Ramp,
+		BatchSize:     flags.BatchSize,
+		BatchInterval: flags.BatchInterval,
+	}
+
+	if flags.WavePeriod > 0 {
+		waveInterval := flags.WaveInterval
+		if waveInterval == 0 {
+			waveInterval = flags.WavePeriod / 10
+		}
+		err = scale.Wave(ctx, clientset, conf, scale.WaveConfig{
+			Min:      flags.WaveMin,
+			Period:   flags.WavePeriod,
+			Interval: waveInterval,
+			Cycles:   flags.WaveCycles,
+		})
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	err = scale.Scale(ctx, clientset, conf)
 	if err != nil {
 		return err
 	}
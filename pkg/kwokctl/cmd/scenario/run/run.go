@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package run provides a command to run the scenarios against a cluster.
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scenario"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name string
+
+	Report       string
+	ReportFormat string
+}
+
+// NewCommand returns a new cobra.Command for running scenarios.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.MaximumNArgs(1),
+		Use:   "run [name]",
+		Short: "Run the scenarios passed by --config against the cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags, args)
+		},
+	}
+	cmd.Flags().StringVar(&flags.Report, "report", "", "Path to write the report, default to stdout")
+	cmd.Flags().StringVar(&flags.ReportFormat, "report-format", "json", "Format of the report (json, junit)")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, args []string) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	switch flags.ReportFormat {
+	case "json", "junit":
+	default:
+		return fmt.Errorf("unsupport report format %q", flags.ReportFormat)
+	}
+
+	scenarios := config.FilterWithTypeFromContext[*internalversion.Scenario](ctx)
+	if len(args) != 0 {
+		var selected []*internalversion.Scenario
+		for _, s := range scenarios {
+			if s.Name == args[0] {
+				selected = append(selected, s)
+			}
+		}
+		if len(selected) == 0 {
+			return fmt.Errorf("scenario %q not found", args[0])
+		}
+		scenarios = selected
+	}
+	if len(scenarios) == 0 {
+		return fmt.Errorf("no scenario found, please pass it by --config")
+	}
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	conf := scenario.Config{
+		Runtime:   rt,
+		Resources: config.FilterWithTypeFromContext[*internalversion.KwokctlResource](ctx),
+	}
+
+	var out io.Writer = os.Stdout
+	if flags.Report != "" {
+		if file.Exists(flags.Report) {
+			return fmt.Errorf("file %q already exists", flags.Report)
+		}
+		f, err := file.Open(flags.Report)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		out = f
+	}
+
+	var failed []string
+	reports := make([]*scenario.Report, 0, len(scenarios))
+	for _, s := range scenarios {
+		report := scenario.Run(ctx, s, conf)
+		if report.Failed() {
+			failed = append(failed, s.Name)
+		}
+		reports = append(reports, report)
+	}
+
+	switch flags.ReportFormat {
+	case "json":
+		err = scenario.WriteJSON(out, reports)
+	case "junit":
+		err = scenario.WriteJUnit(out, reports)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(failed) != 0 {
+		return fmt.Errorf("scenarios failed: %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diff provides a command to compare two k8s format snapshots.
+package diff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+type flagpole struct {
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for comparing snapshots.
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(2),
+		Use:   "diff [old] [new]",
+		Short: "Compare two snapshots saved with the k8s format",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(cmd.Context(), flags, args)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "text", "Output format of the difference (text, json, patch)")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole, args []string) error {
+	var write func(w io.Writer, result *snapshot.DiffResult) error
+	switch flags.Output {
+	case "text":
+		write = snapshot.WriteDiffText
+	case "json":
+		write = snapshot.WriteDiffJSON
+	case "patch":
+		write = snapshot.WriteDiffPatch
+	default:
+		return fmt.Errorf("unsupport output %q", flags.Output)
+	}
+
+	oldObjs, err := loadObjects(args[0])
+	if err != nil {
+		return err
+	}
+	newObjs, err := loadObjects(args[1])
+	if err != nil {
+		return err
+	}
+
+	result, err := snapshot.Diff(oldObjs, newObjs)
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, result)
+}
+
+func loadObjects(path string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	objs, err := snapshot.LoadObjects(yaml.NewDecoder(f))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %q: %w", path, err)
+	}
+	return objs, nil
+}
@@ -38,9 +38,11 @@ import (
 )
 
 type flagpole struct {
-	Name     string
-	Path     string
-	Snapshot bool
+	Name             string
+	Path             string
+	Snapshot         bool
+	Format           string
+	KeyframeInterval time.Duration
 }
 
 // NewCommand returns a new cobra.Command for cluster recording.
@@ -59,6 +61,8 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the recording")
 	cmd.Flags().BoolVar(&flags.Snapshot, "snapshot", false, "Only save the snapshot")
+	cmd.Flags().StringVar(&flags.Format, "format", "yaml", "Format of the recording (yaml, v2), v2 is a compressed NDJSON with a time index for seeking")
+	cmd.Flags().DurationVar(&flags.KeyframeInterval, "keyframe-interval", time.Minute, "Interval between keyframes of the full state, only support for v2 format")
 	return cmd
 }
 
@@ -71,6 +75,11 @@ func runE(ctx context.Context, flags *flagpole) error {
 	if file.Exists(flags.Path) {
 		return fmt.Errorf("file %q already exists", flags.Path)
 	}
+	switch flags.Format {
+	case "yaml", "v2":
+	default:
+		return fmt.Errorf("unsupport format %q", flags.Format)
+	}
 
 	logger := log.FromContext(ctx)
 	logger = logger.With("cluster", flags.Name)
@@ -117,19 +126,39 @@ func runE(ctx context.Context, flags *flagpole) error {
 		_ = f.Close()
 	}()
 
-	press := file.Compress(flags.Path, f)
-	defer func() {
-		_ = press.Close()
-	}()
-
-	var writer io.Writer = press
-
 	startTime := time.Now()
-	writer = recording.NewWriteHook(writer, func(bytes []byte) []byte {
+	replaceTime := func(bytes []byte) []byte {
 		return recording.ReplaceTimeToRelative(startTime, bytes)
-	})
+	}
+
+	var encoder recording.Encoder
+	if flags.Format == "v2" {
+		ws, ok := f.(io.WriteSeeker)
+		if !ok {
+			return fmt.Errorf("file %q is not seekable", flags.Path)
+		}
+		container, err := recording.NewContainerWriter(ws, recording.ContainerConfig{
+			KeyframeInterval: flags.KeyframeInterval,
+			Transform:        replaceTime,
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err := container.Close()
+			if err != nil {
+				logger.Error("Failed to close recording", err)
+			}
+		}()
+		encoder = container
+	} else {
+		press := file.Compress(flags.Path, f)
+		defer func() {
+			_ = press.Close()
+		}()
 
-	encoder := yaml.NewEncoder(writer)
+		encoder = yaml.NewEncoder(recording.NewWriteHook(press, replaceTime))
+	}
 
 	if flags.Snapshot {
 		logger.Info("Saving snapshot")
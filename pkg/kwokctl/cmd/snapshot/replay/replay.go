@@ -21,7 +21,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"time"
 
@@ -30,6 +29,7 @@ import (
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
 	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/etcd"
 	"sigs.k8s.io/kwok/pkg/kwokctl/recording"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
@@ -44,6 +44,8 @@ type flagpole struct {
 	Name     string
 	Path     string
 	Snapshot bool
+	From     time.Duration
+	To       time.Duration
 }
 
 // NewCommand returns a new cobra.Command to replay the cluster as a recording.
@@ -62,6 +64,8 @@ func NewCommand(ctx context.Context) *cobra.Command {
 
 	cmd.Flags().StringVar(&flags.Path, "path", "", "Path to the recording")
 	cmd.Flags().BoolVar(&flags.Snapshot, "snapshot", false, "Only restore the snapshot")
+	cmd.Flags().DurationVar(&flags.From, "from", 0, "Offset of the recording to start replaying, the changes before it are applied without waiting")
+	cmd.Flags().DurationVar(&flags.To, "to", 0, "Offset of the recording to stop replaying, 0 means the end")
 	return cmd
 }
 
@@ -74,6 +78,12 @@ func runE(ctx context.Context, flags *flagpole) error {
 	if !file.Exists(flags.Path) {
 		return fmt.Errorf("path %q does not exist", flags.Path)
 	}
+	if flags.From < 0 || flags.To < 0 {
+		return fmt.Errorf("from and to must be greater than or equal to 0")
+	}
+	if flags.To != 0 && flags.To < flags.From {
+		return fmt.Errorf("to must be greater than or equal to from")
+	}
 
 	logger := log.FromContext(ctx)
 	logger = logger.With("cluster", flags.Name)
@@ -92,16 +102,21 @@ func runE(ctx context.Context, flags *flagpole) error {
 		return err
 	}
 
-	components, err := rt.ListComponents(ctx)
+	// Keep all the etcd members, the kube-apiserver replicas and the load balancer in front of them running.
+	running := append(components.EtcdComponentNames(conf.Options.EtcdReplicas),
+		components.KubeApiserverComponentNames(conf.Options.KubeApiserverReplicas)...)
+	running = append(running, consts.ComponentKubeApiserverLoadBalancer)
+
+	stopped, err := rt.ListComponents(ctx)
 	if err != nil {
 		return err
 	}
 
-	components = slices.Filter(components, func(component internalversion.Component) bool {
-		return component.Name != consts.ComponentKubeApiserver && component.Name != consts.ComponentEtcd
+	stopped = slices.Filter(stopped, func(component internalversion.Component) bool {
+		return !slices.Contains(running, component.Name)
 	})
 
-	for _, component := range components {
+	for _, component := range stopped {
 		err = rt.StopComponent(ctx, component.Name)
 		if err != nil {
 			logger.Error("Failed to stop component", err,
@@ -111,7 +126,7 @@ func runE(ctx context.Context, flags *flagpole) error {
 	}
 
 	defer func() {
-		for _, component := range components {
+		for _, component := range stopped {
 			err = rt.StartComponent(ctx, component.Name)
 			if err != nil {
 				logger.Error("Failed to start component", err,
@@ -133,9 +148,11 @@ func runE(ctx context.Context, flags *flagpole) error {
 	}
 
 	loader, err := etcd.NewLoader(etcd.LoadConfig{
-		Clientset: clientset,
-		Client:    etcdclient,
-		Prefix:    conf.Options.EtcdPrefix,
+		Clientset:  clientset,
+		Client:     etcdclient,
+		Prefix:     conf.Options.EtcdPrefix,
+		ReplayFrom: flags.From,
+		ReplayTo:   flags.To,
 	})
 	if err != nil {
 		return err
@@ -149,22 +166,44 @@ func runE(ctx context.Context, flags *flagpole) error {
 		_ = f.Close()
 	}()
 
-	press, err := file.Decompress(flags.Path, f)
-	if err != nil {
-		return err
+	// The time of the recording starts from the offset to start replaying
+	startTime := time.Now().Add(-flags.From)
+	revertTime := func(bytes []byte) []byte {
+		return recording.RevertTimeFromRelative(startTime, bytes)
 	}
-	defer func() {
-		_ = press.Close()
-	}()
-
-	var reader io.Reader = press
 
-	startTime := time.Now()
-	reader = recording.NewReadHook(reader, func(bytes []byte) []byte {
-		return recording.RevertTimeFromRelative(startTime, bytes)
-	})
+	var decoder recording.Decoder
+	if recording.IsContainer(f) {
+		stat, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		container, err := recording.NewContainerReader(f, stat.Size())
+		if err != nil {
+			return err
+		}
+		segment, offset, err := container.Seek(flags.From)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = segment.Close()
+		}()
+		if offset != 0 {
+			logger.Info("Seek to keyframe", "offset", offset)
+		}
+		decoder = recording.NewJSONDecoder(segment, revertTime)
+	} else {
+		press, err := file.Decompress(flags.Path, f)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = press.Close()
+		}()
 
-	decoder := yaml.NewDecoder(reader)
+		decoder = yaml.NewDecoder(recording.NewReadHook(press, revertTime))
+	}
 
 	if flags.Snapshot {
 		logger.Info("Restoring snapshot")
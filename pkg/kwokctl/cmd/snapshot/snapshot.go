@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/diff"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/export"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/record"
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/snapshot/replay"
@@ -34,7 +35,7 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "snapshot [command]",
-		Short: "Snapshot [save, restore, record, replay, export] one of cluster",
+		Short: "Snapshot [save, restore, record, replay, export, diff] one of cluster",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
@@ -44,5 +45,6 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(export.NewCommand(ctx))
 	cmd.AddCommand(replay.NewCommand(ctx))
 	cmd.AddCommand(record.NewCommand(ctx))
+	cmd.AddCommand(diff.NewCommand(ctx))
 	return cmd
 }
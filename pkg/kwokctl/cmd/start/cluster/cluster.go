@@ -104,5 +104,5 @@ func runE(ctx context.Context, flags *flagpole) error {
 		}
 	}
 
-	return nil
+	return runtime.WaitHosted(gctx, rt)
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/start/cluster"
+	"sigs.k8s.io/kwok/pkg/kwokctl/cmd/start/component"
 )
 
 // NewCommand returns a new cobra.Command for start cluster
@@ -30,11 +31,12 @@ func NewCommand(ctx context.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "start [command]",
-		Short: "Start one of [cluster]",
+		Short: "Start one of [cluster, component]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
 	}
 	cmd.AddCommand(cluster.NewCommand(ctx))
+	cmd.AddCommand(component.NewCommand(ctx))
 	return cmd
 }
@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supervise implements the `supervise` command
+package supervise
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/supervisor"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+type flagpole struct {
+	Name             string
+	Interval         time.Duration
+	FailureThreshold int
+	InitialBackoff   time.Duration
+	MaxBackoff       time.Duration
+}
+
+// NewCommand returns a new cobra.Command for supervising a cluster
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "supervise",
+		Short: "Supervises the components of a cluster and restarts the failed ones until interrupted",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().DurationVar(&flags.Interval, "interval", 5*time.Second, "Interval between the checks of the components")
+	cmd.Flags().IntVar(&flags.FailureThreshold, "failure-threshold", 3, "Number of failed checks in a row before a component is restarted")
+	cmd.Flags().DurationVar(&flags.InitialBackoff, "initial-backoff", time.Second, "Least time between two restarts of a component, doubled on every restart")
+	cmd.Flags().DurationVar(&flags.MaxBackoff, "max-backoff", 5*time.Minute, "Most time between two restarts of a component")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	conf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	var probe func(ctx context.Context, component internalversion.Component) error
+	// Only the metric endpoints of the native components are reachable from the host
+	if components.GetRuntimeMode(conf.Options.Runtime) == components.RuntimeModeNative {
+		probe = supervisor.ProbeMetric
+	}
+
+	s := supervisor.NewSupervisor(target{rt}, supervisor.Config{
+		Interval:         flags.Interval,
+		FailureThreshold: flags.FailureThreshold,
+		InitialBackoff:   flags.InitialBackoff,
+		MaxBackoff:       flags.MaxBackoff,
+		Probe:            probe,
+	})
+
+	logger.Info("Cluster is supervised")
+	return s.Run(ctx)
+}
+
+// target reads the config from disk every time,
+// since the cluster may be changed by other kwokctl commands while it is supervised.
+type target struct {
+	runtime.Runtime
+}
+
+// Load the config from disk
+func (t target) Load(ctx context.Context) (*internalversion.KwokctlConfiguration, error) {
+	objs, err := config.Load(ctx, t.GetWorkdirPath(runtime.ConfigName))
+	if err != nil {
+		return nil, err
+	}
+	configs := config.FilterWithType[*internalversion.KwokctlConfiguration](objs)
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("failed to load config")
+	}
+	return configs[0], nil
+}
+
+// Save the config to disk, with the other objects saved with the cluster, e.g. stages
+func (t target) Save(ctx context.Context) error {
+	objs, err := config.Load(ctx, t.GetWorkdirPath(runtime.ConfigName))
+	if err != nil {
+		return err
+	}
+	ctx = config.NewContext(ctx, config.FilterWithoutType[*internalversion.KwokctlConfiguration](objs))
+	return t.Runtime.Save(ctx)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster contains a command to upgrade a cluster.
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/kwokctl/k8s"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+type flagpole struct {
+	Name        string
+	KubeVersion string
+	Wait        time.Duration
+}
+
+// NewCommand returns a new cobra.Command for cluster upgrading
+func NewCommand(ctx context.Context) *cobra.Command {
+	flags := &flagpole{}
+
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "cluster",
+		Short: "Upgrades a cluster to the Kubernetes version passed by --kube-version",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.Name = config.DefaultCluster
+			return runE(cmd.Context(), flags)
+		},
+	}
+	cmd.Flags().StringVar(&flags.KubeVersion, "kube-version", "", "Kubernetes version to upgrade to")
+	cmd.Flags().DurationVar(&flags.Wait, "wait", 0, "Wait for the cluster to be ready after the upgrade")
+	return cmd
+}
+
+func runE(ctx context.Context, flags *flagpole) error {
+	if flags.KubeVersion == "" {
+		return fmt.Errorf("--kube-version is required")
+	}
+
+	name := config.ClusterName(flags.Name)
+	workdir := path.Join(config.ClustersDir, flags.Name)
+
+	logger := log.FromContext(ctx)
+	logger = logger.With("cluster", flags.Name)
+	ctx = log.NewContext(ctx, logger)
+
+	rt, err := runtime.DefaultRegistry.Load(ctx, name, workdir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Warn("Cluster does not exist")
+		}
+		return err
+	}
+
+	upgrader, ok := rt.(runtime.Upgrader)
+	if !ok {
+		return fmt.Errorf("upgrade is not supported by this runtime")
+	}
+
+	// The other objects saved with the cluster, e.g. stages, are saved again by the upgrade.
+	objs, err := config.Load(ctx, rt.GetWorkdirPath(runtime.ConfigName))
+	if err != nil {
+		return err
+	}
+	ctx = config.NewContext(ctx, config.FilterWithoutType[*internalversion.KwokctlConfiguration](objs))
+
+	oldConf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldVersion, err := version.ParseVersion(oldConf.Options.KubeVersion)
+	if err != nil {
+		return err
+	}
+	newVersion, err := version.ParseVersion(flags.KubeVersion)
+	if err != nil {
+		return err
+	}
+	if newVersion.LT(oldVersion) {
+		return fmt.Errorf("cannot downgrade cluster from %s to %s", version.AddPrefixV(oldVersion.String()), version.AddPrefixV(newVersion.String()))
+	}
+	if newVersion.EQ(oldVersion) {
+		logger.Info("Cluster is already at the version", "version", version.AddPrefixV(newVersion.String()))
+		return nil
+	}
+
+	conf := oldConf.DeepCopy()
+	err = config.SetKubeVersion(&conf.Options, flags.KubeVersion)
+	if err != nil {
+		return err
+	}
+
+	// The feature gates are checked before anything is changed,
+	// so the cluster is not left half upgraded by a feature gate removed in the new version.
+	err = validateFeatureGates(conf, int(newVersion.Minor))
+	if err != nil {
+		return err
+	}
+
+	logger = logger.With("from", oldConf.Options.KubeVersion, "to", conf.Options.KubeVersion)
+	ctx = log.NewContext(ctx, logger)
+
+	start := time.Now()
+	logger.Info("Cluster is upgrading")
+	err = upgrader.Upgrade(ctx, conf)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade cluster %q: %w", name, err)
+	}
+
+	newConf, err := rt.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldComponents := map[string]internalversion.Component{}
+	for _, component := range oldConf.Components {
+		oldComponents[component.Name] = component
+	}
+
+	// The components are rolled one by one in the order they are installed,
+	// so etcd and kube-apiserver come back before the ones that depend on them.
+	for _, component := range newConf.Components {
+		if oldComponent, ok := oldComponents[component.Name]; ok && reflect.DeepEqual(oldComponent, component) {
+			continue
+		}
+
+		logger.Info("Component is rolling", "component", component.Name)
+		err = upgrader.RollComponent(ctx, component.Name)
+		if err != nil {
+			return fmt.Errorf("failed to roll component %q: %w", component.Name, err)
+		}
+	}
+	logger.Info("Cluster is upgraded",
+		"elapsed", time.Since(start),
+	)
+
+	// Wait for cluster to be ready
+	if flags.Wait > 0 {
+		start = time.Now()
+		logger.Info("Waiting for cluster to be ready")
+		err = rt.WaitReady(ctx, flags.Wait)
+		if err != nil {
+			logger.Error("Failed to wait for cluster to be ready", err,
+				"elapsed", time.Since(start),
+			)
+		} else {
+			logger.Info("Cluster is ready",
+				"elapsed", time.Since(start),
+			)
+		}
+	}
+	return nil
+}
+
+// validateFeatureGates returns an error if a feature gate of the upgraded configuration is not available in the version,
+// the default feature gates of the old version are already replaced by SetKubeVersion, so only the ones set by the user are left.
+func validateFeatureGates(conf *internalversion.KwokctlConfiguration, minor int) error {
+	err := k8s.ValidateFeatureGates(conf.Options.KubeFeatureGates, minor)
+	if err != nil {
+		return fmt.Errorf("cluster cannot be upgraded: %w", err)
+	}
+	for _, patch := range conf.ComponentsPatches {
+		for _, arg := range patch.ExtraArgs {
+			if arg.Key != "feature-gates" {
+				continue
+			}
+			err = k8s.ValidateFeatureGates(arg.Value, minor)
+			if err != nil {
+				return fmt.Errorf("component %q cannot be upgraded: %w", patch.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+)
+
+func loadConfig(t *testing.T, kubeVersion string) *internalversion.KwokctlConfiguration {
+	objs, err := config.LoadFromReader(context.Background(), strings.NewReader(`
+apiVersion: config.kwok.x-k8s.io/v1alpha1
+kind: KwokctlConfiguration
+options:
+  runtime: binary
+  mode: StableFeatureGateAndAPI
+  kubeVersion: `+kubeVersion+`
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	confs := config.FilterWithType[*internalversion.KwokctlConfiguration](objs)
+	if len(confs) != 1 {
+		t.Fatalf("got %d configurations, want 1", len(confs))
+	}
+	return confs[0]
+}
+
+func Test_validateFeatureGates(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		minor   int
+		patches []internalversion.ComponentPatches
+		wantErr bool
+	}{
+		{
+			name:  "default stable mode across two minors",
+			from:  "v1.27.0",
+			to:    "v1.29.0",
+			minor: 29,
+		},
+		{
+			name:  "default stable mode across three minors",
+			from:  "v1.28.0",
+			to:    "v1.31.0",
+			minor: 31,
+		},
+		{
+			name:  "feature gate removed in the new version",
+			from:  "v1.27.0",
+			to:    "v1.29.0",
+			minor: 29,
+			patches: []internalversion.ComponentPatches{
+				{
+					Name: "kube-apiserver",
+					ExtraArgs: []internalversion.ExtraArgs{
+						{
+							Key:   "feature-gates",
+							Value: "ProbeTerminationGracePeriod=true",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := loadConfig(t, tt.from)
+			if conf.Options.KubeFeatureGates == "" {
+				t.Fatalf("want the default feature gates of the stable mode")
+			}
+			conf.ComponentsPatches = tt.patches
+
+			err := config.SetKubeVersion(&conf.Options, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = validateFeatureGates(conf, tt.minor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateFeatureGates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
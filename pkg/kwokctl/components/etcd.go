@@ -33,6 +33,7 @@ import (
 
 // BuildEtcdComponentConfig is the configuration for building an etcd component.
 type BuildEtcdComponentConfig struct {
+	Name             string
 	Runtime          string
 	Binary           string
 	Image            string
@@ -46,10 +47,45 @@ type BuildEtcdComponentConfig struct {
 	Verbosity        log.Level
 	QuotaBackendSize string
 	OtlpGrpcAddress  string
+	MemberName       string
+	AdvertiseAddress string
+	InitialCluster   string
+}
+
+// EtcdComponentName returns the component name of the etcd member with the given index.
+func EtcdComponentName(index int) string {
+	if index == 0 {
+		return consts.ComponentEtcd
+	}
+	return consts.ComponentEtcd + "-" + strconv.Itoa(index)
+}
+
+// EtcdComponentNames returns the component names of all the etcd members.
+func EtcdComponentNames(replicas uint32) []string {
+	names := []string{EtcdComponentName(0)}
+	for i := 1; i < int(replicas); i++ {
+		names = append(names, EtcdComponentName(i))
+	}
+	return names
+}
+
+// EtcdMemberName returns the etcd member name of the etcd member with the given index.
+func EtcdMemberName(index int) string {
+	return "node" + strconv.Itoa(index)
 }
 
 // BuildEtcdComponent builds an etcd component.
 func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversion.Component, err error) {
+	if conf.Name == "" {
+		conf.Name = consts.ComponentEtcd
+	}
+	if conf.MemberName == "" {
+		conf.MemberName = EtcdMemberName(0)
+	}
+	if conf.AdvertiseAddress == "" {
+		conf.AdvertiseAddress = conf.BindAddress
+	}
+
 	var volumes []internalversion.Volume
 	var ports []internalversion.Port
 
@@ -64,7 +100,7 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 	}
 
 	etcdArgs := []string{
-		"--name=node0",
+		"--name=" + conf.MemberName,
 		"--auto-compaction-retention=1",
 		"--quota-backend-bytes=" + strconv.FormatInt(quotaBackendSize, 10),
 	}
@@ -98,17 +134,21 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 				Protocol: internalversion.ProtocolTCP,
 			},
 		)
+		initialCluster := conf.InitialCluster
+		if initialCluster == "" {
+			initialCluster = conf.MemberName + "=http://" + conf.AdvertiseAddress + ":2380"
+		}
 		etcdArgs = append(etcdArgs,
-			"--initial-advertise-peer-urls=http://"+conf.BindAddress+":2380",
+			"--initial-advertise-peer-urls=http://"+conf.AdvertiseAddress+":2380",
 			"--listen-peer-urls=http://"+conf.BindAddress+":2380",
-			"--advertise-client-urls=http://"+conf.BindAddress+":2379",
+			"--advertise-client-urls=http://"+conf.AdvertiseAddress+":2379",
 			"--listen-client-urls=http://"+conf.BindAddress+":2379",
-			"--initial-cluster=node0=http://"+conf.BindAddress+":2380",
+			"--initial-cluster="+initialCluster,
 		)
 
 		metric = &internalversion.ComponentMetric{
 			Scheme: "http",
-			Host:   conf.ProjectName + "-" + consts.ComponentEtcd + ":2379",
+			Host:   conf.ProjectName + "-" + conf.Name + ":2379",
 			Path:   "/metrics",
 		}
 	} else {
@@ -131,13 +171,17 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 			},
 		)
 
+		initialCluster := conf.InitialCluster
+		if initialCluster == "" {
+			initialCluster = conf.MemberName + "=http://" + conf.AdvertiseAddress + ":" + etcdPeerPortStr
+		}
 		etcdArgs = append(etcdArgs,
 			"--data-dir="+conf.DataPath,
-			"--initial-advertise-peer-urls=http://"+conf.BindAddress+":"+etcdPeerPortStr,
+			"--initial-advertise-peer-urls=http://"+conf.AdvertiseAddress+":"+etcdPeerPortStr,
 			"--listen-peer-urls=http://"+conf.BindAddress+":"+etcdPeerPortStr,
-			"--advertise-client-urls=http://"+conf.BindAddress+":"+etcdClientPortStr,
+			"--advertise-client-urls=http://"+conf.AdvertiseAddress+":"+etcdClientPortStr,
 			"--listen-client-urls=http://"+conf.BindAddress+":"+etcdClientPortStr,
-			"--initial-cluster=node0=http://"+conf.BindAddress+":"+etcdPeerPortStr,
+			"--initial-cluster="+initialCluster,
 		)
 
 		metric = &internalversion.ComponentMetric{
@@ -174,7 +218,7 @@ func BuildEtcdComponent(conf BuildEtcdComponentConfig) (component internalversio
 	}
 
 	return internalversion.Component{
-		Name:    consts.ComponentEtcd,
+		Name:    conf.Name,
 		Version: conf.Version.String(),
 		Volumes: volumes,
 		Command: []string{consts.ComponentEtcd},
@@ -18,6 +18,7 @@ package components
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
@@ -30,6 +31,7 @@ import (
 
 // BuildKubeApiserverComponentConfig is the configuration for building a kube-apiserver component.
 type BuildKubeApiserverComponentConfig struct {
+	Name              string
 	Runtime           string
 	ProjectName       string
 	Binary            string
@@ -54,10 +56,32 @@ type BuildKubeApiserverComponentConfig struct {
 	DisableQPSLimits  bool
 	TracingConfigPath string
 	EtcdPrefix        string
+	EtcdServers       []string
+	EtcdLinks         []string
+}
+
+// KubeApiserverComponentName returns the component name of the kube-apiserver replica with the given index.
+func KubeApiserverComponentName(index int) string {
+	if index == 0 {
+		return consts.ComponentKubeApiserver
+	}
+	return consts.ComponentKubeApiserver + "-" + strconv.Itoa(index)
+}
+
+// KubeApiserverComponentNames returns the component names of all the kube-apiserver replicas.
+func KubeApiserverComponentNames(replicas uint32) []string {
+	names := []string{KubeApiserverComponentName(0)}
+	for i := 1; i < int(replicas); i++ {
+		names = append(names, KubeApiserverComponentName(i))
+	}
+	return names
 }
 
 // BuildKubeApiserverComponent builds a kube-apiserver component.
 func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (component internalversion.Component, err error) {
+	if conf.Name == "" {
+		conf.Name = consts.ComponentKubeApiserver
+	}
 	if conf.EtcdPort == 0 {
 		conf.EtcdPort = 2379
 	}
@@ -121,7 +145,11 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 	var volumes []internalversion.Volume
 	var metric *internalversion.ComponentMetric
 
-	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+	if len(conf.EtcdServers) != 0 {
+		kubeApiserverArgs = append(kubeApiserverArgs,
+			"--etcd-servers="+strings.Join(conf.EtcdServers, ","),
+		)
+	} else if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
 		kubeApiserverArgs = append(kubeApiserverArgs,
 			"--etcd-servers=http://"+conf.EtcdAddress+":2379",
 		)
@@ -179,7 +207,7 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 			)
 			metric = &internalversion.ComponentMetric{
 				Scheme:             "https",
-				Host:               conf.ProjectName + "-" + consts.ComponentKubeApiserver + ":6443",
+				Host:               conf.ProjectName + "-" + conf.Name + ":6443",
 				Path:               "/metrics",
 				CertPath:           "/etc/kubernetes/pki/admin.crt",
 				KeyPath:            "/etc/kubernetes/pki/admin.key",
@@ -234,7 +262,7 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 			)
 			metric = &internalversion.ComponentMetric{
 				Scheme: "http",
-				Host:   conf.ProjectName + "-" + consts.ComponentKubeApiserver + ":8080",
+				Host:   conf.ProjectName + "-" + conf.Name + ":8080",
 				Path:   "/metrics",
 			}
 		} else {
@@ -311,12 +339,15 @@ func BuildKubeApiserverComponent(conf BuildKubeApiserverComponentConfig) (compon
 	envs := []internalversion.Env{}
 
 	links := []string{consts.ComponentEtcd}
+	if len(conf.EtcdLinks) != 0 {
+		links = append([]string{}, conf.EtcdLinks...)
+	}
 	if conf.TracingConfigPath != "" {
 		links = append(links, consts.ComponentJaeger)
 	}
 
 	return internalversion.Component{
-		Name:    consts.ComponentKubeApiserver,
+		Name:    conf.Name,
 		Version: conf.Version.String(),
 		Links:   links,
 		Command: []string{consts.ComponentKubeApiserver},
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+)
+
+// BuildKubeApiserverLoadBalancerComponentConfig is the configuration for building a kube-apiserver load balancer component.
+type BuildKubeApiserverLoadBalancerComponentConfig struct {
+	Runtime     string
+	ProjectName string
+	Binary      string
+	Image       string
+	Workdir     string
+	BindAddress string
+	Port        uint32
+	SecurePort  bool
+	ConfigPath  string
+	Backends    []string
+	Links       []string
+}
+
+// BuildKubeApiserverLoadBalancerComponent builds a kube-apiserver load balancer component.
+// The load balancer is haproxy in the container runtime,
+// and is kwokctl itself in the binary runtime.
+func BuildKubeApiserverLoadBalancerComponent(conf BuildKubeApiserverLoadBalancerComponentConfig) (component internalversion.Component, err error) {
+	var args []string
+	var command []string
+	var volumes []internalversion.Volume
+	var ports []internalversion.Port
+
+	portName := "http"
+	if conf.SecurePort {
+		portName = "https"
+	}
+
+	if GetRuntimeMode(conf.Runtime) != RuntimeModeNative {
+		port := uint32(8080)
+		if conf.SecurePort {
+			port = 6443
+		}
+		volumes = append(volumes,
+			internalversion.Volume{
+				HostPath:  conf.ConfigPath,
+				MountPath: "/usr/local/etc/haproxy/haproxy.cfg",
+				ReadOnly:  true,
+			},
+		)
+		command = []string{"haproxy"}
+		args = append(args,
+			"-W",
+			"-db",
+			"-f",
+			"/usr/local/etc/haproxy/haproxy.cfg",
+		)
+		ports = append(
+			ports,
+			internalversion.Port{
+				Name:     portName,
+				HostPort: conf.Port,
+				Port:     port,
+				Protocol: internalversion.ProtocolTCP,
+			},
+		)
+	} else {
+		command = []string{"kwokctl"}
+		args = append(args,
+			"load-balancer",
+			"--bind="+conf.BindAddress+":"+format.String(conf.Port),
+		)
+		for _, backend := range conf.Backends {
+			args = append(args, "--backend="+backend)
+		}
+		ports = append(
+			ports,
+			internalversion.Port{
+				Name:     portName,
+				HostPort: 0,
+				Port:     conf.Port,
+				Protocol: internalversion.ProtocolTCP,
+			},
+		)
+	}
+
+	envs := []internalversion.Env{}
+
+	return internalversion.Component{
+		Name:    consts.ComponentKubeApiserverLoadBalancer,
+		Links:   conf.Links,
+		Command: command,
+		Volumes: volumes,
+		Args:    args,
+		Binary:  conf.Binary,
+		Image:   conf.Image,
+		Ports:   ports,
+		WorkDir: conf.Workdir,
+		Envs:    envs,
+	}, nil
+}
@@ -77,6 +77,7 @@ const (
 var (
 	runtimeTypeMap = map[string]string{
 		consts.RuntimeTypeBinary:      RuntimeModeNative,
+		consts.RuntimeTypeEmbedded:    RuntimeModeNative,
 		consts.RuntimeTypeDocker:      RuntimeModeContainer,
 		consts.RuntimeTypePodman:      RuntimeModeContainer,
 		consts.RuntimeTypeNerdctl:     RuntimeModeContainer,
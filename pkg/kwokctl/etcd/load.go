@@ -45,6 +45,12 @@ type LoadConfig struct {
 	Clientset clientset.Clientset
 	Client    Client
 	Prefix    string
+
+	// ReplayFrom is the offset of the recording to start replaying,
+	// the patches before it are applied without waiting.
+	ReplayFrom time.Duration
+	// ReplayTo is the offset of the recording to stop replaying, 0 means the end.
+	ReplayTo time.Duration
 }
 
 // Loader loads the resources to cluster
@@ -111,7 +117,7 @@ func (l *Loader) AllowHandle(ctx context.Context) context.CancelFunc {
 }
 
 // Load loads the resources to cluster
-func (l *Loader) Load(ctx context.Context, decoder *yaml.Decoder) error {
+func (l *Loader) Load(ctx context.Context, decoder recording.Decoder) error {
 	logger := log.FromContext(ctx)
 	for ctx.Err() == nil {
 		obj, err := decoder.DecodeUnstructured()
@@ -145,12 +151,12 @@ func (l *Loader) Load(ctx context.Context, decoder *yaml.Decoder) error {
 }
 
 // Replay replays the resources to cluster
-func (l *Loader) Replay(ctx context.Context, decoder *yaml.Decoder) error {
+func (l *Loader) Replay(ctx context.Context, decoder recording.Decoder) error {
 	logger := log.FromContext(ctx)
 
 	h := heap.NewHeap[time.Duration, *recording.ResourcePatch]()
 
-	var dur time.Duration
+	dur := l.loadConfig.ReplayFrom
 	for ctx.Err() == nil {
 		obj, err := decoder.DecodeUnstructured()
 		if err != nil {
@@ -180,6 +186,9 @@ func (l *Loader) Replay(ctx context.Context, decoder *yaml.Decoder) error {
 		// Tolerate events that are out of order over a period of time
 		if h.Len() >= 1024 {
 			_, rp, _ := h.Pop()
+			if l.afterReplayTo(rp) {
+				return nil
+			}
 			l.handleResourcePatch(ctx, rp, &dur)
 		}
 	}
@@ -190,13 +199,26 @@ func (l *Loader) Replay(ctx context.Context, decoder *yaml.Decoder) error {
 		if !ok {
 			break
 		}
+		if l.afterReplayTo(rp) {
+			return nil
+		}
 		l.handleResourcePatch(ctx, rp, &dur)
 	}
 
 	return nil
 }
 
+func (l *Loader) afterReplayTo(resourcePatch *recording.ResourcePatch) bool {
+	return l.loadConfig.ReplayTo > 0 && resourcePatch.DurationNanosecond > l.loadConfig.ReplayTo
+}
+
 func (l *Loader) handleResourcePatch(ctx context.Context, resourcePatch *recording.ResourcePatch, dur *time.Duration) {
+	// Fast forward to the offset to start replaying
+	if resourcePatch.DurationNanosecond < l.loadConfig.ReplayFrom {
+		l.applyResourcePatch(ctx, resourcePatch)
+		return
+	}
+
 	d := resourcePatch.DurationNanosecond - *dur
 	switch {
 	case d > 0:
@@ -33,7 +33,6 @@ import (
 	clientset "sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/heap"
 	"sigs.k8s.io/kwok/pkg/utils/patch"
-	"sigs.k8s.io/kwok/pkg/utils/yaml"
 )
 
 // SaveConfig is the combination of the impersonation config
@@ -52,6 +51,7 @@ type Saver struct {
 	rev        int64
 	saveConfig SaveConfig
 	track      map[log.ObjectRef]json.RawMessage
+	written    map[writtenKey]json.RawMessage
 	baseTime   time.Time
 	clock      clock.PassiveClock
 }
@@ -85,7 +85,20 @@ func NewSaver(saveConfig SaveConfig) (*Saver, error) {
 	}, nil
 }
 
-func (s *Saver) save(encoder *yaml.Encoder, kv *KeyValue) error {
+// writtenKey is the key of the objects written to the recording
+type writtenKey struct {
+	gvk schema.GroupVersionKind
+	log.ObjectRef
+}
+
+func writtenKeyFromObject(obj *unstructured.Unstructured) writtenKey {
+	return writtenKey{
+		gvk:       obj.GroupVersionKind(),
+		ObjectRef: log.KObj(obj),
+	}
+}
+
+func (s *Saver) save(encoder recording.Encoder, kv *KeyValue) error {
 	value := kv.Value
 	if value == nil {
 		value = kv.PrevValue
@@ -115,11 +128,17 @@ func (s *Saver) save(encoder *yaml.Encoder, kv *KeyValue) error {
 	}
 
 	s.track[log.KObj(obj)] = data
+	if _, ok := encoder.(recording.KeyframeEncoder); ok {
+		if s.written == nil {
+			s.written = map[writtenKey]json.RawMessage{}
+		}
+		s.written[writtenKeyFromObject(obj)] = data
+	}
 	return nil
 }
 
 // Save saves the snapshot of cluster
-func (s *Saver) Save(ctx context.Context, encoder *yaml.Encoder) error {
+func (s *Saver) Save(ctx context.Context, encoder recording.Encoder) error {
 	rev, err := s.saveConfig.Client.Get(ctx, s.saveConfig.Prefix,
 		WithResponse(func(kv *KeyValue) error {
 			return s.save(encoder, kv)
@@ -133,7 +152,7 @@ func (s *Saver) Save(ctx context.Context, encoder *yaml.Encoder) error {
 	return nil
 }
 
-func (s *Saver) buildResourcePatch(kv *KeyValue) (*recording.ResourcePatch, error) {
+func (s *Saver) buildResourcePatch(kv *KeyValue) (*recordEntry, error) {
 	lastValue := kv.Value
 	if lastValue == nil {
 		lastValue = kv.PrevValue
@@ -180,6 +199,10 @@ func (s *Saver) buildResourcePatch(kv *KeyValue) (*recording.ResourcePatch, erro
 		rp.SetDuration(now.Sub(s.baseTime))
 	}
 
+	entry := &recordEntry{
+		rp:  &rp,
+		key: writtenKeyFromObject(obj),
+	}
 	switch {
 	case kv.Value != nil:
 		patchMeta, err := s.patchMetaSchema.Lookup(gvr)
@@ -195,34 +218,76 @@ func (s *Saver) buildResourcePatch(kv *KeyValue) (*recording.ResourcePatch, erro
 		if err != nil {
 			return nil, err
 		}
+		entry.data = s.track[log.KObj(obj)]
 	default:
 		rp.SetDelete(obj, s.track)
 	}
 
-	return &rp, nil
+	return entry, nil
+}
+
+// recordEntry is a resource patch with the full object after it is applied
+type recordEntry struct {
+	rp   *recording.ResourcePatch
+	key  writtenKey
+	data json.RawMessage
+}
+
+// encode writes the resource patch, and the keyframe before it if needed
+func (s *Saver) encode(encoder recording.Encoder, entry *recordEntry) error {
+	ke, ok := encoder.(recording.KeyframeEncoder)
+	if !ok {
+		return encoder.Encode(entry.rp)
+	}
+
+	if ke.NeedKeyframe(entry.rp.DurationNanosecond) {
+		objs := make([]json.RawMessage, 0, len(s.written))
+		for _, data := range s.written {
+			objs = append(objs, data)
+		}
+		err := ke.EncodeKeyframe(entry.rp.DurationNanosecond, objs)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := ke.Encode(entry.rp)
+	if err != nil {
+		return err
+	}
+
+	if s.written == nil {
+		s.written = map[writtenKey]json.RawMessage{}
+	}
+	if entry.data == nil {
+		delete(s.written, entry.key)
+	} else {
+		s.written[entry.key] = entry.data
+	}
+	return nil
 }
 
 // Record records the snapshot of cluster.
-func (s *Saver) Record(ctx context.Context, encoder *yaml.Encoder) error {
-	h := heap.NewHeap[time.Duration, *recording.ResourcePatch]()
+func (s *Saver) Record(ctx context.Context, encoder recording.Encoder) error {
+	h := heap.NewHeap[time.Duration, *recordEntry]()
 
 	err := s.saveConfig.Client.Watch(ctx, s.saveConfig.Prefix,
 		WithRevision(s.rev),
 		WithResponse(func(kv *KeyValue) error {
-			rp, err := s.buildResourcePatch(kv)
+			entry, err := s.buildResourcePatch(kv)
 			if err != nil {
 				return err
 			}
-			if rp == nil {
+			if entry == nil {
 				return nil
 			}
 
-			h.Push(rp.DurationNanosecond, rp)
+			h.Push(entry.rp.DurationNanosecond, entry)
 
 			// Tolerate events that are out of order over a period of time
 			if h.Len() >= 128 {
-				_, rp, _ := h.Pop()
-				err = encoder.Encode(rp)
+				_, entry, _ := h.Pop()
+				err = s.encode(encoder, entry)
 				if err != nil {
 					return err
 				}
@@ -236,11 +301,11 @@ func (s *Saver) Record(ctx context.Context, encoder *yaml.Encoder) error {
 
 	// Flush the remaining events
 	for {
-		_, rp, ok := h.Pop()
+		_, entry, ok := h.Pop()
 		if !ok {
 			break
 		}
-		err = encoder.Encode(rp)
+		err = s.encode(encoder, entry)
 		if err != nil {
 			return err
 		}
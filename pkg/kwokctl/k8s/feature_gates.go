@@ -60,6 +60,39 @@ func GetFeatureGates(version int) string {
 	return strings.Join(gates, ",")
 }
 
+// ValidateFeatureGates returns an error if the feature gates are malformed
+// or any of them is not known by the given version, which the components refuse to start with.
+func ValidateFeatureGates(gates string, version int) error {
+	if gates == "" || version < 0 {
+		return nil
+	}
+
+	known := map[string]bool{}
+	for _, raw := range rawData {
+		if raw.Contain(version) {
+			known[raw.Name] = true
+		}
+	}
+
+	var unknown []string
+	for _, gate := range strings.Split(gates, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(gate), "=")
+		if !ok {
+			return fmt.Errorf("invalid feature gate %q, must be in the form of name=bool", gate)
+		}
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid feature gate %q: %w", gate, err)
+		}
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) != 0 {
+		return fmt.Errorf("feature gates %v are not available in 1.%d", unknown, version)
+	}
+	return nil
+}
+
 // FeatureSpec is the specification of a feature
 type FeatureSpec struct {
 	Name  string
@@ -79,3 +79,68 @@ func TestGetFeatureGates(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFeatureGates(t *testing.T) {
+	rawData = []FeatureSpec{
+		{Name: "feature1", Stage: Alpha, Since: 10, Until: 14},
+		{Name: "feature1", Stage: Beta, Since: 15, Until: 19},
+		{Name: "feature1", Stage: GA, Since: 20, Until: 21},
+		{Name: "feature2", Stage: Beta, Since: 18, Until: -1},
+	}
+
+	tests := []struct {
+		name    string
+		gates   string
+		version int
+		wantErr bool
+	}{
+		{
+			name:    "Empty",
+			gates:   "",
+			version: 20,
+		},
+		{
+			name:    "Known",
+			gates:   "feature1=true,feature2=false",
+			version: 20,
+		},
+		{
+			name:    "Removed",
+			gates:   "feature1=true",
+			version: 22,
+			wantErr: true,
+		},
+		{
+			name:    "Not yet added",
+			gates:   "feature2=true",
+			version: 17,
+			wantErr: true,
+		},
+		{
+			name:    "Malformed",
+			gates:   "feature1",
+			version: 20,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid value",
+			gates:   "feature1=yes",
+			version: 20,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown version",
+			gates:   "feature3=true",
+			version: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFeatureGates(tt.gates, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFeatureGates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+global
+  log stdout format raw local0 info
+
+defaults
+  mode tcp
+  log global
+  option dontlognull
+  timeout connect 5s
+  timeout client 30m
+  timeout server 30m
+  default-server init-addr last,libc,none
+
+frontend kube-apiserver
+  bind *:{{ .Port }}
+  default_backend kube-apiservers
+
+backend kube-apiservers
+  balance roundrobin
+{{- range $index, $backend := .Backends }}
+  server kube-apiserver-{{ $index }} {{ $backend }} check
+{{- end }}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	_ "embed"
+)
+
+//go:embed kube_apiserver_load_balancer_config.cfg.tpl
+var kubeApiserverLoadBalancerConfigTpl string
+
+var kubeApiserverLoadBalancerConfigTemplate = template.Must(template.New("kube_apiserver_load_balancer_config").Parse(kubeApiserverLoadBalancerConfigTpl))
+
+// BuildKubeApiserverLoadBalancerConfig builds a haproxy config file for the load balancer in front of kube-apiserver replicas.
+func BuildKubeApiserverLoadBalancerConfig(conf BuildKubeApiserverLoadBalancerConfigParam) (string, error) {
+	if len(conf.Backends) == 0 {
+		return "", fmt.Errorf("build kubeApiserverLoadBalancerConfig error: no backends")
+	}
+	buf := bytes.NewBuffer(nil)
+	err := kubeApiserverLoadBalancerConfigTemplate.Execute(buf, conf)
+	if err != nil {
+		return "", fmt.Errorf("build kubeApiserverLoadBalancerConfig error: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// BuildKubeApiserverLoadBalancerConfigParam is the configuration for BuildKubeApiserverLoadBalancerConfig.
+type BuildKubeApiserverLoadBalancerConfigParam struct {
+	// Port is the port the load balancer listens on.
+	Port uint32
+	// Backends is the addresses of kube-apiserver replicas in the form of host:port.
+	Backends []string
+}
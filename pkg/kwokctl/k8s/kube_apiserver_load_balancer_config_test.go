@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildKubeApiserverLoadBalancerConfig(t *testing.T) {
+	got, err := BuildKubeApiserverLoadBalancerConfig(BuildKubeApiserverLoadBalancerConfigParam{
+		Port: 6443,
+		Backends: []string{
+			"kwok-kube-apiserver:6443",
+			"kwok-kube-apiserver-1:6443",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"  bind *:6443\n",
+		"  server kube-apiserver-0 kwok-kube-apiserver:6443 check\n",
+		"  server kube-apiserver-1 kwok-kube-apiserver-1:6443 check\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in config:\n%s", want, got)
+		}
+	}
+
+	_, err = BuildKubeApiserverLoadBalancerConfig(BuildKubeApiserverLoadBalancerConfigParam{
+		Port: 6443,
+	})
+	if err == nil {
+		t.Errorf("expected an error for no backends")
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Encoder encodes the objects of a recording.
+type Encoder interface {
+	Encode(obj any) error
+}
+
+// KeyframeEncoder is an Encoder which splits the recording into segments,
+// each segment starts with a keyframe of the full state of the objects.
+type KeyframeEncoder interface {
+	Encoder
+	// NeedKeyframe returns true if a keyframe should be written before the patch of the duration.
+	NeedKeyframe(dur time.Duration) bool
+	// EncodeKeyframe starts a new segment with the full state of the objects.
+	EncodeKeyframe(dur time.Duration, objs []json.RawMessage) error
+}
+
+// Decoder decodes the objects of a recording.
+type Decoder interface {
+	DecodeUnstructured() (*unstructured.Unstructured, error)
+	UndecodedUnstructured(obj *unstructured.Unstructured)
+}
+
+// JSONDecoder decodes the objects of a recording from NDJSON.
+type JSONDecoder struct {
+	r         *bufio.Reader
+	transform func([]byte) []byte
+	buf       []*unstructured.Unstructured
+}
+
+// NewJSONDecoder returns a new JSONDecoder,
+// the transform is applied to each line before decoding if it is not nil.
+func NewJSONDecoder(r io.Reader, transform func([]byte) []byte) *JSONDecoder {
+	return &JSONDecoder{
+		r:         bufio.NewReader(r),
+		transform: transform,
+	}
+}
+
+// DecodeUnstructured decodes the next line into an unstructured object.
+func (d *JSONDecoder) DecodeUnstructured() (*unstructured.Unstructured, error) {
+	if len(d.buf) != 0 {
+		last := len(d.buf) - 1
+		obj := d.buf[last]
+		d.buf = d.buf[:last]
+		return obj, nil
+	}
+
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if len(line) == 0 || (len(line) == 1 && line[0] == '\n') {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if d.transform != nil {
+			line = d.transform(line)
+		}
+
+		obj := &unstructured.Unstructured{}
+		err = json.Unmarshal(line, &obj.Object)
+		if err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+}
+
+// UndecodedUnstructured put a decoded unstructured object back to the decoder.
+func (d *JSONDecoder) UndecodedUnstructured(obj *unstructured.Unstructured) {
+	d.buf = append(d.buf, obj)
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// The container format is a gzip file of multiple members,
+// so it can still be read by any gzip reader as a NDJSON file.
+//
+// The first member is the snapshot, each following member starts with a keyframe,
+// which is the full state of the objects, followed by the resource patches.
+// The last member is the index of the members by time,
+// and its offset is stored in the extra field of the first member header.
+
+const (
+	containerExtraID1 = 'K'
+	containerExtraID2 = 'W'
+	// containerIndexOffsetPos is the position of the index offset in the file,
+	// after the 10 bytes fixed header, 2 bytes XLEN and 4 bytes subfield header.
+	containerIndexOffsetPos = 16
+)
+
+// ContainerIndex is the index of a container.
+type ContainerIndex struct {
+	Segments []ContainerSegment `json:"segments"`
+}
+
+// ContainerSegment is a member of the container.
+type ContainerSegment struct {
+	// DurationNanosecond is the duration of the recording when the segment starts.
+	DurationNanosecond time.Duration `json:"durationNanosecond"`
+	// Offset is the offset of the segment in the file.
+	Offset int64 `json:"offset"`
+}
+
+// ContainerConfig is the configuration of the container writer.
+type ContainerConfig struct {
+	// KeyframeInterval is the interval between keyframes, 0 means no keyframe.
+	KeyframeInterval time.Duration
+	// Transform is applied to each line before writing.
+	Transform func([]byte) []byte
+}
+
+// ContainerWriter writes a recording in the container format.
+type ContainerWriter struct {
+	w        io.WriteSeeker
+	counter  *countWriter
+	gz       *gzip.Writer
+	conf     ContainerConfig
+	index    ContainerIndex
+	keyframe time.Duration
+}
+
+// NewContainerWriter returns a new ContainerWriter.
+func NewContainerWriter(w io.WriteSeeker, conf ContainerConfig) (*ContainerWriter, error) {
+	counter := &countWriter{w: w}
+	gz, err := gzip.NewWriterLevel(counter, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	gz.Header.Extra = []byte{containerExtraID1, containerExtraID2, 8, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	return &ContainerWriter{
+		w:       w,
+		counter: counter,
+		gz:      gz,
+		conf:    conf,
+		index: ContainerIndex{
+			Segments: []ContainerSegment{
+				{},
+			},
+		},
+	}, nil
+}
+
+// Encode writes the object as a line.
+func (c *ContainerWriter) Encode(obj any) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return c.writeLine(data)
+}
+
+func (c *ContainerWriter) writeLine(data []byte) error {
+	if c.conf.Transform != nil {
+		data = c.conf.Transform(data)
+	}
+	_, err := c.gz.Write(data)
+	if err != nil {
+		return err
+	}
+	_, err = c.gz.Write([]byte{'\n'})
+	return err
+}
+
+// NeedKeyframe returns true if a keyframe should be written before the patch of the duration.
+func (c *ContainerWriter) NeedKeyframe(dur time.Duration) bool {
+	return c.conf.KeyframeInterval > 0 && dur >= c.keyframe+c.conf.KeyframeInterval
+}
+
+// EncodeKeyframe starts a new segment with the full state of the objects.
+func (c *ContainerWriter) EncodeKeyframe(dur time.Duration, objs []json.RawMessage) error {
+	err := c.nextMember()
+	if err != nil {
+		return err
+	}
+	c.keyframe = dur
+	c.index.Segments = append(c.index.Segments, ContainerSegment{
+		DurationNanosecond: dur,
+		Offset:             c.counter.n,
+	})
+	for _, obj := range objs {
+		err = c.writeLine(obj)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ContainerWriter) nextMember() error {
+	err := c.gz.Close()
+	if err != nil {
+		return err
+	}
+	c.gz.Reset(c.counter)
+	return nil
+}
+
+// Close writes the index and closes the container.
+func (c *ContainerWriter) Close() error {
+	err := c.nextMember()
+	if err != nil {
+		return err
+	}
+	indexOffset := c.counter.n
+
+	data, err := json.Marshal(c.index)
+	if err != nil {
+		return err
+	}
+	_, err = c.gz.Write(append(data, '\n'))
+	if err != nil {
+		return err
+	}
+	err = c.gz.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.w.Seek(containerIndexOffsetPos, io.SeekStart)
+	if err != nil {
+		return err
+	}
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(indexOffset))
+	_, err = c.w.Write(buf[:])
+	if err != nil {
+		return err
+	}
+	_, err = c.w.Seek(0, io.SeekEnd)
+	return err
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// IsContainer returns true if the file is in the container format.
+func IsContainer(r io.ReaderAt) bool {
+	_, err := readContainerIndexOffset(r)
+	return err == nil
+}
+
+func readContainerIndexOffset(r io.ReaderAt) (int64, error) {
+	var header [containerIndexOffsetPos + 8]byte
+	_, err := r.ReadAt(header[:], 0)
+	if err != nil {
+		return 0, err
+	}
+	const flagExtra = 1 << 2
+	if header[0] != 0x1f || header[1] != 0x8b || header[3]&flagExtra == 0 ||
+		header[12] != containerExtraID1 || header[13] != containerExtraID2 {
+		return 0, fmt.Errorf("not a container")
+	}
+	offset := int64(binary.LittleEndian.Uint64(header[containerIndexOffsetPos:]))
+	if offset == 0 {
+		return 0, fmt.Errorf("container is not closed")
+	}
+	return offset, nil
+}
+
+// ContainerReader reads a recording in the container format.
+type ContainerReader struct {
+	r           io.ReaderAt
+	indexOffset int64
+	index       ContainerIndex
+}
+
+// NewContainerReader returns a new ContainerReader.
+func NewContainerReader(r io.ReaderAt, size int64) (*ContainerReader, error) {
+	indexOffset, err := readContainerIndexOffset(r)
+	if err != nil {
+		return nil, err
+	}
+	if indexOffset >= size {
+		return nil, fmt.Errorf("invalid index offset %d", indexOffset)
+	}
+
+	gz, err := gzip.NewReader(io.NewSectionReader(r, indexOffset, size-indexOffset))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = gz.Close()
+	}()
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ContainerReader{
+		r:           r,
+		indexOffset: indexOffset,
+	}
+	err = json.Unmarshal(bytes.TrimSpace(data), &c.index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode index: %w", err)
+	}
+	if len(c.index.Segments) == 0 {
+		return nil, fmt.Errorf("empty index")
+	}
+	return c, nil
+}
+
+// Index returns the index of the container.
+func (c *ContainerReader) Index() ContainerIndex {
+	return c.index
+}
+
+// Seek returns the reader from the last segment started before or at the duration,
+// and the duration of the segment.
+// The reader starts with the keyframe of the segment followed by the patches of it and all later segments,
+// the keyframes of the later segments are skipped, since the patches are continuous.
+func (c *ContainerReader) Seek(dur time.Duration) (io.ReadCloser, time.Duration, error) {
+	start := 0
+	for i, s := range c.index.Segments[1:] {
+		if s.DurationNanosecond > dur {
+			break
+		}
+		start = i + 1
+	}
+
+	segments := c.index.Segments[start:]
+	closers := make(multiCloser, 0, len(segments))
+	readers := make([]io.Reader, 0, len(segments))
+	for i, segment := range segments {
+		end := c.indexOffset
+		if i+1 < len(segments) {
+			end = segments[i+1].Offset
+		}
+		gz, err := gzip.NewReader(io.NewSectionReader(c.r, segment.Offset, end-segment.Offset))
+		if err != nil {
+			_ = closers.Close()
+			return nil, 0, err
+		}
+		closers = append(closers, gz)
+		if i == 0 {
+			readers = append(readers, gz)
+		} else {
+			readers = append(readers, newPatchReader(gz))
+		}
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(readers...),
+		Closer: closers,
+	}, segments[0].DurationNanosecond, nil
+}
+
+type multiCloser []io.Closer
+
+func (m multiCloser) Close() error {
+	var errs []error
+	for _, c := range m {
+		err := c.Close()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// patchReader reads the lines of the resource patches and skips the objects of the keyframe.
+type patchReader struct {
+	r   *bufio.Reader
+	buf []byte
+}
+
+func newPatchReader(r io.Reader) *patchReader {
+	return &patchReader{
+		r: bufio.NewReader(r),
+	}
+}
+
+func (p *patchReader) Read(b []byte) (int, error) {
+	for len(p.buf) == 0 {
+		line, err := p.r.ReadBytes('\n')
+		if len(line) != 0 && isResourcePatch(line) {
+			p.buf = line
+		}
+		if err != nil {
+			if len(p.buf) == 0 {
+				return 0, err
+			}
+			break
+		}
+	}
+	n := copy(b, p.buf)
+	p.buf = p.buf[n:]
+	return n, nil
+}
+
+// isResourcePatch returns true if the line is a resource patch,
+// the line that cannot be parsed is also returned to be reported by the decoder.
+func isResourcePatch(line []byte) bool {
+	var typeMeta struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	err := json.Unmarshal(line, &typeMeta)
+	if err != nil {
+		return len(bytes.TrimSpace(line)) != 0
+	}
+	return typeMeta.Kind == ResourcePatchType.Kind && typeMeta.APIVersion == ResourcePatchType.APIVersion
+}
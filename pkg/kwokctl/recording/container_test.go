@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recording
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kwok/pkg/apis/action/v1alpha1"
+)
+
+func TestContainer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recording")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	w, err := NewContainerWriter(f, ContainerConfig{
+		KeyframeInterval: time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node := func(name string) json.RawMessage {
+		return json.RawMessage(`{"apiVersion":"v1","kind":"Node","metadata":{"name":"` + name + `"}}`)
+	}
+	patch := func(name string, dur time.Duration) *ResourcePatch {
+		rp := &ResourcePatch{
+			TypeMeta:           ResourcePatchType,
+			Method:             v1alpha1.PatchMethodCreate,
+			Template:           node(name),
+			DurationNanosecond: dur,
+		}
+		rp.SetTargetName(name, "")
+		return rp
+	}
+
+	err = w.Encode(node("node-0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := []json.RawMessage{node("node-0")}
+	for i, dur := range []time.Duration{30 * time.Second, 90 * time.Second, 150 * time.Second} {
+		if w.NeedKeyframe(dur) {
+			err = w.EncodeKeyframe(dur, state)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		name := "node-" + string(rune('1'+i))
+		err = w.Encode(patch(name, dur))
+		if err != nil {
+			t.Fatal(err)
+		}
+		state = append(state, node(name))
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsContainer(f) {
+		t.Fatal("expected a container")
+	}
+
+	// It can be read as a plain gzip file
+	_, _ = f.Seek(0, io.SeekStart)
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(all), "\n"); lines != 2+3+4+1 {
+		t.Errorf("unexpected lines %d: %s", lines, all)
+	}
+
+	stat, _ := f.Stat()
+	r, err := NewContainerReader(f, stat.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(r.Index().Segments); got != 3 {
+		t.Fatalf("expected 3 segments, got %d", got)
+	}
+
+	tests := []struct {
+		from       time.Duration
+		wantOffset time.Duration
+		wantNames  []string
+	}{
+		// the keyframes of the later segments are skipped
+		{0, 0, []string{"node-0", "node-1", "node-2", "node-3"}},
+		{100 * time.Second, 90 * time.Second, []string{"node-0", "node-1", "node-2", "node-3"}},
+		{200 * time.Second, 150 * time.Second, []string{"node-0", "node-1", "node-2", "node-3"}},
+	}
+	for _, tt := range tests {
+		segment, offset, err := r.Seek(tt.from)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if offset != tt.wantOffset {
+			t.Errorf("Seek(%s) offset = %s, want %s", tt.from, offset, tt.wantOffset)
+		}
+
+		decoder := NewJSONDecoder(segment, nil)
+		var names []string
+		for {
+			obj, err := decoder.DecodeUnstructured()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				t.Fatal(err)
+			}
+			if obj.GetKind() == ResourcePatchType.Kind {
+				name, _, _ := unstructured.NestedString(obj.Object, "target", "name")
+				names = append(names, name)
+			} else {
+				names = append(names, obj.GetName())
+			}
+		}
+		_ = segment.Close()
+		if strings.Join(names, ",") != strings.Join(tt.wantNames, ",") {
+			t.Errorf("Seek(%s) got %v, want %v", tt.from, names, tt.wantNames)
+		}
+	}
+}
@@ -25,6 +25,8 @@ import (
 	"os/user"
 	rt "runtime"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/nxadm/tail"
@@ -52,6 +54,13 @@ import (
 // Cluster is an implementation of Runtime for binary
 type Cluster struct {
 	*runtime.Cluster
+
+	// embedded is true if etcd and kwok-controller are run in the kwokctl process
+	embedded bool
+
+	hostedMut sync.Mutex
+	// hosted is the components run in the kwokctl process
+	hosted map[string]*hostedComponent
 }
 
 // NewCluster creates a new Runtime for binary
@@ -145,6 +154,8 @@ type env struct {
 	adminCertPath           string
 	scheme                  string
 	usedPorts               sets.Sets[uint32]
+	etcdServers             []string
+	etcdLinks               []string
 }
 
 func (c *Cluster) env(ctx context.Context) (*env, error) {
@@ -246,6 +257,13 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	if c.embedded {
+		err = c.setupEmbedded(ctx, env)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = c.setup(ctx, env)
 	if err != nil {
 		return err
@@ -334,12 +352,7 @@ func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
 	// Configure the etcd
-	etcdPath, err := c.EnsureBinary(ctx, consts.ComponentEtcd, conf.EtcdBinary)
-	if err != nil {
-		return err
-	}
-
-	etcdVersion, err := c.ParseVersionFromBinary(ctx, etcdPath)
+	etcdPath, etcdVersion, err := c.ensureEtcd(ctx, conf)
 	if err != nil {
 		return err
 	}
@@ -349,24 +362,82 @@ func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 		otlpGrpcAddress = net.LocalAddress + ":" + format.String(conf.JaegerOtlpGrpcPort)
 	}
 
-	etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
-		Runtime:          conf.Runtime,
-		ProjectName:      c.Name(),
-		Workdir:          env.workdir,
-		Binary:           etcdPath,
-		Version:          etcdVersion,
-		BindAddress:      conf.BindAddress,
-		DataPath:         env.etcdDataPath,
-		Port:             conf.EtcdPort,
-		PeerPort:         conf.EtcdPeerPort,
-		Verbosity:        env.verbosity,
-		QuotaBackendSize: conf.EtcdQuotaBackendSize,
-		OtlpGrpcAddress:  otlpGrpcAddress,
-	})
-	if err != nil {
-		return err
+	replicas := int(conf.EtcdReplicas)
+	if replicas <= 1 {
+		etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+			Runtime:          conf.Runtime,
+			ProjectName:      c.Name(),
+			Workdir:          env.workdir,
+			Binary:           etcdPath,
+			Version:          etcdVersion,
+			BindAddress:      conf.BindAddress,
+			DataPath:         env.etcdDataPath,
+			Port:             conf.EtcdPort,
+			PeerPort:         conf.EtcdPeerPort,
+			Verbosity:        env.verbosity,
+			QuotaBackendSize: conf.EtcdQuotaBackendSize,
+			OtlpGrpcAddress:  otlpGrpcAddress,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+		return nil
+	}
+
+	// The first member keeps the ports in the options, the others get unused ports.
+	ports := make([]uint32, replicas)
+	peerPorts := make([]uint32, replicas)
+	ports[0] = conf.EtcdPort
+	peerPorts[0] = conf.EtcdPeerPort
+	for i := 1; i < replicas; i++ {
+		err = c.setupPorts(ctx, env.usedPorts, &ports[i], &peerPorts[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	peers := make([]string, 0, replicas)
+	for i := 0; i < replicas; i++ {
+		peers = append(peers, components.EtcdMemberName(i)+"=http://"+net.LocalAddress+":"+format.String(peerPorts[i]))
+	}
+	initialCluster := strings.Join(peers, ",")
+
+	for i := 0; i < replicas; i++ {
+		dataPath := env.etcdDataPath
+		if i != 0 {
+			dataPath = c.GetWorkdirPath(components.EtcdComponentName(i))
+			err = c.MkdirAll(dataPath)
+			if err != nil {
+				return fmt.Errorf("failed to mkdir etcd data path: %w", err)
+			}
+		}
+
+		etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+			Name:             components.EtcdComponentName(i),
+			Runtime:          conf.Runtime,
+			ProjectName:      c.Name(),
+			Workdir:          env.workdir,
+			Binary:           etcdPath,
+			Version:          etcdVersion,
+			BindAddress:      conf.BindAddress,
+			DataPath:         dataPath,
+			Port:             ports[i],
+			PeerPort:         peerPorts[i],
+			Verbosity:        env.verbosity,
+			QuotaBackendSize: conf.EtcdQuotaBackendSize,
+			OtlpGrpcAddress:  otlpGrpcAddress,
+			MemberName:       components.EtcdMemberName(i),
+			AdvertiseAddress: net.LocalAddress,
+			InitialCluster:   initialCluster,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+		env.etcdServers = append(env.etcdServers, "http://"+net.LocalAddress+":"+format.String(ports[i]))
+		env.etcdLinks = append(env.etcdLinks, etcdComponent.Name)
 	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
 	return nil
 }
 
@@ -400,35 +471,84 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 		}
 	}
 
-	kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
-		Runtime:           conf.Runtime,
-		ProjectName:       c.Name(),
-		Workdir:           env.workdir,
-		Binary:            kubeApiserverPath,
-		Version:           kubeApiserverVersion,
-		BindAddress:       conf.BindAddress,
-		Port:              conf.KubeApiserverPort,
-		EtcdAddress:       net.LocalAddress,
-		EtcdPort:          conf.EtcdPort,
-		KubeRuntimeConfig: conf.KubeRuntimeConfig,
-		KubeFeatureGates:  conf.KubeFeatureGates,
-		SecurePort:        conf.SecurePort,
-		KubeAuthorization: conf.KubeAuthorization,
-		KubeAdmission:     conf.KubeAdmission,
-		AuditPolicyPath:   env.auditPolicyPath,
-		AuditLogPath:      env.auditLogPath,
-		CaCertPath:        env.caCertPath,
-		AdminCertPath:     env.adminCertPath,
-		AdminKeyPath:      env.adminKeyPath,
-		Verbosity:         env.verbosity,
-		DisableQPSLimits:  conf.DisableQPSLimits,
-		TracingConfigPath: kubeApiserverTracingConfigPath,
-		EtcdPrefix:        conf.EtcdPrefix,
+	// With more than one replica, the port in the options is taken by the load balancer,
+	// and all replicas get unused ports.
+	replicas := int(conf.KubeApiserverReplicas)
+	ports := []uint32{conf.KubeApiserverPort}
+	if replicas > 1 {
+		ports = make([]uint32, replicas)
+		for i := range ports {
+			err = c.setupPorts(ctx, env.usedPorts, &ports[i])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	backends := make([]string, 0, len(ports))
+	links := make([]string, 0, len(ports))
+	for i, port := range ports {
+		kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
+			Name:              components.KubeApiserverComponentName(i),
+			Runtime:           conf.Runtime,
+			ProjectName:       c.Name(),
+			Workdir:           env.workdir,
+			Binary:            kubeApiserverPath,
+			Version:           kubeApiserverVersion,
+			BindAddress:       conf.BindAddress,
+			Port:              port,
+			EtcdAddress:       net.LocalAddress,
+			EtcdPort:          conf.EtcdPort,
+			EtcdServers:       env.etcdServers,
+			EtcdLinks:         env.etcdLinks,
+			KubeRuntimeConfig: conf.KubeRuntimeConfig,
+			KubeFeatureGates:  conf.KubeFeatureGates,
+			SecurePort:        conf.SecurePort,
+			KubeAuthorization: conf.KubeAuthorization,
+			KubeAdmission:     conf.KubeAdmission,
+			AuditPolicyPath:   env.auditPolicyPath,
+			AuditLogPath:      env.auditLogPath,
+			CaCertPath:        env.caCertPath,
+			AdminCertPath:     env.adminCertPath,
+			AdminKeyPath:      env.adminKeyPath,
+			Verbosity:         env.verbosity,
+			DisableQPSLimits:  conf.DisableQPSLimits,
+			TracingConfigPath: kubeApiserverTracingConfigPath,
+			EtcdPrefix:        conf.EtcdPrefix,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
+		backends = append(backends, net.LocalAddress+":"+format.String(port))
+		links = append(links, kubeApiserverComponent.Name)
+	}
+
+	if replicas <= 1 {
+		return nil
+	}
+
+	// The load balancer is run by kwokctl itself.
+	kwokctlPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	loadBalancerComponent, err := components.BuildKubeApiserverLoadBalancerComponent(components.BuildKubeApiserverLoadBalancerComponentConfig{
+		Runtime:     conf.Runtime,
+		ProjectName: c.Name(),
+		Workdir:     env.workdir,
+		Binary:      kwokctlPath,
+		BindAddress: conf.BindAddress,
+		Port:        conf.KubeApiserverPort,
+		SecurePort:  conf.SecurePort,
+		Backends:    backends,
+		Links:       links,
 	})
 	if err != nil {
 		return err
 	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, loadBalancerComponent)
 	return nil
 }
 
@@ -586,12 +706,7 @@ func (c *Cluster) addKwokController(ctx context.Context, env *env) (err error) {
 	conf := &env.kwokctlConfig.Options
 
 	// Configure the kwok-controller
-	kwokControllerPath, err := c.EnsureBinary(ctx, consts.ComponentKwokController, conf.KwokControllerBinary)
-	if err != nil {
-		return err
-	}
-
-	kwokControllerVersion, err := c.ParseVersionFromBinary(ctx, kwokControllerPath)
+	kwokControllerPath, kwokControllerVersion, err := c.ensureKwokController(ctx, conf)
 	if err != nil {
 		return err
 	}
@@ -826,8 +941,28 @@ func (c *Cluster) Uninstall(ctx context.Context) error {
 	return nil
 }
 
+// processName returns the name of the pid file and the log file of the component.
+// It is the name of the binary as in the clusters created before,
+// unless the binary is shared with other components, e.g. the replicas of etcd and kube-apiserver.
+func (c *Cluster) processName(ctx context.Context, component internalversion.Component) string {
+	if component.Binary == "" {
+		return component.Name
+	}
+	name := path.OnlyName(component.Binary)
+	config, err := c.Config(ctx)
+	if err != nil {
+		return name
+	}
+	for _, other := range config.Components {
+		if other.Name != component.Name && other.Binary != "" && path.OnlyName(other.Binary) == name {
+			return component.Name
+		}
+	}
+	return name
+}
+
 func (c *Cluster) isRunning(ctx context.Context, component internalversion.Component) bool {
-	return c.ForkExecIsRunning(ctx, component.WorkDir, component.Binary)
+	return c.ForkExecIsRunning(ctx, component.WorkDir, c.processName(ctx, component))
 }
 
 func (c *Cluster) startComponent(ctx context.Context, component internalversion.Component) error {
@@ -861,7 +996,10 @@ func (c *Cluster) startComponent(ctx context.Context, component internalversion.
 	}
 
 	logger.Debug("Starting component")
-	return c.ForkExec(ctx, component.WorkDir, component.Binary, component.Args...)
+	if c.isHostable(component) {
+		return c.startHosted(ctx, component)
+	}
+	return c.ForkExec(ctx, component.WorkDir, c.processName(ctx, component), component.Binary, component.Args...)
 }
 
 func (c *Cluster) startComponents(ctx context.Context) error {
@@ -882,7 +1020,10 @@ func (c *Cluster) stopComponent(ctx context.Context, component internalversion.C
 		return nil
 	}
 	logger.Debug("Stopping component")
-	return c.ForkExecKill(ctx, component.WorkDir, component.Binary)
+	if c.isHostable(component) && c.stopHosted(component) {
+		return nil
+	}
+	return c.ForkExecKill(ctx, component.WorkDir, c.processName(ctx, component))
 }
 
 func (c *Cluster) stopComponents(ctx context.Context) error {
@@ -1017,16 +1158,50 @@ func (c *Cluster) StopComponent(ctx context.Context, name string) error {
 	return nil
 }
 
+// PauseComponent freezes a component in the cluster until it is unpaused
+func (c *Cluster) PauseComponent(ctx context.Context, name string) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	if c.isHostable(component) {
+		return fmt.Errorf("failed to pause %s: %w", name, errHostedComponent)
+	}
+
+	err = c.ForkExecSuspend(ctx, component.WorkDir, c.processName(ctx, component))
+	if err != nil {
+		return fmt.Errorf("failed to pause %s: %w", name, err)
+	}
+	return nil
+}
+
+// UnpauseComponent resumes a component in the cluster that was paused
+func (c *Cluster) UnpauseComponent(ctx context.Context, name string) error {
+	component, err := c.GetComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	if c.isHostable(component) {
+		return fmt.Errorf("failed to unpause %s: %w", name, errHostedComponent)
+	}
+
+	err = c.ForkExecResume(ctx, component.WorkDir, c.processName(ctx, component))
+	if err != nil {
+		return fmt.Errorf("failed to unpause %s: %w", name, err)
+	}
+	return nil
+}
+
 // Logs returns the logs of the specified component.
 func (c *Cluster) Logs(ctx context.Context, name string, out io.Writer) error {
-	_, err := c.GetComponent(ctx, name)
+	component, err := c.GetComponent(ctx, name)
 	if err != nil {
 		return err
 	}
 
 	logger := log.FromContext(ctx)
 
-	logs := c.GetLogPath(name + ".log")
+	logs := c.GetLogPath(c.processName(ctx, component) + ".log")
 	if c.IsDryRun() {
 		if file, ok := dryrun.IsCatToFileWriter(out); ok {
 			dryrun.PrintMessage("cp %s %s", logs, file)
@@ -1056,14 +1231,14 @@ func (c *Cluster) Logs(ctx context.Context, name string, out io.Writer) error {
 
 // LogsFollow follows the logs of the component
 func (c *Cluster) LogsFollow(ctx context.Context, name string, out io.Writer) error {
-	_, err := c.GetComponent(ctx, name)
+	component, err := c.GetComponent(ctx, name)
 	if err != nil {
 		return err
 	}
 
 	logger := log.FromContext(ctx)
 
-	logs := c.GetLogPath(name + ".log")
+	logs := c.GetLogPath(c.processName(ctx, component) + ".log")
 	if c.IsDryRun() {
 		dryrun.PrintMessage("tail -f %s", logs)
 		return nil
@@ -1136,7 +1311,7 @@ func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
 	}
 
 	for _, component := range conf.Components {
-		src := c.GetLogPath(component.Name + ".log")
+		src := c.GetLogPath(c.processName(ctx, component) + ".log")
 		dest := path.Join(componentsDir, component.Name+".log")
 		if err = c.CopyFile(src, dest); err != nil {
 			logger.Error("Failed to copy file", err)
@@ -1161,6 +1336,13 @@ func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
 	}
 	conf := &config.Options
 
+	if c.embedded {
+		return []string{
+			conf.KubeApiserverBinary,
+			conf.KubectlBinary,
+		}, nil
+	}
+
 	return []string{
 		conf.EtcdBinary,
 		conf.KubeApiserverBinary,
@@ -1197,6 +1379,9 @@ func (c *Cluster) InspectComponent(ctx context.Context, name string) (runtime.Co
 
 	running := c.isRunning(ctx, component)
 	if !running {
+		if c.ForkExecIsCrashed(ctx, component.WorkDir, c.processName(ctx, component)) {
+			return runtime.ComponentStatusCrashed, nil
+		}
 		return runtime.ComponentStatusStopped, nil
 	}
 
@@ -0,0 +1,403 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	etcdversion "go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/consts"
+	kwokcmd "sigs.k8s.io/kwok/pkg/kwok/cmd"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/path"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+// NewEmbeddedCluster creates a new Runtime for binary,
+// which runs etcd and kwok-controller in the kwokctl process, so only kube-apiserver has to be downloaded.
+func NewEmbeddedCluster(name, workdir string) (runtime.Runtime, error) {
+	return &Cluster{
+		Cluster:  runtime.NewCluster(name, workdir),
+		embedded: true,
+		hosted:   map[string]*hostedComponent{},
+	}, nil
+}
+
+// embeddedComponents is the components supported by the embedded runtime.
+var embeddedComponents = []string{
+	consts.ComponentEtcd,
+	consts.ComponentKubeApiserver,
+	consts.ComponentKwokController,
+}
+
+// hostableComponents is the components run in the kwokctl process by the embedded runtime.
+var hostableComponents = map[string]hostedRunner{
+	consts.ComponentEtcd:           startEtcd,
+	consts.ComponentKwokController: startKwokController,
+}
+
+// errHostedComponent is returned for the operations that are not supported by the components run in the kwokctl process.
+var errHostedComponent = errors.New("the component is run in the kwokctl process")
+
+// hostedRunner starts a component in the kwokctl process and returns once it is ready,
+// the returned function blocks until the component exits, it is stopped when the context is canceled.
+type hostedRunner func(ctx context.Context, component internalversion.Component, logPath string) (func(ctx context.Context) error, error)
+
+type hostedComponent struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// setupEmbedded drops the components that are not supported by the embedded runtime.
+func (c *Cluster) setupEmbedded(ctx context.Context, env *env) error {
+	if env.kwokctlConfig.Options.EtcdReplicas > 1 || env.kwokctlConfig.Options.KubeApiserverReplicas > 1 {
+		return fmt.Errorf("multiple replicas of etcd or kube-apiserver are not supported by the embedded runtime")
+	}
+
+	var skipped []string
+	env.components = slices.Filter(env.components, func(name string) bool {
+		if slices.Contains(embeddedComponents, name) {
+			return true
+		}
+		skipped = append(skipped, name)
+		return false
+	})
+	if len(skipped) != 0 {
+		logger := log.FromContext(ctx)
+		logger.Info("Components are not supported by the embedded runtime, skipped",
+			"components", skipped,
+		)
+	}
+	return nil
+}
+
+// isHostable returns true if the component is run in the kwokctl process.
+func (c *Cluster) isHostable(component internalversion.Component) bool {
+	if !c.embedded {
+		return false
+	}
+	_, ok := hostableComponents[component.Name]
+	return ok
+}
+
+// startHosted starts the component in the kwokctl process,
+// the pid file records the pid of kwokctl so that other kwokctl processes can see it running.
+func (c *Cluster) startHosted(ctx context.Context, component internalversion.Component) error {
+	name := c.processName(ctx, component)
+	pidPath := path.Join(component.WorkDir, "pids", name+".pid")
+	logPath := path.Join(component.WorkDir, "logs", name+".log")
+
+	if c.IsDryRun() {
+		dryrun.PrintMessage("# Run in the kwokctl process: %s", runtime.FormatExec(ctx, component.Name, component.Args...))
+		return nil
+	}
+
+	wait, err := hostableComponents[component.Name](ctx, component, logPath)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", component.Name, err)
+	}
+
+	err = c.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())))
+	if err != nil {
+		return fmt.Errorf("write pid file %s: %w", pidPath, err)
+	}
+
+	// The component outlives the context of the command that starts it
+	hostedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	hosted := &hostedComponent{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	c.hostedMut.Lock()
+	c.hosted[component.Name] = hosted
+	c.hostedMut.Unlock()
+
+	go func() {
+		defer close(hosted.done)
+		hosted.err = wait(hostedCtx)
+		_ = c.Remove(pidPath)
+	}()
+	return nil
+}
+
+// stopHosted stops the component if it is run in this process,
+// it returns false if the component is run in another kwokctl process.
+func (c *Cluster) stopHosted(component internalversion.Component) bool {
+	c.hostedMut.Lock()
+	hosted, ok := c.hosted[component.Name]
+	delete(c.hosted, component.Name)
+	c.hostedMut.Unlock()
+	if !ok {
+		return false
+	}
+	hosted.cancel()
+	<-hosted.done
+	return true
+}
+
+// Hosting returns the components run in this process.
+func (c *Cluster) Hosting() []string {
+	c.hostedMut.Lock()
+	defer c.hostedMut.Unlock()
+	names := make([]string, 0, len(c.hosted))
+	for name := range c.hosted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Wait blocks until the context is canceled or any of the components run in this process exits.
+func (c *Cluster) Wait(ctx context.Context) error {
+	c.hostedMut.Lock()
+	hosted := make(map[string]*hostedComponent, len(c.hosted))
+	for name, h := range c.hosted {
+		hosted[name] = h
+	}
+	c.hostedMut.Unlock()
+
+	exited := make(chan string, len(hosted))
+	for name, h := range hosted {
+		go func() {
+			select {
+			case <-h.done:
+				exited <- name
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case name := <-exited:
+		if err := hosted[name].err; err != nil {
+			return fmt.Errorf("%s exited: %w", name, err)
+		}
+		return fmt.Errorf("%s exited", name)
+	}
+}
+
+// etcdReadyTimeout is how long to wait for the embedded etcd to serve clients.
+const etcdReadyTimeout = time.Minute
+
+// startEtcd starts etcd with the args of the etcd component.
+func startEtcd(ctx context.Context, component internalversion.Component, logPath string) (func(ctx context.Context) error, error) {
+	cfg, err := parseEtcdArgs(component.Args)
+	if err != nil {
+		return nil, err
+	}
+	cfg.LogOutputs = []string{logPath}
+
+	e, err := embed.StartEtcd(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-e.Server.ReadyNotify():
+	case err = <-e.Err():
+		e.Close()
+		return nil, err
+	case <-ctx.Done():
+		e.Close()
+		return nil, ctx.Err()
+	case <-time.After(etcdReadyTimeout):
+		e.Close()
+		return nil, fmt.Errorf("etcd is not ready after %s", etcdReadyTimeout)
+	}
+
+	return func(ctx context.Context) error {
+		defer e.Close()
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-e.Err():
+			return err
+		case <-e.Server.StopNotify():
+			return fmt.Errorf("etcd server stopped")
+		}
+	}, nil
+}
+
+// parseEtcdArgs converts the args of the etcd component to the config of the embedded etcd,
+// the flags that have no counterpart are ignored.
+func parseEtcdArgs(args []string) (*embed.Config, error) {
+	cfg := embed.NewConfig()
+
+	flags := pflag.NewFlagSet(consts.ComponentEtcd, pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.StringVar(&cfg.Name, "name", cfg.Name, "")
+	flags.StringVar(&cfg.Dir, "data-dir", cfg.Dir, "")
+	flags.StringVar(&cfg.InitialCluster, "initial-cluster", cfg.InitialCluster, "")
+	flags.Int64Var(&cfg.QuotaBackendBytes, "quota-backend-bytes", cfg.QuotaBackendBytes, "")
+	flags.StringVar(&cfg.AutoCompactionRetention, "auto-compaction-retention", cfg.AutoCompactionRetention, "")
+	flags.StringVar(&cfg.LogLevel, "log-level", cfg.LogLevel, "")
+	listenClientURLs := flags.String("listen-client-urls", "", "")
+	advertiseClientURLs := flags.String("advertise-client-urls", "", "")
+	listenPeerURLs := flags.String("listen-peer-urls", "", "")
+	advertisePeerURLs := flags.String("initial-advertise-peer-urls", "", "")
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse etcd args: %w", err)
+	}
+
+	for _, u := range []struct {
+		flag string
+		raw  string
+		dst  *[]url.URL
+	}{
+		{"listen-client-urls", *listenClientURLs, &cfg.ListenClientUrls},
+		{"advertise-client-urls", *advertiseClientURLs, &cfg.AdvertiseClientUrls},
+		{"listen-peer-urls", *listenPeerURLs, &cfg.ListenPeerUrls},
+		{"initial-advertise-peer-urls", *advertisePeerURLs, &cfg.AdvertisePeerUrls},
+	} {
+		if u.raw == "" {
+			continue
+		}
+		urls, err := parseURLs(u.raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --%s: %w", u.flag, err)
+		}
+		*u.dst = urls
+	}
+	return cfg, nil
+}
+
+func parseURLs(s string) ([]url.URL, error) {
+	var urls []url.URL
+	for _, raw := range strings.Split(s, ",") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, *u)
+	}
+	return urls, nil
+}
+
+// startKwokController starts kwok-controller with the args of the kwok-controller component.
+func startKwokController(ctx context.Context, component internalversion.Component, logPath string) (func(ctx context.Context) error, error) {
+	configPaths, level, args, err := splitKwokControllerArgs(component.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := config.Load(ctx, configPaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	logFile, err := file.Open(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("open log file %s: %w", logPath, err)
+	}
+
+	return func(ctx context.Context) error {
+		defer func() {
+			_ = logFile.Close()
+		}()
+		ctx = config.NewContext(ctx, objs)
+		ctx = log.NewContext(ctx, log.NewLogger(logFile, level))
+
+		cmd := kwokcmd.NewCommand(ctx)
+		cmd.SetArgs(args)
+		cmd.SetOut(logFile)
+		cmd.SetErr(logFile)
+		return cmd.ExecuteContext(ctx)
+	}, nil
+}
+
+// splitKwokControllerArgs splits out the --config and --v args,
+// which are the global flags of the kwok binary instead of the flags of its command.
+func splitKwokControllerArgs(args []string) (configPaths []string, level log.Level, rest []string, err error) {
+	level = log.LevelInfo
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--config="):
+			configPaths = append(configPaths, strings.TrimPrefix(arg, "--config="))
+		case strings.HasPrefix(arg, "--v="):
+			level, err = log.ParseLevel(strings.TrimPrefix(arg, "--v="))
+			if err != nil {
+				return nil, 0, nil, err
+			}
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return configPaths, level, rest, nil
+}
+
+func (c *Cluster) ensureEtcd(ctx context.Context, conf *internalversion.KwokctlConfigurationOptions) (string, version.Version, error) {
+	if c.embedded {
+		v, err := version.ParseVersion(etcdversion.Version)
+		if err != nil {
+			return "", version.Version{}, err
+		}
+		return "", v, nil
+	}
+
+	etcdPath, err := c.EnsureBinary(ctx, consts.ComponentEtcd, conf.EtcdBinary)
+	if err != nil {
+		return "", version.Version{}, err
+	}
+
+	etcdVersion, err := c.ParseVersionFromBinary(ctx, etcdPath)
+	if err != nil {
+		return "", version.Version{}, err
+	}
+	return etcdPath, etcdVersion, nil
+}
+
+func (c *Cluster) ensureKwokController(ctx context.Context, conf *internalversion.KwokctlConfigurationOptions) (string, version.Version, error) {
+	if c.embedded {
+		v, err := version.ParseVersion(consts.Version)
+		if err != nil {
+			return "", version.Version{}, err
+		}
+		return "", v, nil
+	}
+
+	kwokControllerPath, err := c.EnsureBinary(ctx, consts.ComponentKwokController, conf.KwokControllerBinary)
+	if err != nil {
+		return "", version.Version{}, err
+	}
+
+	kwokControllerVersion, err := c.ParseVersionFromBinary(ctx, kwokControllerPath)
+	if err != nil {
+		return "", version.Version{}, err
+	}
+	return kwokControllerPath, kwokControllerVersion, nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/sets"
+	"sigs.k8s.io/kwok/pkg/utils/version"
+)
+
+func buildEtcdComponent(t *testing.T, dir string, port, peerPort uint32) internalversion.Component {
+	t.Helper()
+	component, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+		Runtime:          "embedded",
+		Version:          version.NewVersion(3, 5, 16),
+		DataPath:         filepath.Join(dir, "etcd"),
+		Workdir:          dir,
+		BindAddress:      net.LocalAddress,
+		Port:             port,
+		PeerPort:         peerPort,
+		Verbosity:        log.LevelWarn,
+		QuotaBackendSize: "8Gi",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return component
+}
+
+func Test_parseEtcdArgs(t *testing.T) {
+	dir := t.TempDir()
+	component := buildEtcdComponent(t, dir, 2379, 2380)
+
+	cfg, err := parseEtcdArgs(append(component.Args, "--experimental-enable-distributed-tracing=true"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Name != "node0" {
+		t.Errorf("expected name node0, got %q", cfg.Name)
+	}
+	if cfg.Dir != filepath.Join(dir, "etcd") {
+		t.Errorf("expected data dir %q, got %q", filepath.Join(dir, "etcd"), cfg.Dir)
+	}
+	if cfg.QuotaBackendBytes != 8<<30 {
+		t.Errorf("expected quota backend bytes %d, got %d", 8<<30, cfg.QuotaBackendBytes)
+	}
+	if cfg.AutoCompactionRetention != "1" {
+		t.Errorf("expected auto compaction retention 1, got %q", cfg.AutoCompactionRetention)
+	}
+	if cfg.LogLevel != "warn" {
+		t.Errorf("expected log level warn, got %q", cfg.LogLevel)
+	}
+	if cfg.InitialCluster != "node0=http://127.0.0.1:2380" {
+		t.Errorf("unexpected initial cluster %q", cfg.InitialCluster)
+	}
+
+	urls := map[string]string{
+		"listen-client-urls":          cfg.ListenClientUrls[0].String(),
+		"advertise-client-urls":       cfg.AdvertiseClientUrls[0].String(),
+		"listen-peer-urls":            cfg.ListenPeerUrls[0].String(),
+		"initial-advertise-peer-urls": cfg.AdvertisePeerUrls[0].String(),
+	}
+	want := map[string]string{
+		"listen-client-urls":          "http://127.0.0.1:2379",
+		"advertise-client-urls":       "http://127.0.0.1:2379",
+		"listen-peer-urls":            "http://127.0.0.1:2380",
+		"initial-advertise-peer-urls": "http://127.0.0.1:2380",
+	}
+	if diff := cmp.Diff(want, urls); diff != "" {
+		t.Errorf("unexpected urls (-want +got):\n%s", diff)
+	}
+}
+
+func Test_splitKwokControllerArgs(t *testing.T) {
+	configPaths, level, rest, err := splitKwokControllerArgs([]string{
+		"--manage-all-nodes=true",
+		"--kubeconfig=/root/kubeconfig.yaml",
+		"--config=/root/kwok.yaml",
+		"--node-port=10247",
+		"--v=DEBUG",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"/root/kwok.yaml"}, configPaths); diff != "" {
+		t.Errorf("unexpected config paths (-want +got):\n%s", diff)
+	}
+	if level != log.LevelDebug {
+		t.Errorf("expected level %v, got %v", log.LevelDebug, level)
+	}
+	wantRest := []string{
+		"--manage-all-nodes=true",
+		"--kubeconfig=/root/kubeconfig.yaml",
+		"--node-port=10247",
+	}
+	if diff := cmp.Diff(wantRest, rest); diff != "" {
+		t.Errorf("unexpected args (-want +got):\n%s", diff)
+	}
+
+	_, _, _, err = splitKwokControllerArgs([]string{"--v=LOUD"})
+	if err == nil {
+		t.Errorf("expected an error for an invalid level")
+	}
+}
+
+func TestClusterHostedEtcd(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, sub := range []string{"pids", "logs"} {
+		err := os.MkdirAll(filepath.Join(dir, sub), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	used := sets.Sets[uint32]{}
+	port, err := net.GetUnusedPort(ctx, used)
+	if err != nil {
+		t.Fatal(err)
+	}
+	used.Insert(port)
+	peerPort, err := net.GetUnusedPort(ctx, used)
+	if err != nil {
+		t.Fatal(err)
+	}
+	component := buildEtcdComponent(t, dir, port, peerPort)
+
+	c, err := NewEmbeddedCluster("test", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster := c.(*Cluster)
+
+	err = cluster.startComponent(ctx, component)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cluster.isRunning(ctx, component) {
+		t.Fatalf("expected etcd to be running")
+	}
+	if diff := cmp.Diff([]string{component.Name}, cluster.Hosting()); diff != "" {
+		t.Errorf("unexpected hosting components (-want +got):\n%s", diff)
+	}
+
+	resp, err := http.Get("http://" + net.LocalAddress + ":" + format.String(port) + "/health")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected etcd to be healthy, got status %d", resp.StatusCode)
+	}
+
+	err = cluster.PauseComponent(ctx, component.Name)
+	if err == nil {
+		t.Errorf("expected pausing a hosted component to fail")
+	}
+
+	err = cluster.stopComponent(ctx, component)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.isRunning(ctx, component) {
+		t.Errorf("expected etcd to be stopped")
+	}
+	if len(cluster.Hosting()) != 0 {
+		t.Errorf("expected no hosting components, got %v", cluster.Hosting())
+	}
+}
@@ -18,8 +18,10 @@ package binary
 
 import (
 	"context"
+	"fmt"
 
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/etcd"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
@@ -40,21 +42,27 @@ func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
 
 // SnapshotRestore restore the snapshot of cluster
 func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
+	config, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if config.Options.EtcdReplicas > 1 {
+		return fmt.Errorf("snapshot restore is not supported with %d etcd members", config.Options.EtcdReplicas)
+	}
+
 	logger := log.FromContext(ctx)
 
-	// Restart etcd and kube-apiserver
-	components := []string{
-		consts.ComponentEtcd,
-		consts.ComponentKubeApiserver,
-	}
-	for _, component := range components {
+	// Restart etcd and all the kube-apiserver replicas
+	restarted := append([]string{consts.ComponentEtcd},
+		components.KubeApiserverComponentNames(config.Options.KubeApiserverReplicas)...)
+	for _, component := range restarted {
 		err := c.StopComponent(ctx, component)
 		if err != nil {
 			logger.Error("Failed to stop", err, "component", component)
 		}
 	}
 	defer func() {
-		for _, component := range components {
+		for _, component := range restarted {
 			err := c.StartComponent(ctx, component)
 			if err != nil {
 				logger.Error("Failed to start", err, "component", component)
@@ -79,7 +87,7 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 	}()
 
 	etcdDataTmp := c.GetWorkdirPath("etcd-data")
-	err := c.RemoveAll(etcdDataTmp)
+	err = c.RemoveAll(etcdDataTmp)
 	if err != nil {
 		return err
 	}
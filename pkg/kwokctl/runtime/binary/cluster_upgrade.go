@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binary
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+// Upgrade rebuilds the components from the new configuration, the running ones are left as they are
+func (c *Cluster) Upgrade(ctx context.Context, conf *internalversion.KwokctlConfiguration) error {
+	if conf.Options.EtcdReplicas > 1 || conf.Options.KubeApiserverReplicas > 1 {
+		return fmt.Errorf("upgrade is not supported with multiple replicas")
+	}
+
+	old, err := c.Config(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The binaries are only downloaded if they do not exist,
+	// so the ones from another source are removed first.
+	binaries := []struct {
+		name     string
+		old, new string
+	}{
+		{consts.ComponentEtcd, old.Options.EtcdBinary, conf.Options.EtcdBinary},
+		{"etcdctl", old.Options.EtcdctlBinary, conf.Options.EtcdctlBinary},
+		{consts.ComponentKubeApiserver, old.Options.KubeApiserverBinary, conf.Options.KubeApiserverBinary},
+		{consts.ComponentKubeControllerManager, old.Options.KubeControllerManagerBinary, conf.Options.KubeControllerManagerBinary},
+		{consts.ComponentKubeScheduler, old.Options.KubeSchedulerBinary, conf.Options.KubeSchedulerBinary},
+		{"kubectl", old.Options.KubectlBinary, conf.Options.KubectlBinary},
+		{consts.ComponentKwokController, old.Options.KwokControllerBinary, conf.Options.KwokControllerBinary},
+	}
+	for _, binary := range binaries {
+		if binary.old == binary.new {
+			continue
+		}
+		err = c.RemoveAll(c.GetBinPath(binary.name + conf.Options.BinSuffix))
+		if err != nil {
+			return err
+		}
+	}
+
+	conf = conf.DeepCopy()
+	conf.Components = nil
+	err = c.SetConfig(ctx, conf)
+	if err != nil {
+		return err
+	}
+	return c.Install(ctx)
+}
+
+// RollComponent restarts the component with its new configuration
+func (c *Cluster) RollComponent(ctx context.Context, name string) error {
+	err := c.StopComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	return c.StartComponent(ctx, name)
+}
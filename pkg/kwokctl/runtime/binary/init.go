@@ -23,4 +23,5 @@ import (
 
 func init() {
 	runtime.DefaultRegistry.Register(consts.RuntimeTypeBinary, NewCluster)
+	runtime.DefaultRegistry.Register(consts.RuntimeTypeEmbedded, NewEmbeddedCluster)
 }
@@ -64,6 +64,8 @@ var (
 	AuditLogName            = "audit.log"
 	SchedulerConfigName     = "scheduler.yaml"
 	ApiserverTracingConfig  = "apiserver-tracing-config.yaml"
+
+	ApiserverLoadBalancerConfig = "apiserver-load-balancer.cfg"
 )
 
 // Cluster is the cluster
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
@@ -112,6 +113,12 @@ func (c *Cluster) setup(ctx context.Context, env *env) error {
 			c.Name() + "-kube-apiserver",
 			c.Name() + "-kwok-controller",
 		}
+		for i := 1; i < int(conf.KubeApiserverReplicas); i++ {
+			sans = append(sans, c.Name()+"-"+components.KubeApiserverComponentName(i))
+		}
+		if conf.KubeApiserverReplicas > 1 {
+			sans = append(sans, c.Name()+"-"+consts.ComponentKubeApiserverLoadBalancer)
+		}
 		ips, err := net.GetAllIPs()
 		if err != nil {
 			logger := log.FromContext(ctx)
@@ -191,8 +198,11 @@ type env struct {
 	inClusterAdminKeyPath         string
 	inClusterAdminCertPath        string
 	inClusterPort                 uint32
+	inClusterApiserverAddress     string
 	scheme                        string
 	usedPorts                     sets.Sets[uint32]
+	etcdServers                   []string
+	etcdLinks                     []string
 }
 
 func (c *Cluster) env(ctx context.Context) (*env, error) {
@@ -228,6 +238,11 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 	inClusterAdminKeyPath := path.Join(inClusterPkiPath, "admin.key")
 	inClusterAdminCertPath := path.Join(inClusterPkiPath, "admin.crt")
 
+	inClusterApiserverAddress := c.Name() + "-" + consts.ComponentKubeApiserver
+	if config.Options.KubeApiserverReplicas > 1 {
+		inClusterApiserverAddress = c.Name() + "-" + consts.ComponentKubeApiserverLoadBalancer
+	}
+
 	inClusterPort := uint32(8080)
 	scheme := "http"
 	if config.Options.SecurePort {
@@ -261,6 +276,7 @@ func (c *Cluster) env(ctx context.Context) (*env, error) {
 		inClusterAdminKeyPath:         inClusterAdminKeyPath,
 		inClusterAdminCertPath:        inClusterAdminCertPath,
 		inClusterPort:                 inClusterPort,
+		inClusterApiserverAddress:     inClusterApiserverAddress,
 		scheme:                        scheme,
 		usedPorts:                     usedPorts,
 	}, nil
@@ -377,23 +393,65 @@ func (c *Cluster) addEtcd(ctx context.Context, env *env) (err error) {
 		otlpGrpcAddress = c.Name() + "-jaeger:4317"
 	}
 
-	etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
-		Runtime:          conf.Runtime,
-		ProjectName:      c.Name(),
-		Workdir:          env.workdir,
-		Image:            conf.EtcdImage,
-		Version:          etcdVersion,
-		BindAddress:      net.PublicAddress,
-		Port:             conf.EtcdPort,
-		DataPath:         env.etcdDataPath,
-		Verbosity:        env.verbosity,
-		QuotaBackendSize: conf.EtcdQuotaBackendSize,
-		OtlpGrpcAddress:  otlpGrpcAddress,
-	})
-	if err != nil {
-		return err
+	replicas := int(conf.EtcdReplicas)
+	if replicas <= 1 {
+		etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+			Runtime:          conf.Runtime,
+			ProjectName:      c.Name(),
+			Workdir:          env.workdir,
+			Image:            conf.EtcdImage,
+			Version:          etcdVersion,
+			BindAddress:      net.PublicAddress,
+			Port:             conf.EtcdPort,
+			DataPath:         env.etcdDataPath,
+			Verbosity:        env.verbosity,
+			QuotaBackendSize: conf.EtcdQuotaBackendSize,
+			OtlpGrpcAddress:  otlpGrpcAddress,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+		return nil
+	}
+
+	peers := make([]string, 0, replicas)
+	for i := 0; i < replicas; i++ {
+		peers = append(peers, components.EtcdMemberName(i)+"=http://"+c.Name()+"-"+components.EtcdComponentName(i)+":2380")
+	}
+	initialCluster := strings.Join(peers, ",")
+
+	for i := 0; i < replicas; i++ {
+		// Only the first member is exposed to the host.
+		port := conf.EtcdPort
+		if i != 0 {
+			port = 0
+		}
+		name := components.EtcdComponentName(i)
+		etcdComponent, err := components.BuildEtcdComponent(components.BuildEtcdComponentConfig{
+			Name:             name,
+			Runtime:          conf.Runtime,
+			ProjectName:      c.Name(),
+			Workdir:          env.workdir,
+			Image:            conf.EtcdImage,
+			Version:          etcdVersion,
+			BindAddress:      net.PublicAddress,
+			Port:             port,
+			DataPath:         env.etcdDataPath,
+			Verbosity:        env.verbosity,
+			QuotaBackendSize: conf.EtcdQuotaBackendSize,
+			OtlpGrpcAddress:  otlpGrpcAddress,
+			MemberName:       components.EtcdMemberName(i),
+			AdvertiseAddress: c.Name() + "-" + name,
+			InitialCluster:   initialCluster,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
+		env.etcdServers = append(env.etcdServers, "http://"+c.Name()+"-"+name+":2379")
+		env.etcdLinks = append(env.etcdLinks, name)
 	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, etcdComponent)
 	return nil
 }
 
@@ -426,35 +484,92 @@ func (c *Cluster) addKubeApiserver(ctx context.Context, env *env) (err error) {
 		}
 	}
 
-	kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
-		Runtime:           conf.Runtime,
-		ProjectName:       c.Name(),
-		Workdir:           env.workdir,
-		Image:             conf.KubeApiserverImage,
-		Version:           kubeApiserverVersion,
-		BindAddress:       net.PublicAddress,
-		Port:              conf.KubeApiserverPort,
-		KubeRuntimeConfig: conf.KubeRuntimeConfig,
-		KubeFeatureGates:  conf.KubeFeatureGates,
-		SecurePort:        conf.SecurePort,
-		KubeAuthorization: conf.KubeAuthorization,
-		KubeAdmission:     conf.KubeAdmission,
-		AuditPolicyPath:   env.auditPolicyPath,
-		AuditLogPath:      env.auditLogPath,
-		CaCertPath:        env.caCertPath,
-		AdminCertPath:     env.adminCertPath,
-		AdminKeyPath:      env.adminKeyPath,
-		EtcdPort:          conf.EtcdPort,
-		EtcdAddress:       c.Name() + "-etcd",
-		Verbosity:         env.verbosity,
-		DisableQPSLimits:  conf.DisableQPSLimits,
-		TracingConfigPath: kubeApiserverTracingConfigPath,
-		EtcdPrefix:        conf.EtcdPrefix,
+	replicas := int(conf.KubeApiserverReplicas)
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	backends := make([]string, 0, replicas)
+	links := make([]string, 0, replicas)
+	for i := 0; i < replicas; i++ {
+		// With more than one replica, only the load balancer is exposed to the host.
+		port := conf.KubeApiserverPort
+		if replicas > 1 {
+			port = 0
+		}
+		kubeApiserverComponent, err := components.BuildKubeApiserverComponent(components.BuildKubeApiserverComponentConfig{
+			Name:              components.KubeApiserverComponentName(i),
+			Runtime:           conf.Runtime,
+			ProjectName:       c.Name(),
+			Workdir:           env.workdir,
+			Image:             conf.KubeApiserverImage,
+			Version:           kubeApiserverVersion,
+			BindAddress:       net.PublicAddress,
+			Port:              port,
+			KubeRuntimeConfig: conf.KubeRuntimeConfig,
+			KubeFeatureGates:  conf.KubeFeatureGates,
+			SecurePort:        conf.SecurePort,
+			KubeAuthorization: conf.KubeAuthorization,
+			KubeAdmission:     conf.KubeAdmission,
+			AuditPolicyPath:   env.auditPolicyPath,
+			AuditLogPath:      env.auditLogPath,
+			CaCertPath:        env.caCertPath,
+			AdminCertPath:     env.adminCertPath,
+			AdminKeyPath:      env.adminKeyPath,
+			EtcdPort:          conf.EtcdPort,
+			EtcdAddress:       c.Name() + "-etcd",
+			EtcdServers:       env.etcdServers,
+			EtcdLinks:         env.etcdLinks,
+			Verbosity:         env.verbosity,
+			DisableQPSLimits:  conf.DisableQPSLimits,
+			TracingConfigPath: kubeApiserverTracingConfigPath,
+			EtcdPrefix:        conf.EtcdPrefix,
+		})
+		if err != nil {
+			return err
+		}
+		env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
+		backends = append(backends, c.Name()+"-"+kubeApiserverComponent.Name+":"+format.String(env.inClusterPort))
+		links = append(links, kubeApiserverComponent.Name)
+	}
+
+	if replicas == 1 {
+		return nil
+	}
+
+	// Configure the load balancer
+	err = c.EnsureImage(ctx, c.runtime, conf.KubeApiserverLoadBalancerImage)
+	if err != nil {
+		return err
+	}
+
+	loadBalancerConfigData, err := k8s.BuildKubeApiserverLoadBalancerConfig(k8s.BuildKubeApiserverLoadBalancerConfigParam{
+		Port:     env.inClusterPort,
+		Backends: backends,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate kubeApiserverLoadBalancerConfig: %w", err)
+	}
+	loadBalancerConfigPath := c.GetWorkdirPath(runtime.ApiserverLoadBalancerConfig)
+	err = c.WriteFile(loadBalancerConfigPath, []byte(loadBalancerConfigData))
+	if err != nil {
+		return fmt.Errorf("failed to write kubeApiserverLoadBalancerConfig: %w", err)
+	}
+
+	loadBalancerComponent, err := components.BuildKubeApiserverLoadBalancerComponent(components.BuildKubeApiserverLoadBalancerComponentConfig{
+		Runtime:     conf.Runtime,
+		ProjectName: c.Name(),
+		Workdir:     env.workdir,
+		Image:       conf.KubeApiserverLoadBalancerImage,
+		Port:        conf.KubeApiserverPort,
+		SecurePort:  conf.SecurePort,
+		ConfigPath:  loadBalancerConfigPath,
+		Links:       links,
 	})
 	if err != nil {
 		return err
 	}
-	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, kubeApiserverComponent)
+	env.kwokctlConfig.Components = append(env.kwokctlConfig.Components, loadBalancerComponent)
 	return nil
 }
 
@@ -870,7 +985,7 @@ func (c *Cluster) finishInstall(ctx context.Context, env *env) error {
 	inClusterKubeconfigData, err := kubeconfig.EncodeKubeconfig(kubeconfig.BuildKubeconfig(kubeconfig.BuildKubeconfigConfig{
 		ProjectName:  c.Name(),
 		SecurePort:   conf.SecurePort,
-		Address:      env.scheme + "://" + c.Name() + "-kube-apiserver:" + format.String(env.inClusterPort),
+		Address:      env.scheme + "://" + env.inClusterApiserverAddress + ":" + format.String(env.inClusterPort),
 		CACrtPath:    env.inClusterCaCertPath,
 		AdminCrtPath: env.inClusterAdminCertPath,
 		AdminKeyPath: env.inClusterAdminKeyPath,
@@ -1053,6 +1168,24 @@ func (c *Cluster) StopComponent(ctx context.Context, componentName string) error
 	return c.stopComponent(ctx, componentName)
 }
 
+// PauseComponent freezes a component in the cluster until it is unpaused
+func (c *Cluster) PauseComponent(ctx context.Context, componentName string) error {
+	_, err := c.GetComponent(ctx, componentName)
+	if err != nil {
+		return err
+	}
+	return c.Exec(ctx, c.runtime, "pause", c.Name()+"-"+componentName)
+}
+
+// UnpauseComponent resumes a component in the cluster that was paused
+func (c *Cluster) UnpauseComponent(ctx context.Context, componentName string) error {
+	_, err := c.GetComponent(ctx, componentName)
+	if err != nil {
+		return err
+	}
+	return c.Exec(ctx, c.runtime, "unpause", c.Name()+"-"+componentName)
+}
+
 func (c *Cluster) logs(ctx context.Context, name string, out io.Writer, follow bool) error {
 	args := []string{"logs"}
 	if follow {
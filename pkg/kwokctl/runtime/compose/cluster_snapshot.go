@@ -18,8 +18,10 @@ package compose
 
 import (
 	"context"
+	"fmt"
 
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/kwokctl/components"
 	"sigs.k8s.io/kwok/pkg/kwokctl/etcd"
 	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
 	"sigs.k8s.io/kwok/pkg/log"
@@ -58,6 +60,9 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 		return err
 	}
 	conf := &config.Options
+	if conf.EtcdReplicas > 1 {
+		return fmt.Errorf("snapshot restore is not supported with %d etcd members", conf.EtcdReplicas)
+	}
 
 	logger := log.FromContext(ctx)
 	// Restore snapshot to host temporary directory
@@ -74,20 +79,18 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 	}()
 
 	etcdContainerName := c.Name() + "-etcd"
+	kubeApiservers := components.KubeApiserverComponentNames(conf.KubeApiserverReplicas)
 	if !c.isNerdctl {
-		// Restart etcd and kube-apiserver
-		components := []string{
-			consts.ComponentEtcd,
-			consts.ComponentKubeApiserver,
-		}
-		for _, component := range components {
+		// Restart etcd and all the kube-apiserver replicas
+		restarted := append([]string{consts.ComponentEtcd}, kubeApiservers...)
+		for _, component := range restarted {
 			err := c.StopComponent(ctx, component)
 			if err != nil {
 				logger.Error("Failed to stop", err, "component", component)
 			}
 		}
 		defer func() {
-			for _, component := range components {
+			for _, component := range restarted {
 				err := c.StartComponent(ctx, component)
 				if err != nil {
 					logger.Error("Failed to start", err, "component", component)
@@ -120,15 +123,19 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 		// TODO: remove this when `nerdctl cp` supports work on stopped containers
 		// https://github.com/containerd/nerdctl/issues/1812
 
-		// Stop the kube-apiserver container to avoid data modification by etcd during restore.
-		err = c.StopComponent(ctx, consts.ComponentKubeApiserver)
-		if err != nil {
-			logger.Error("Failed to stop kube-apiserver", err)
+		// Stop the kube-apiserver containers to avoid data modification by etcd during restore.
+		for _, component := range kubeApiservers {
+			err = c.StopComponent(ctx, component)
+			if err != nil {
+				logger.Error("Failed to stop", err, "component", component)
+			}
 		}
 		defer func() {
-			err = c.StartComponent(ctx, consts.ComponentKubeApiserver)
-			if err != nil {
-				logger.Error("Failed to start kube-apiserver", err)
+			for _, component := range kubeApiservers {
+				err = c.StartComponent(ctx, component)
+				if err != nil {
+					logger.Error("Failed to start", err, "component", component)
+				}
 			}
 		}()
 
@@ -149,18 +156,12 @@ func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
 			}
 		}
 		defer func() {
-			components := []string{
-				consts.ComponentEtcd,
-				consts.ComponentKubeApiserver,
-			}
-			for _, component := range components {
-				err := c.StartComponent(ctx, component)
-				if err != nil {
-					logger.Error("Failed to start", err, "component", component)
-				}
+			err := c.StartComponent(ctx, consts.ComponentEtcd)
+			if err != nil {
+				logger.Error("Failed to start", err, "component", consts.ComponentEtcd)
 			}
 
-			components = []string{
+			components := []string{
 				consts.ComponentKwokController,
 				consts.ComponentKubeControllerManager,
 				consts.ComponentKubeScheduler,
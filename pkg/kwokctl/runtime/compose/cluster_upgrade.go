@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// Upgrade rebuilds the components from the new configuration, the running ones are left as they are
+func (c *Cluster) Upgrade(ctx context.Context, conf *internalversion.KwokctlConfiguration) error {
+	if conf.Options.EtcdReplicas > 1 || conf.Options.KubeApiserverReplicas > 1 {
+		return fmt.Errorf("upgrade is not supported with multiple replicas")
+	}
+
+	conf = conf.DeepCopy()
+	conf.Components = nil
+	err := c.SetConfig(ctx, conf)
+	if err != nil {
+		return err
+	}
+	return c.Install(ctx)
+}
+
+// RollComponent restarts the component with its new configuration
+func (c *Cluster) RollComponent(ctx context.Context, name string) error {
+	err := c.stopComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// The container is created with the old configuration, e.g. the image
+	err = c.deleteComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	err = c.createComponent(ctx, name)
+	if err != nil {
+		return err
+	}
+	return c.startComponent(ctx, name)
+}
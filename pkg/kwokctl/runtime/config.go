@@ -149,6 +149,24 @@ type Runtime interface {
 	GetEtcdClient(ctx context.Context) (etcd.Client, func(), error)
 }
 
+// Upgrader is implemented by the runtimes that can move a running cluster to a new configuration in place.
+type Upgrader interface {
+	// Upgrade rebuilds the components from the new configuration, the running ones are left as they are
+	Upgrade(ctx context.Context, conf *internalversion.KwokctlConfiguration) error
+
+	// RollComponent restarts the component with its new configuration
+	RollComponent(ctx context.Context, name string) error
+}
+
+// Host is implemented by the runtimes that run some components in the kwokctl process.
+type Host interface {
+	// Hosting returns the components run in the current process
+	Hosting() []string
+
+	// Wait blocks until the context is canceled or any of the hosted components exits
+	Wait(ctx context.Context) error
+}
+
 type SnapshotSaveWithYAMLConfig struct {
 	Filters []string
 }
@@ -164,4 +182,5 @@ const (
 	ComponentStatusStopped
 	ComponentStatusRunning
 	ComponentStatusReady
+	ComponentStatusCrashed
 )
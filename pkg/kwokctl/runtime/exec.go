@@ -37,8 +37,9 @@ import (
 )
 
 // ForkExec forks a new process and execs the given command.
+// The name identifies the pid file and the log file of the process.
 // The process will be terminated when the context is canceled.
-func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ...string) error {
+func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, command string, args ...string) error {
 	pidPath := path.Join(dir, "pids", path.OnlyName(name)+".pid")
 	if file.Exists(pidPath) {
 		pidData, err := os.ReadFile(pidPath)
@@ -65,11 +66,11 @@ func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ..
 	})
 
 	if c.IsDryRun() {
-		dryrun.PrintMessage("%s", FormatExec(ctx, name, args...))
+		dryrun.PrintMessage("%s", FormatExec(ctx, command, args...))
 		dryrun.PrintMessage("echo $! >%s", pidPath)
 		return nil
 	}
-	cmd, err := exec.Command(ctx, name, args...)
+	cmd, err := exec.Command(ctx, command, args...)
 	if err != nil {
 		return err
 	}
@@ -78,6 +79,12 @@ func (c *Cluster) ForkExec(ctx context.Context, dir string, name string, args ..
 		return fmt.Errorf("write pid file %s: %w", pidPath, err)
 	}
 
+	// Reap the process if it exits before kwokctl, e.g. while supervising,
+	// otherwise it is left as a zombie and still looks running.
+	go func() {
+		_ = cmd.Wait()
+	}()
+
 	return nil
 }
 
@@ -117,6 +124,44 @@ func (c *Cluster) ForkExecKill(ctx context.Context, dir string, name string) err
 	return nil
 }
 
+// ForkExecSuspend suspends the process until ForkExecResume is called.
+func (c *Cluster) ForkExecSuspend(ctx context.Context, dir string, name string) error {
+	return c.forkExecSignal(ctx, dir, name, "STOP", exec.SuspendProcess)
+}
+
+// ForkExecResume resumes the process suspended by ForkExecSuspend.
+func (c *Cluster) ForkExecResume(ctx context.Context, dir string, name string) error {
+	return c.forkExecSignal(ctx, dir, name, "CONT", exec.ResumeProcess)
+}
+
+func (c *Cluster) forkExecSignal(ctx context.Context, dir string, name string, signal string, fun func(pid int) error) error {
+	pidPath := path.Join(dir, "pids", path.OnlyName(name)+".pid")
+	if c.IsDryRun() {
+		dryrun.PrintMessage("kill -%s $(cat %s)", signal, pidPath)
+		return nil
+	}
+
+	raw, err := os.ReadFile(pidPath)
+	if err != nil {
+		return fmt.Errorf("read pid file %s: %w", pidPath, err)
+	}
+	pid, err := strconv.Atoi(string(raw))
+	if err != nil {
+		return fmt.Errorf("parse pid file %s: %w", pidPath, err)
+	}
+	return fun(pid)
+}
+
+// ForkExecIsCrashed checks if the process exited without being killed by ForkExecKill,
+// i.e. its pid file is left behind.
+func (c *Cluster) ForkExecIsCrashed(ctx context.Context, dir string, name string) bool {
+	pidPath := path.Join(dir, "pids", path.OnlyName(name)+".pid")
+	if !file.Exists(pidPath) {
+		return false
+	}
+	return !c.ForkExecIsRunning(ctx, dir, name)
+}
+
 // ForkExecIsRunning checks if the process is running.
 func (c *Cluster) ForkExecIsRunning(ctx context.Context, dir string, name string) bool {
 	pidPath := path.Join(dir, "pids", path.OnlyName(name)+".pid")
@@ -185,7 +230,15 @@ func (c *Cluster) EnsureImage(ctx context.Context, command string, image string)
 }
 
 func (c *Cluster) ensureImage(ctx context.Context, command string, image string, quiet bool, cacheDir string) error {
-	dest := path.Join(cacheDir, "tarball", image+".tar")
+	// the tarball loaded from a bundle is verified by its digest and kept for other clusters
+	bundled := utilsimage.BundledTarballPath(cacheDir, image)
+	if file.Exists(bundled) {
+		return exec.Exec(ctx, command, "load",
+			"-i", bundled,
+		)
+	}
+
+	dest := utilsimage.TarballPath(cacheDir, image)
 	err := os.MkdirAll(filepath.Dir(dest), 0750)
 	if err != nil {
 		return err
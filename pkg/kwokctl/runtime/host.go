@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// WaitHosted keeps the current process running while it hosts components of the cluster,
+// the cluster is stopped once the context is canceled or a hosted component exits.
+func WaitHosted(ctx context.Context, rt Runtime) error {
+	host, ok := rt.(Host)
+	if !ok {
+		return nil
+	}
+	hosting := host.Hosting()
+	if len(hosting) == 0 {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("Components are running in the kwokctl process, interrupt to stop the cluster",
+		"components", hosting,
+	)
+	waitErr := host.Wait(ctx)
+	if waitErr != nil {
+		logger.Error("Hosted component exited", waitErr)
+	}
+
+	logger.Info("Cluster is stopping")
+	err := rt.Stop(context.WithoutCancel(ctx))
+	if err != nil {
+		return err
+	}
+	logger.Info("Cluster is stopped")
+	return waitErr
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/consts"
+)
+
+// String returns the status as shown by kwokctl get
+func (s ComponentStatus) String() string {
+	switch s {
+	case ComponentStatusReady:
+		return "Ready"
+	case ComponentStatusRunning:
+		return "NotReady"
+	case ComponentStatusStopped:
+		return "Stopped"
+	case ComponentStatusCrashed:
+		return "Crashed"
+	}
+	return "Unknown"
+}
+
+// ClusterInfo is the information about a cluster for the machine-readable output
+type ClusterInfo struct {
+	// Name is the name of the cluster
+	Name string `json:"name"`
+	// Runtime is the runtime of the cluster
+	Runtime string `json:"runtime,omitempty"`
+	// KubeVersion is the Kubernetes version of the cluster
+	KubeVersion string `json:"kubeVersion,omitempty"`
+	// KwokctlVersion is the version of kwokctl that created the cluster
+	KwokctlVersion string `json:"kwokctlVersion,omitempty"`
+	// Kubeconfig is the path of the kubeconfig of the cluster
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Ports is the ports given to the host by the component name
+	Ports map[string]uint32 `json:"ports,omitempty"`
+	// Ready is the number of ready components and the total, e.g. 3/5
+	Ready string `json:"ready"`
+	// Status is the status of the cluster, e.g. Ready
+	Status string `json:"status"`
+	// Components is the components of the cluster
+	Components []ComponentInfo `json:"components,omitempty"`
+}
+
+// ComponentInfo is the information about a component for the machine-readable output
+type ComponentInfo struct {
+	// Name is the name of the component
+	Name string `json:"name"`
+	// Version is the version of the component
+	Version string `json:"version,omitempty"`
+	// Binary is the binary of the component
+	Binary string `json:"binary,omitempty"`
+	// Image is the image of the component
+	Image string `json:"image,omitempty"`
+	// Ports is the ports given to the host by the port name
+	Ports map[string]uint32 `json:"ports,omitempty"`
+	// Status is the status of the component, e.g. Ready
+	Status string `json:"status"`
+}
+
+// InspectComponentInfo returns the information about the component
+func InspectComponentInfo(ctx context.Context, rt Runtime, component internalversion.Component) ComponentInfo {
+	info := ComponentInfo{
+		Name:    component.Name,
+		Version: component.Version,
+		Binary:  component.Binary,
+		Image:   component.Image,
+	}
+	for _, port := range component.Ports {
+		if port.HostPort == 0 {
+			continue
+		}
+		if info.Ports == nil {
+			info.Ports = map[string]uint32{}
+		}
+		name := port.Name
+		if name == "" {
+			name = fmt.Sprint(port.Port)
+		}
+		info.Ports[name] = port.HostPort
+	}
+
+	s, err := rt.InspectComponent(ctx, component.Name)
+	if err != nil {
+		info.Status = "Error:" + err.Error()
+	} else {
+		info.Status = s.String()
+	}
+	return info
+}
+
+// InspectClusterInfo returns the information about the cluster and its components
+func InspectClusterInfo(ctx context.Context, name string, rt Runtime) ClusterInfo {
+	info := ClusterInfo{
+		Name:       name,
+		Kubeconfig: rt.GetWorkdirPath(InHostKubeconfigName),
+		Ready:      "0/0",
+	}
+
+	conf, err := rt.Config(ctx)
+	if err == nil {
+		info.Runtime = conf.Options.Runtime
+		info.KubeVersion = conf.Options.KubeVersion
+		info.KwokctlVersion = conf.Status.Version
+		info.Ports = optionsPorts(&conf.Options)
+	}
+
+	components, err := rt.ListComponents(ctx)
+	if err != nil {
+		info.Status = "Unknown"
+		return info
+	}
+
+	var count int
+	for _, component := range components {
+		componentInfo := InspectComponentInfo(ctx, rt, component)
+		if componentInfo.Status == ComponentStatusReady.String() {
+			count++
+		}
+		info.Components = append(info.Components, componentInfo)
+	}
+	info.Ready = fmt.Sprintf("%d/%d", count, len(components))
+
+	switch {
+	case len(components) == 0:
+		info.Status = "Unknown"
+	case count == 0:
+		info.Status = "Stopped"
+	default:
+		ready, err := rt.Ready(ctx)
+		if err != nil {
+			info.Status = "Error:" + err.Error()
+		} else if !ready {
+			info.Status = "NotReady"
+		} else {
+			info.Status = "Ready"
+		}
+	}
+	return info
+}
+
+func optionsPorts(options *internalversion.KwokctlConfigurationOptions) map[string]uint32 {
+	ports := map[string]uint32{}
+	for name, port := range map[string]uint32{
+		consts.ComponentKubeApiserver:              options.KubeApiserverPort,
+		consts.ComponentKubeApiserverInsecureProxy: options.KubeApiserverInsecurePort,
+		consts.ComponentEtcd:                       options.EtcdPort,
+		consts.ComponentKwokController:             options.KwokControllerPort,
+		consts.ComponentKubeControllerManager:      options.KubeControllerManagerPort,
+		consts.ComponentKubeScheduler:              options.KubeSchedulerPort,
+		consts.ComponentMetricsServer:              options.MetricsServerPort,
+		consts.ComponentPrometheus:                 options.PrometheusPort,
+		consts.ComponentJaeger:                     options.JaegerPort,
+		consts.ComponentDashboard:                  options.DashboardPort,
+	} {
+		if port != 0 {
+			ports[name] = port
+		}
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	return ports
+}
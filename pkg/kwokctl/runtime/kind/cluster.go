@@ -244,6 +244,10 @@ func (c *Cluster) Install(ctx context.Context) error {
 		return err
 	}
 
+	if env.kwokctlConfig.Options.KubeApiserverReplicas > 1 || env.kwokctlConfig.Options.EtcdReplicas > 1 {
+		return fmt.Errorf("multiple kube-apiserver or etcd replicas are not supported by the %s runtime", env.kwokctlConfig.Options.Runtime)
+	}
+
 	err = c.preInstall(ctx, env)
 	if err != nil {
 		return err
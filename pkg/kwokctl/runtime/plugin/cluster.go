@@ -0,0 +1,384 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/etcd"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/wait"
+)
+
+// Cluster is an implementation of Runtime that proxies the operations to a plugin
+type Cluster struct {
+	*runtime.Cluster
+
+	runtime string
+	plugin  string
+}
+
+// NewCluster returns a builder of the runtime provided by the plugin executable
+func NewCluster(runtimeName, plugin string) runtime.BuildRuntime {
+	return func(name, workdir string) (runtime.Runtime, error) {
+		return &Cluster{
+			Cluster: runtime.NewCluster(name, workdir),
+			runtime: runtimeName,
+			plugin:  plugin,
+		}, nil
+	}
+}
+
+func (c *Cluster) request(ctx context.Context) (*Request, error) {
+	req := &Request{
+		Name:    c.Name(),
+		Workdir: c.Workdir(),
+	}
+	config, err := c.Config(ctx)
+	if err != nil {
+		// the config is not set before the cluster is created
+		return req, nil
+	}
+	req.Config, err = internalversion.ConvertToV1alpha1KwokctlConfiguration(config)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (c *Cluster) exec(ctx context.Context, method string, req *Request, out io.Writer) error {
+	if c.IsDryRun() {
+		dryrun.PrintMessage("%s %s", c.plugin, method)
+		return nil
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	ctx = exec.WithIOStreams(ctx, exec.IOStreams{
+		In:  bytes.NewReader(data),
+		Out: out,
+	})
+	err = exec.Exec(ctx, c.plugin, method)
+	if err != nil {
+		return fmt.Errorf("runtime plugin %q: %w", c.runtime, err)
+	}
+	return nil
+}
+
+// call invokes the method of the plugin and decodes its response
+func (c *Cluster) call(ctx context.Context, method string, req *Request) (*Response, error) {
+	out := bytes.NewBuffer(nil)
+	err := c.exec(ctx, method, req, out)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Response{}
+	if out.Len() == 0 {
+		return resp, nil
+	}
+	err = json.Unmarshal(out.Bytes(), resp)
+	if err != nil {
+		return nil, fmt.Errorf("runtime plugin %q: decode response of %s: %w", c.runtime, method, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("runtime plugin %q: %s: %s", c.runtime, method, resp.Error)
+	}
+	return resp, nil
+}
+
+func (c *Cluster) callSimple(ctx context.Context, method string, fun func(req *Request)) (*Response, error) {
+	req, err := c.request(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if fun != nil {
+		fun(req)
+	}
+	return c.call(ctx, method, req)
+}
+
+// Available checks whether the runtime is available.
+func (c *Cluster) Available(ctx context.Context) error {
+	_, err := c.callSimple(ctx, MethodAvailable, nil)
+	return err
+}
+
+// Install installs the cluster
+func (c *Cluster) Install(ctx context.Context) error {
+	err := c.Cluster.Install(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.callSimple(ctx, MethodInstall, nil)
+	if err != nil {
+		return err
+	}
+	if resp.Config == nil {
+		return nil
+	}
+
+	config, err := internalversion.ConvertToInternalKwokctlConfiguration(resp.Config)
+	if err != nil {
+		return err
+	}
+	err = c.SetConfig(ctx, config)
+	if err != nil {
+		return err
+	}
+	return c.Save(ctx)
+}
+
+// Uninstall uninstalls the cluster.
+func (c *Cluster) Uninstall(ctx context.Context) error {
+	_, err := c.callSimple(ctx, MethodUninstall, nil)
+	if err != nil {
+		return err
+	}
+	return c.Cluster.Uninstall(ctx)
+}
+
+// Up starts the cluster.
+func (c *Cluster) Up(ctx context.Context) error {
+	_, err := c.callSimple(ctx, MethodUp, nil)
+	return err
+}
+
+// Down stops the cluster
+func (c *Cluster) Down(ctx context.Context) error {
+	_, err := c.callSimple(ctx, MethodDown, nil)
+	return err
+}
+
+// Start starts the cluster
+func (c *Cluster) Start(ctx context.Context) error {
+	_, err := c.callSimple(ctx, MethodStart, nil)
+	return err
+}
+
+// Stop stops the cluster
+func (c *Cluster) Stop(ctx context.Context) error {
+	_, err := c.callSimple(ctx, MethodStop, nil)
+	return err
+}
+
+// StartComponent starts a component in the cluster
+func (c *Cluster) StartComponent(ctx context.Context, name string) error {
+	_, err := c.callSimple(ctx, MethodStartComponent, func(req *Request) {
+		req.Component = name
+	})
+	return err
+}
+
+// StopComponent stops a component in the cluster
+func (c *Cluster) StopComponent(ctx context.Context, name string) error {
+	_, err := c.callSimple(ctx, MethodStopComponent, func(req *Request) {
+		req.Component = name
+	})
+	return err
+}
+
+// InspectComponent returns the status of the component
+func (c *Cluster) InspectComponent(ctx context.Context, name string) (runtime.ComponentStatus, error) {
+	resp, err := c.callSimple(ctx, MethodInspectComponent, func(req *Request) {
+		req.Component = name
+	})
+	if err != nil {
+		return runtime.ComponentStatusUnknown, err
+	}
+	return parseComponentStatus(resp.Status), nil
+}
+
+func parseComponentStatus(s string) runtime.ComponentStatus {
+	for _, status := range []runtime.ComponentStatus{
+		runtime.ComponentStatusReady,
+		runtime.ComponentStatusRunning,
+		runtime.ComponentStatusStopped,
+		runtime.ComponentStatusCrashed,
+	} {
+		if status.String() == s {
+			return status
+		}
+	}
+	return runtime.ComponentStatusUnknown
+}
+
+// Ready returns true if the cluster is ready
+func (c *Cluster) Ready(ctx context.Context) (bool, error) {
+	resp, err := c.callSimple(ctx, MethodReady, nil)
+	if err != nil {
+		return false, err
+	}
+	return resp.Ready, nil
+}
+
+// WaitReady waits for the cluster to be ready.
+func (c *Cluster) WaitReady(ctx context.Context, timeout time.Duration) error {
+	if c.IsDryRun() {
+		return nil
+	}
+
+	var (
+		err     error
+		waitErr error
+		ready   bool
+	)
+	logger := log.FromContext(ctx)
+	waitErr = wait.Poll(ctx, func(ctx context.Context) (bool, error) {
+		ready, err = c.Ready(ctx)
+		if err != nil {
+			logger.Debug("Cluster is not ready",
+				"err", err,
+			)
+		}
+		return ready, nil
+	},
+		wait.WithTimeout(timeout),
+		wait.WithContinueOnError(10),
+		wait.WithInterval(time.Second/2),
+	)
+	if err != nil {
+		return err
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	return nil
+}
+
+// AddContext add the context of cluster to kubeconfig
+func (c *Cluster) AddContext(ctx context.Context, kubeconfigPath string) error {
+	_, err := c.callSimple(ctx, MethodAddContext, func(req *Request) {
+		req.Path = kubeconfigPath
+	})
+	return err
+}
+
+// RemoveContext remove the context of cluster from kubeconfig
+func (c *Cluster) RemoveContext(ctx context.Context, kubeconfigPath string) error {
+	_, err := c.callSimple(ctx, MethodRemoveContext, func(req *Request) {
+		req.Path = kubeconfigPath
+	})
+	return err
+}
+
+// InitCRs initializes the CRs.
+func (c *Cluster) InitCRs(ctx context.Context) error {
+	_, err := c.callSimple(ctx, MethodInitCRs, nil)
+	return err
+}
+
+// ListBinaries list binaries in the cluster
+func (c *Cluster) ListBinaries(ctx context.Context) ([]string, error) {
+	resp, err := c.callSimple(ctx, MethodListBinaries, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// ListImages list images in the cluster
+func (c *Cluster) ListImages(ctx context.Context) ([]string, error) {
+	resp, err := c.callSimple(ctx, MethodListImages, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// SnapshotSave save the snapshot of cluster
+func (c *Cluster) SnapshotSave(ctx context.Context, path string) error {
+	_, err := c.callSimple(ctx, MethodSnapshotSave, func(req *Request) {
+		req.Path = path
+	})
+	return err
+}
+
+// SnapshotRestore restore the snapshot of cluster
+func (c *Cluster) SnapshotRestore(ctx context.Context, path string) error {
+	_, err := c.callSimple(ctx, MethodSnapshotRestore, func(req *Request) {
+		req.Path = path
+	})
+	return err
+}
+
+// CollectLogs returns the logs of the specified component.
+func (c *Cluster) CollectLogs(ctx context.Context, dir string) error {
+	_, err := c.callSimple(ctx, MethodCollectLogs, func(req *Request) {
+		req.Path = dir
+	})
+	return err
+}
+
+// Logs returns the logs of the specified component.
+func (c *Cluster) Logs(ctx context.Context, name string, out io.Writer) error {
+	return c.stream(ctx, MethodLogs, out, func(req *Request) {
+		req.Component = name
+	})
+}
+
+// LogsFollow follows the logs of the component
+func (c *Cluster) LogsFollow(ctx context.Context, name string, out io.Writer) error {
+	return c.stream(ctx, MethodLogsFollow, out, func(req *Request) {
+		req.Component = name
+	})
+}
+
+// EtcdctlInCluster implements the ectdctl subcommand
+func (c *Cluster) EtcdctlInCluster(ctx context.Context, args ...string) error {
+	opt := exec.GetExecOptions(ctx)
+	out := opt.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	return c.stream(ctx, MethodEtcdctl, out, func(req *Request) {
+		req.Args = args
+	})
+}
+
+func (c *Cluster) stream(ctx context.Context, method string, out io.Writer, fun func(req *Request)) error {
+	req, err := c.request(ctx)
+	if err != nil {
+		return err
+	}
+	fun(req)
+	return c.exec(ctx, method, req, out)
+}
+
+// PortForward is not supported by the plugins
+func (c *Cluster) PortForward(ctx context.Context, name string, portOrName string, hostPort uint32) (cancel func(), retErr error) {
+	return nil, fmt.Errorf("runtime plugin %q: %w", c.runtime, errors.ErrUnsupported)
+}
+
+// GetEtcdClient is not supported by the plugins, the snapshots are saved by the plugin instead
+func (c *Cluster) GetEtcdClient(ctx context.Context) (etcd.Client, func(), error) {
+	return nil, nil, fmt.Errorf("runtime plugin %q: %w", c.runtime, errors.ErrUnsupported)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	configv1alpha1 "sigs.k8s.io/kwok/pkg/apis/config/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+const fakePluginEnv = "KWOKCTL_TEST_FAKE_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakePluginEnv) != "" {
+		fakePlugin()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// fakePlugin is the plugin used by the tests, served by the test binary itself
+func fakePlugin() {
+	var req Request
+	err := json.NewDecoder(os.Stdin).Decode(&req)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var resp Response
+	switch os.Args[1] {
+	case MethodInstall:
+		resp.Config = req.Config
+		resp.Config.Components = []configv1alpha1.Component{{Name: "etcd"}}
+	case MethodInspectComponent:
+		if req.Component == "etcd" {
+			resp.Status = "Ready"
+		}
+	case MethodListImages:
+		resp.Items = []string{"image-of-" + req.Name}
+	case MethodUp:
+		resp.Error = "boom"
+	case MethodLogs:
+		_, _ = fmt.Fprintf(os.Stdout, "logs of %s\n", req.Component)
+		return
+	case MethodStop:
+		_, _ = fmt.Fprintln(os.Stderr, "crashed")
+		os.Exit(1)
+	}
+	_ = json.NewEncoder(os.Stdout).Encode(resp)
+}
+
+func TestPlugin(t *testing.T) {
+	ctx := context.Background()
+
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	err = os.Symlink(self, filepath.Join(dir, Prefix+"fake"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, Prefix+"not-executable"), nil, 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(fakePluginEnv, "true")
+
+	registry := runtime.NewRegistry()
+	Discover(registry, dir)
+	if got, want := registry.List(), []string{"fake"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Discover() = %v, want %v", got, want)
+	}
+
+	buildRuntime, _ := registry.Get("fake")
+	rt, err := buildRuntime("kwok-test", filepath.Join(dir, "clusters", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = rt.Available(ctx)
+	if err != nil {
+		t.Fatalf("Available() error = %v", err)
+	}
+
+	conf := &internalversion.KwokctlConfiguration{}
+	conf.Options.Runtime = "fake"
+	err = rt.SetConfig(ctx, conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rt.Install(ctx)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	components, err := rt.ListComponents(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(components) != 1 || components[0].Name != "etcd" {
+		t.Errorf("the config returned by install is not set, got components %v", components)
+	}
+
+	status, err := rt.InspectComponent(ctx, "etcd")
+	if err != nil {
+		t.Fatalf("InspectComponent() error = %v", err)
+	}
+	if status != runtime.ComponentStatusReady {
+		t.Errorf("InspectComponent() = %v, want %v", status, runtime.ComponentStatusReady)
+	}
+
+	images, err := rt.ListImages(ctx)
+	if err != nil {
+		t.Fatalf("ListImages() error = %v", err)
+	}
+	if want := []string{"image-of-kwok-test"}; !reflect.DeepEqual(images, want) {
+		t.Errorf("ListImages() = %v, want %v", images, want)
+	}
+
+	out := bytes.NewBuffer(nil)
+	err = rt.Logs(ctx, "etcd", out)
+	if err != nil {
+		t.Fatalf("Logs() error = %v", err)
+	}
+	if got, want := out.String(), "logs of etcd\n"; got != want {
+		t.Errorf("Logs() = %q, want %q", got, want)
+	}
+
+	err = rt.Up(ctx)
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Up() error = %v, want the error of the response", err)
+	}
+
+	err = rt.Stop(ctx)
+	if err == nil || !strings.Contains(err.Error(), "crashed") {
+		t.Errorf("Stop() error = %v, want the stderr of the plugin", err)
+	}
+}
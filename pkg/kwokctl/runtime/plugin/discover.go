@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+)
+
+func init() {
+	Discover(runtime.DefaultRegistry, os.Getenv("PATH"))
+}
+
+// Discover registers the plugins found in the list of directories into the registry.
+// The runtimes already registered are not overridden, and the first plugin found wins, as with PATH lookups.
+func Discover(registry *runtime.Registry, paths string) {
+	for _, dir := range filepath.SplitList(paths) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), Prefix) {
+				continue
+			}
+			name := strings.TrimSuffix(strings.TrimPrefix(entry.Name(), Prefix), ".exe")
+			if name == "" {
+				continue
+			}
+			if _, ok := registry.Get(name); ok {
+				continue
+			}
+			plugin := filepath.Join(dir, entry.Name())
+			if _, err := exec.LookPath(plugin); err != nil {
+				// not executable
+				continue
+			}
+			registry.Register(name, NewCluster(name, plugin))
+		}
+	}
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin implements the runtimes provided by external executables named kwokctl-runtime-<name>.
+//
+// The executable is invoked once per operation with the method as its only argument,
+// a Request is written to its stdin, and a Response is read from its stdout.
+// The logs and etcdctl methods stream their output to stdout instead of a Response.
+// A non-zero exit code or a non-empty Response.Error fails the operation.
+package plugin
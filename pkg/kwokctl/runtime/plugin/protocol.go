@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	configv1alpha1 "sigs.k8s.io/kwok/pkg/apis/config/v1alpha1"
+)
+
+// Prefix is the prefix of the executables that provide a runtime
+const Prefix = "kwokctl-runtime-"
+
+// The methods passed to the plugin as its argument
+const (
+	MethodAvailable        = "available"
+	MethodInstall          = "install"
+	MethodUninstall        = "uninstall"
+	MethodUp               = "up"
+	MethodDown             = "down"
+	MethodStart            = "start"
+	MethodStop             = "stop"
+	MethodStartComponent   = "start-component"
+	MethodStopComponent    = "stop-component"
+	MethodInspectComponent = "inspect-component"
+	MethodReady            = "ready"
+	MethodAddContext       = "add-context"
+	MethodRemoveContext    = "remove-context"
+	MethodInitCRs          = "init-crs"
+	MethodListBinaries     = "list-binaries"
+	MethodListImages       = "list-images"
+	MethodSnapshotSave     = "snapshot-save"
+	MethodSnapshotRestore  = "snapshot-restore"
+	MethodCollectLogs      = "collect-logs"
+
+	// The following methods stream their output to stdout
+	MethodLogs       = "logs"
+	MethodLogsFollow = "logs-follow"
+	MethodEtcdctl    = "etcdctl"
+)
+
+// Request is written to the stdin of the plugin
+type Request struct {
+	// Name is the name of the cluster
+	Name string `json:"name"`
+	// Workdir is the working directory of the cluster
+	Workdir string `json:"workdir"`
+	// Config is the configuration of the cluster, if it has been set
+	Config *configv1alpha1.KwokctlConfiguration `json:"config,omitempty"`
+	// Component is the name of the component for the component methods and logs
+	Component string `json:"component,omitempty"`
+	// Path is the snapshot file, the kubeconfig or the directory to collect logs into
+	Path string `json:"path,omitempty"`
+	// Args are the arguments of etcdctl
+	Args []string `json:"args,omitempty"`
+}
+
+// Response is read from the stdout of the plugin
+type Response struct {
+	// Error is the reason of the failure
+	Error string `json:"error,omitempty"`
+	// Config is the configuration to save after install, e.g. with the components filled in
+	Config *configv1alpha1.KwokctlConfiguration `json:"config,omitempty"`
+	// Status is the status of the component for inspect-component, Ready, NotReady, Stopped or Unknown
+	Status string `json:"status,omitempty"`
+	// Ready is whether the cluster is ready for ready
+	Ready bool `json:"ready,omitempty"`
+	// Items are the binaries or images for list-binaries and list-images
+	Items []string `json:"items,omitempty"`
+}
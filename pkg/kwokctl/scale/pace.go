@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+)
+
+// pacer limits the pace of creating or deleting resources.
+type pacer struct {
+	start         time.Time
+	total         int
+	rate          float64
+	ramp          time.Duration
+	batchSize     int
+	batchInterval time.Duration
+}
+
+func newPacer(conf Config, total int) *pacer {
+	return &pacer{
+		start:         time.Now(),
+		total:         total,
+		rate:          conf.Rate,
+		ramp:          conf.Ramp,
+		batchSize:     conf.BatchSize,
+		batchInterval: conf.BatchInterval,
+	}
+}
+
+// delay returns the duration since start before the i-th resource can be handled,
+// it is the largest of the limits.
+func (p *pacer) delay(i int) time.Duration {
+	var d time.Duration
+	if p.rate > 0 {
+		d = max(d, time.Duration(float64(i)/p.rate*float64(time.Second)))
+	}
+	if p.ramp > 0 && p.total > 0 {
+		d = max(d, time.Duration(float64(p.ramp)*float64(i)/float64(p.total)))
+	}
+	if p.batchSize > 0 && p.batchInterval > 0 {
+		d = max(d, time.Duration(i/p.batchSize)*p.batchInterval)
+	}
+	return d
+}
+
+// wait waits until the i-th resource can be handled.
+func (p *pacer) wait(ctx context.Context, i int) error {
+	d := p.delay(i) - time.Since(p.start)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func validatePace(conf Config) error {
+	if conf.Rate < 0 {
+		return fmt.Errorf("rate must be greater than or equal to 0")
+	}
+	if conf.Ramp < 0 {
+		return fmt.Errorf("ramp must be greater than or equal to 0")
+	}
+	if conf.BatchSize < 0 {
+		return fmt.Errorf("batch size must be greater than or equal to 0")
+	}
+	if conf.BatchInterval < 0 {
+		return fmt.Errorf("batch interval must be greater than or equal to 0")
+	}
+	if conf.BatchInterval > 0 && conf.BatchSize == 0 {
+		return fmt.Errorf("batch size must be set when batch interval is set")
+	}
+	if conf.BatchSize > 0 && conf.BatchInterval == 0 {
+		return fmt.Errorf("batch interval must be set when batch size is set")
+	}
+	return nil
+}
+
+// WaveConfig is the configuration for oscillating the replicas of a resource.
+type WaveConfig struct {
+	// Min is the minimum number of replicas, the maximum is the replicas of Config.
+	Min int
+	// Period is the duration of a cycle from the minimum to the maximum and back.
+	Period time.Duration
+	// Interval is the interval between scaling.
+	Interval time.Duration
+	// Cycles is the number of cycles, 0 means until the context is done.
+	Cycles int
+}
+
+// Wave oscillates the replicas of a resource between the minimum and the maximum.
+func Wave(ctx context.Context, clientset client.Clientset, conf Config, wave WaveConfig) error {
+	if wave.Period <= 0 {
+		return fmt.Errorf("wave period must be greater than 0")
+	}
+	if wave.Interval <= 0 {
+		return fmt.Errorf("wave interval must be greater than 0")
+	}
+	if wave.Min < 0 || wave.Min > conf.Replicas {
+		return fmt.Errorf("wave min must be between 0 and replicas %d", conf.Replicas)
+	}
+
+	if conf.DryRun {
+		dryrun.PrintMessage("# Oscillate resource %s between %d and %d replicas every %s", conf.Name, wave.Min, conf.Replicas, wave.Period)
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+	maxReplicas := conf.Replicas
+	end := wave.Period * time.Duration(wave.Cycles)
+	start := time.Now()
+	for {
+		elapsed := time.Since(start)
+		if wave.Cycles > 0 && elapsed >= end {
+			elapsed = end
+		}
+
+		conf.Replicas = waveReplicas(wave.Min, maxReplicas, wave.Period, elapsed)
+		logger.Info("Wave", "replicas", conf.Replicas, "elapsed", elapsed.Truncate(time.Second))
+		err := Scale(ctx, clientset, conf)
+		if err != nil {
+			return err
+		}
+
+		if wave.Cycles > 0 && elapsed >= end {
+			return nil
+		}
+
+		timer := time.NewTimer(wave.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// waveReplicas returns the replicas at the elapsed time of a cosine wave,
+// which starts from the minimum and reaches the maximum at the half of the period.
+func waveReplicas(minReplicas, maxReplicas int, period, elapsed time.Duration) int {
+	phase := 2 * math.Pi * float64(elapsed%period) / float64(period)
+	return minReplicas + int(math.Round(float64(maxReplicas-minReplicas)*(1-math.Cos(phase))/2))
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_pacerDelay(t *testing.T) {
+	tests := []struct {
+		name string
+		conf Config
+		i    int
+		want time.Duration
+	}{
+		{
+			name: "no limit",
+			i:    10,
+			want: 0,
+		},
+		{
+			name: "rate",
+			conf: Config{Rate: 4},
+			i:    10,
+			want: 2500 * time.Millisecond,
+		},
+		{
+			name: "ramp",
+			conf: Config{Ramp: time.Minute},
+			i:    50,
+			want: 30 * time.Second,
+		},
+		{
+			name: "batch",
+			conf: Config{BatchSize: 10, BatchInterval: time.Second},
+			i:    25,
+			want: 2 * time.Second,
+		},
+		{
+			name: "largest",
+			conf: Config{Rate: 100, Ramp: time.Minute, BatchSize: 10, BatchInterval: time.Second},
+			i:    50,
+			want: 30 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPacer(tt.conf, 100)
+			if got := p.delay(tt.i); got != tt.want {
+				t.Errorf("delay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validatePace(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    Config
+		wantErr bool
+	}{
+		{
+			name: "no limit",
+		},
+		{
+			name: "batch",
+			conf: Config{BatchSize: 10, BatchInterval: time.Second},
+		},
+		{
+			name:    "batch size without interval",
+			conf:    Config{BatchSize: 10},
+			wantErr: true,
+		},
+		{
+			name:    "batch interval without size",
+			conf:    Config{BatchInterval: time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "negative rate",
+			conf:    Config{Rate: -1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePace(tt.conf); (err != nil) != tt.wantErr {
+				t.Errorf("validatePace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_waveReplicas(t *testing.T) {
+	period := time.Minute
+	tests := []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{0, 10},
+		{15 * time.Second, 55},
+		{30 * time.Second, 100},
+		{45 * time.Second, 55},
+		{time.Minute, 10},
+		{90 * time.Second, 100},
+	}
+	for _, tt := range tests {
+		if got := waveReplicas(10, 100, period, tt.elapsed); got != tt.want {
+			t.Errorf("waveReplicas(%v) = %v, want %v", tt.elapsed, got, tt.want)
+		}
+	}
+}
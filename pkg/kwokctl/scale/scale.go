@@ -25,19 +25,27 @@ import (
 	"sort"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/pager"
 
+	"sigs.k8s.io/kwok/kustomize/kwokctl/resource"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
 	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
 	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
 	"sigs.k8s.io/kwok/pkg/log"
 	"sigs.k8s.io/kwok/pkg/utils/client"
 	"sigs.k8s.io/kwok/pkg/utils/gotpl"
 	utilsnet "sigs.k8s.io/kwok/pkg/utils/net"
+	"sigs.k8s.io/kwok/pkg/utils/progressbar"
+	"sigs.k8s.io/kwok/pkg/utils/slices"
 	"sigs.k8s.io/kwok/pkg/utils/yaml"
 )
 
@@ -45,11 +53,46 @@ import (
 type Config struct {
 	Parameters   any
 	Template     string
+	Children     []internalversion.KwokctlResourceChild
 	Name         string
 	Namespace    string
 	Replicas     int
 	SerialLength int
 	DryRun       bool
+
+	// Rate is the maximum number of resources created or deleted per second, 0 means no limit.
+	Rate float64
+	// Ramp is the duration over which the creation or deletion is spread linearly.
+	Ramp time.Duration
+	// BatchSize is the number of resources created or deleted in each batch.
+	BatchSize int
+	// BatchInterval is the interval between batches.
+	BatchInterval time.Duration
+}
+
+// GetResource returns the KwokctlResource with the given name,
+// the default resources are used for pod and node if they are not found.
+func GetResource(ctx context.Context, krcs []*internalversion.KwokctlResource, name string) (*internalversion.KwokctlResource, error) {
+	krc, ok := slices.Find(krcs, func(krc *internalversion.KwokctlResource) bool {
+		return krc.Name == name
+	})
+	if ok {
+		return krc, nil
+	}
+
+	var resourceData string
+	switch name {
+	default:
+		return nil, fmt.Errorf("resource %s is not exists", name)
+	case "pod":
+		resourceData = resource.DefaultPod
+	case "node":
+		resourceData = resource.DefaultNode
+	}
+
+	logger := log.FromContext(ctx)
+	logger.Info("No resource found, use default resource", "resource", name)
+	return config.UnmarshalWithType[*internalversion.KwokctlResource](resourceData)
 }
 
 // Scale scales a resource in a cluster.
@@ -57,32 +100,53 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 	if conf.SerialLength == 0 && conf.Replicas > 1 {
 		return fmt.Errorf("serial length must be greater than 0 when replicas is greater than 1")
 	}
+	err := validatePace(conf)
+	if err != nil {
+		return err
+	}
 
 	param := conf.Parameters
 
-	name := conf.Name
 	namespace := conf.Namespace
-	index := 0
-	renderer := gotpl.NewRenderer(gotpl.FuncMap{
-		"Name": func() string {
-			return name
-		},
-		"Namespace": func() string {
-			return namespace
-		},
-		"Index": func() int {
-			return index
-		},
-		"AddCIDR": utilsnet.AddCIDR,
-	})
+	state := &renderState{
+		name:      conf.Name,
+		namespace: namespace,
+	}
+	renderer := newRenderer(state)
 	data, err := renderer.ToJSON(conf.Template, param)
 	if err != nil {
 		return err
 	}
 
+	childrenData := make([][]byte, 0, len(conf.Children))
+	if len(conf.Children) != 0 {
+		var example map[string]any
+		err = json.Unmarshal(data, &example)
+		if err != nil {
+			return err
+		}
+		state.parent = example
+		for _, child := range conf.Children {
+			state.name = generateSerialNumber(conf.Name+"-"+child.Name, 0, conf.SerialLength)
+			childData, err := renderer.ToJSON(child.Template, param)
+			if err != nil {
+				return fmt.Errorf("failed to render child %q: %w", child.Name, err)
+			}
+			childrenData = append(childrenData, childData)
+		}
+		state.name = conf.Name
+		state.parent = nil
+	}
+
 	if conf.DryRun {
 		dryrun.PrintMessage("# Scale resource %s to %d replicas", conf.Name, conf.Replicas)
+		if conf.Rate > 0 || conf.Ramp > 0 || conf.BatchSize > 0 {
+			dryrun.PrintMessage("# Pace: rate %v/s, ramp %s, batch size %d, batch interval %s", conf.Rate, conf.Ramp, conf.BatchSize, conf.BatchInterval)
+		}
 		dryrun.PrintMessage("# Resource example: %s", string(data))
+		for i, child := range conf.Children {
+			dryrun.PrintMessage("# Child %s with %d replicas for each resource, example: %s", child.Name, child.Replicas, string(childrenData[i]))
+		}
 		return nil
 	}
 
@@ -116,6 +180,34 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 
 	nri := dynamicClient.Resource(gvr)
 
+	childrenResource := make([]dynamic.NamespaceableResourceInterface, 0, len(childrenData))
+	childrenNamespaced := make([]bool, 0, len(childrenData))
+	for _, childData := range childrenData {
+		var cu *unstructured.Unstructured
+		err = json.Unmarshal(childData, &cu)
+		if err != nil {
+			return err
+		}
+		cgv, err := schema.ParseGroupVersion(cu.GetAPIVersion())
+		if err != nil {
+			return err
+		}
+		cgvr, err := restMapper.ResourceFor(schema.GroupVersionResource{
+			Group:    cgv.Group,
+			Version:  cgv.Version,
+			Resource: cu.GetKind(),
+		})
+		if err != nil {
+			return err
+		}
+		cmapping, err := restMapper.RESTMapping(schema.GroupKind{Group: cgv.Group, Kind: cu.GetKind()}, cgv.Version)
+		if err != nil {
+			return err
+		}
+		childrenNamespaced = append(childrenNamespaced, cmapping.Scope.Name() == meta.RESTScopeNameNamespace)
+		childrenResource = append(childrenResource, dynamicClient.Resource(cgvr))
+	}
+
 	logger := log.FromContext(ctx)
 	logger = logger.With("name", conf.Name, "replicas", conf.Replicas, "resource", gvr.Resource)
 
@@ -123,6 +215,7 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 
 	if namespace == "" {
 		namespace = u.GetNamespace()
+		state.namespace = namespace
 	}
 	if namespace != "" {
 		ri = nri.Namespace(namespace)
@@ -133,7 +226,7 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 	listPager := pager.New(func(ctx context.Context, opts metav1.ListOptions) (apiruntime.Object, error) {
 		return ri.List(ctx, opts)
 	})
-	deleteCount := 0
+	toDelete := []string{}
 	objs := make([]softInfo, 0, conf.Replicas)
 	sorted := false
 	err = listPager.EachListItem(ctx, metav1.ListOptions{
@@ -160,13 +253,8 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 			sorted = true
 		}
 
-		deleteCount++
-
 		if len(objs) == 0 {
-			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
-			if err != nil {
-				logger.Error("Delete resource", err)
-			}
+			toDelete = append(toDelete, obj.GetName())
 			return nil
 		}
 
@@ -174,18 +262,12 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 		endObj := objs[len(objs)-1]
 		if endObj.Less(obj.GetCreationTimestamp(), obj.GetName()) {
 			// Delete the last object.
-			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
-			if err != nil {
-				logger.Error("Delete resource", err)
-			}
+			toDelete = append(toDelete, obj.GetName())
 			return nil
 		}
 
 		// Delete the end object.
-		err = ri.Delete(ctx, endObj.Name, metav1.DeleteOptions{})
-		if err != nil {
-			logger.Error("Delete resource", err)
-		}
+		toDelete = append(toDelete, endObj.Name)
 
 		// Find the index of the new object to be inserted.
 		index, _ := sort.Find(len(objs), func(i int) int {
@@ -197,10 +279,7 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 
 		if index == len(objs) {
 			// Delete the last object.
-			err = ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
-			if err != nil {
-				logger.Error("Delete resource", err)
-			}
+			toDelete = append(toDelete, obj.GetName())
 			return nil
 		}
 		// Insert the new object.
@@ -215,8 +294,22 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 		return err
 	}
 
-	if deleteCount > 0 {
-		logger.Info("Deleted resources", "counter", deleteCount, "elapsed", time.Since(start))
+	if len(toDelete) > 0 {
+		pace := newPacer(conf, len(toDelete))
+		progress := progressbar.NewCounter("Deleting "+conf.Name, uint64(len(toDelete)))
+		for i, name := range toDelete {
+			err = pace.wait(ctx, i)
+			if err != nil {
+				return err
+			}
+			err = ri.Delete(ctx, name, metav1.DeleteOptions{})
+			if err != nil {
+				logger.Error("Delete resource", err)
+			}
+			deleteChildren(ctx, childrenResource, namespace, name)
+			progress.Add(1)
+		}
+		logger.Info("Deleted resources", "counter", len(toDelete), "elapsed", time.Since(start))
 		return nil
 	}
 
@@ -235,19 +328,29 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 	// free memory
 	objs = nil
 
+	pace := newPacer(conf, wantCreate)
+	progress := progressbar.NewCounter("Creating "+conf.Name, uint64(wantCreate))
 	buf := bytes.NewBuffer(nil)
-	gen := newResourceGenerator(func(_ int) ([]byte, error) {
+	gen := newResourceGenerator(func(i int) ([]byte, error) {
+		err := pace.wait(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		// The resource is loaded once it is generated,
+		// so it is counted here.
+		defer progress.Add(1)
+
 		for {
-			name = generateSerialNumber(conf.Name, index, conf.SerialLength)
-			_, ok := has[name]
+			state.name = generateSerialNumber(conf.Name, state.index, conf.SerialLength)
+			_, ok := has[state.name]
 			if !ok {
 				break
 			}
-			index++
+			state.index++
 		}
 		// defer to increment index
 		defer func() {
-			index++
+			state.index++
 		}()
 
 		data, err := renderer.ToJSON(conf.Template, param)
@@ -268,12 +371,26 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 		labels[labelNameKey] = conf.Name
 		u.SetLabels(labels)
 		u.SetNamespace(namespace)
-		u.SetName(name)
+		u.SetName(state.name)
 
 		buf.Reset()
-		_, _ = buf.WriteString("---\n")
-		encoder := yaml.NewEncoder(buf)
-		err = encoder.Encode(u)
+		if len(conf.Children) == 0 {
+			err = encodeResource(buf, u)
+			if err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		}
+
+		// The uid is only used to link the children to the parent,
+		// the loader replaces it with the one assigned by the apiserver.
+		u.SetUID(uuid.NewUUID())
+		err = encodeResource(buf, u)
+		if err != nil {
+			return nil, err
+		}
+
+		err = encodeChildren(buf, renderer, state, conf.Children, childrenNamespaced, conf.SerialLength, param, u)
 		if err != nil {
 			return nil, err
 		}
@@ -300,9 +417,142 @@ func Scale(ctx context.Context, clientset client.Clientset, conf Config) error {
 }
 
 var (
-	labelNameKey = "kwok.x-k8s.io/kwokctl-scale"
+	labelNameKey   = "kwok.x-k8s.io/kwokctl-scale"
+	labelParentKey = "kwok.x-k8s.io/kwokctl-scale-parent"
 )
 
+// encodeResource encodes the resource as a YAML document,
+// the separator is at the end so that the decoder does not
+// need to read the next resource to finish the current one.
+func encodeResource(buf *bytes.Buffer, u *unstructured.Unstructured) error {
+	encoder := yaml.NewEncoder(buf)
+	err := encoder.Encode(u)
+	if err != nil {
+		return err
+	}
+	_, _ = buf.WriteString("---\n")
+	return nil
+}
+
+// renderState holds the values returned by the functions of the templates.
+type renderState struct {
+	name       string
+	namespace  string
+	index      int
+	childIndex int
+	parent     map[string]any
+}
+
+// newRenderer returns a renderer whose functions return the values of the state.
+func newRenderer(state *renderState) gotpl.Renderer {
+	return gotpl.NewRenderer(gotpl.FuncMap{
+		"Name": func() string {
+			return state.name
+		},
+		"Namespace": func() string {
+			return state.namespace
+		},
+		"Index": func() int {
+			return state.index
+		},
+		"Parent": func() map[string]any {
+			return state.parent
+		},
+		"ChildIndex": func() int {
+			return state.childIndex
+		},
+		"AddCIDR": utilsnet.AddCIDR,
+	})
+}
+
+// encodeChildren renders the children of the parent and encodes them as YAML documents.
+// A namespaced child without a namespace is created in the namespace of the scale,
+// or in the default namespace if the parent is cluster-scoped, and a cluster-scoped child has no namespace.
+func encodeChildren(buf *bytes.Buffer, renderer gotpl.Renderer, state *renderState, children []internalversion.KwokctlResourceChild, childrenNamespaced []bool, serialLength int, param any, parent *unstructured.Unstructured) error {
+	parentName := parent.GetName()
+	state.parent = parent.Object
+	defer func() {
+		state.name = parentName
+		state.parent = nil
+		state.childIndex = 0
+	}()
+	ownerReference := metav1.OwnerReference{
+		APIVersion: parent.GetAPIVersion(),
+		Kind:       parent.GetKind(),
+		Name:       parentName,
+		UID:        parent.GetUID(),
+	}
+	for i, child := range children {
+		for state.childIndex = 0; state.childIndex < child.Replicas; state.childIndex++ {
+			state.name = generateSerialNumber(parentName+"-"+child.Name, state.childIndex, serialLength)
+			data, err := renderer.ToJSON(child.Template, param)
+			if err != nil {
+				return err
+			}
+
+			var cu *unstructured.Unstructured
+			err = json.Unmarshal(data, &cu)
+			if err != nil {
+				return err
+			}
+
+			labels := cu.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels[labelParentKey] = parentName
+			cu.SetLabels(labels)
+			if !childrenNamespaced[i] {
+				cu.SetNamespace("")
+			} else if cu.GetNamespace() == "" {
+				namespace := state.namespace
+				if namespace == "" {
+					namespace = metav1.NamespaceDefault
+				}
+				cu.SetNamespace(namespace)
+			}
+			cu.SetName(state.name)
+			cu.SetOwnerReferences(append(cu.GetOwnerReferences(), ownerReference))
+
+			err = encodeResource(buf, cu)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteChildren deletes the children of the parent,
+// it does not rely on the garbage collector which may be disabled.
+// The children of a namespaced parent are only looked up in its namespace.
+func deleteChildren(ctx context.Context, childrenResource []dynamic.NamespaceableResourceInterface, parentNamespace, parentName string) {
+	logger := log.FromContext(ctx)
+	for _, nri := range childrenResource {
+		var lister dynamic.ResourceInterface = nri
+		if parentNamespace != "" {
+			lister = nri.Namespace(parentNamespace)
+		}
+		list, err := lister.List(ctx, metav1.ListOptions{
+			LabelSelector: labelParentKey + "=" + parentName,
+		})
+		if err != nil {
+			logger.Error("List children", err, "parent", parentName)
+			continue
+		}
+		for _, item := range list.Items {
+			var ri dynamic.ResourceInterface = nri
+			if ns := item.GetNamespace(); ns != "" {
+				ri = nri.Namespace(ns)
+			}
+			err = ri.Delete(ctx, item.GetName(), metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				logger.Error("Delete child resource", err, "parent", parentName)
+			}
+		}
+	}
+}
+
 type softInfo struct {
 	Name              string
 	CreationTimestamp metav1.Time
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+func Test_deleteChildren(t *testing.T) {
+	ctx := context.Background()
+	newPod := func(namespace, name, parent string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("Pod")
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		u.SetLabels(map[string]string{
+			labelParentKey: parent,
+		})
+		return u
+	}
+
+	podsGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(apiruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			podsGVR: "PodList",
+		},
+		newPod("default", "node-000000-pod-000000", "node-000000"),
+		newPod("default", "node-000000-pod-000001", "node-000000"),
+		newPod("default", "node-000001-pod-000000", "node-000001"),
+		newPod("other", "node-000000-pod-000000", "node-000000"),
+	)
+
+	// cluster-scoped parent, the children in all namespaces are deleted
+	deleteChildren(ctx, []dynamic.NamespaceableResourceInterface{client.Resource(podsGVR)}, "", "node-000000")
+
+	list, err := client.Resource(podsGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list pods: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].GetName() != "node-000001-pod-000000" {
+		t.Errorf("unexpected pods: %v", list.Items)
+	}
+
+	client = fake.NewSimpleDynamicClientWithCustomListKinds(apiruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			podsGVR: "PodList",
+		},
+		newPod("default", "parent-000000-pod-000000", "parent-000000"),
+		newPod("other", "parent-000000-pod-000000", "parent-000000"),
+	)
+
+	// namespaced parent, only the children in its namespace are deleted
+	deleteChildren(ctx, []dynamic.NamespaceableResourceInterface{client.Resource(podsGVR)}, "default", "parent-000000")
+
+	list, err = client.Resource(podsGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list pods: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].GetNamespace() != "other" {
+		t.Errorf("unexpected pods: %v", list.Items)
+	}
+}
+
+func Test_encodeChildren(t *testing.T) {
+	state := &renderState{
+		namespace: "default",
+	}
+	renderer := newRenderer(state)
+
+	parent := &unstructured.Unstructured{}
+	parent.SetAPIVersion("v1")
+	parent.SetKind("Node")
+	parent.SetName("node-000000")
+	parent.SetUID("uid-000000")
+
+	children := []internalversion.KwokctlResourceChild{
+		{
+			Name:     "pod",
+			Replicas: 2,
+			Template: `kind: Pod
+apiVersion: v1
+metadata:
+  name: {{ Name }}
+  annotations:
+    index: "{{ ChildIndex }}"
+spec:
+  nodeName: {{ (Parent).metadata.name }}`,
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := encodeChildren(buf, renderer, state, children, []bool{true}, 6, nil, parent)
+	if err != nil {
+		t.Fatalf("failed to encode children: %v", err)
+	}
+
+	decoder := yaml.NewDecoder(buf)
+	var got []*unstructured.Unstructured
+	err = decoder.DecodeToUnstructured(func(obj *unstructured.Unstructured) error {
+		got = append(got, obj)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to decode children: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(got))
+	}
+	for i, child := range got {
+		wantName := fmt.Sprintf("node-000000-pod-%06d", i)
+		if child.GetName() != wantName {
+			t.Errorf("expected name %q, got %q", wantName, child.GetName())
+		}
+		if child.GetNamespace() != "default" {
+			t.Errorf("expected namespace default, got %q", child.GetNamespace())
+		}
+		if got := child.GetAnnotations()["index"]; got != strconv.Itoa(i) {
+			t.Errorf("expected child index %d, got %q", i, got)
+		}
+		nodeName, _, _ := unstructured.NestedString(child.Object, "spec", "nodeName")
+		if nodeName != "node-000000" {
+			t.Errorf("expected node name from the parent, got %q", nodeName)
+		}
+		if got := child.GetLabels()[labelParentKey]; got != "node-000000" {
+			t.Errorf("expected parent label, got %q", got)
+		}
+		refs := child.GetOwnerReferences()
+		if len(refs) != 1 || refs[0].UID != "uid-000000" {
+			t.Errorf("unexpected owner references: %v", refs)
+		}
+	}
+
+	if state.parent != nil || state.childIndex != 0 || state.name != "node-000000" {
+		t.Errorf("expected the state to be restored, got %+v", state)
+	}
+}
+
+func Test_encodeChildrenNamespace(t *testing.T) {
+	parent := &unstructured.Unstructured{}
+	parent.SetAPIVersion("v1")
+	parent.SetKind("Node")
+	parent.SetName("node-000000")
+
+	children := []internalversion.KwokctlResourceChild{
+		{
+			Name:     "pod",
+			Replicas: 1,
+			Template: `kind: Pod
+apiVersion: v1`,
+		},
+		{
+			Name:     "other-pod",
+			Replicas: 1,
+			Template: `kind: Pod
+apiVersion: v1
+metadata:
+  namespace: other`,
+		},
+		{
+			Name:     "volume",
+			Replicas: 1,
+			Template: `kind: PersistentVolume
+apiVersion: v1
+metadata:
+  namespace: other`,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		want      []string
+	}{
+		{
+			name:      "cluster-scoped parent",
+			namespace: "",
+			want:      []string{"default", "other", ""},
+		},
+		{
+			name:      "scale namespace",
+			namespace: "scale",
+			want:      []string{"scale", "other", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &renderState{
+				namespace: tt.namespace,
+			}
+			renderer := newRenderer(state)
+
+			buf := bytes.NewBuffer(nil)
+			err := encodeChildren(buf, renderer, state, children, []bool{true, true, false}, 6, nil, parent)
+			if err != nil {
+				t.Fatalf("failed to encode children: %v", err)
+			}
+
+			var got []string
+			err = yaml.NewDecoder(buf).DecodeToUnstructured(func(obj *unstructured.Unstructured) error {
+				got = append(got, obj.GetNamespace())
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("failed to decode children: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected namespaces %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// StepStatus is the status of a step.
+type StepStatus string
+
+// StepStatus values.
+const (
+	StepStatusPassed  StepStatus = "passed"
+	StepStatusFailed  StepStatus = "failed"
+	StepStatusSkipped StepStatus = "skipped"
+)
+
+// Report is the report of a scenario run.
+type Report struct {
+	Name            string       `json:"name"`
+	StartTime       time.Time    `json:"startTime"`
+	DurationSeconds float64      `json:"durationSeconds"`
+	Steps           []StepReport `json:"steps"`
+}
+
+// StepReport is the report of a step.
+type StepReport struct {
+	Name            string     `json:"name"`
+	Action          string     `json:"action,omitempty"`
+	Status          StepStatus `json:"status"`
+	DurationSeconds float64    `json:"durationSeconds"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// Failed returns true if any step failed.
+func (r *Report) Failed() bool {
+	for _, step := range r.Steps {
+		if step.Status == StepStatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteJSON writes the reports as JSON.
+func WriteJSON(w io.Writer, reports []*Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(reports)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// WriteJUnit writes the reports as JUnit XML, each report is a test suite.
+func WriteJUnit(w io.Writer, reports []*Report) error {
+	suites := junitTestSuites{
+		Suites: make([]junitTestSuite, 0, len(reports)),
+	}
+	for _, r := range reports {
+		suites.Suites = append(suites.Suites, r.junitTestSuite())
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	err = encoder.Encode(suites)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func (r *Report) junitTestSuite() junitTestSuite {
+	suite := junitTestSuite{
+		Name:      r.Name,
+		Tests:     len(r.Steps),
+		Time:      formatSeconds(r.DurationSeconds),
+		Timestamp: r.StartTime.UTC().Format(time.RFC3339),
+		Cases:     make([]junitTestCase, 0, len(r.Steps)),
+	}
+	for _, step := range r.Steps {
+		c := junitTestCase{
+			Name:      step.Name,
+			Classname: r.Name,
+			Time:      formatSeconds(step.DurationSeconds),
+		}
+		switch step.Status {
+		case StepStatusFailed:
+			suite.Failures++
+			c.Failure = &junitFailure{
+				Message: step.Error,
+				Text:    step.Error,
+			}
+		case StepStatusSkipped:
+			suite.Skipped++
+			c.Skipped = &junitSkipped{
+				Message: "previous step failed",
+			}
+		}
+		suite.Cases = append(suite.Cases, c)
+	}
+	return suite
+}
+
+func formatSeconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/pager"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/dryrun"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/kwokctl/scale"
+	"sigs.k8s.io/kwok/pkg/kwokctl/snapshot"
+	"sigs.k8s.io/kwok/pkg/log"
+	"sigs.k8s.io/kwok/pkg/utils/client"
+	"sigs.k8s.io/kwok/pkg/utils/exec"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+)
+
+// Config is the configuration for running a scenario.
+type Config struct {
+	// Runtime is the runtime of the cluster.
+	Runtime runtime.Runtime
+	// Resources is the KwokctlResources available for the scale steps.
+	Resources []*internalversion.KwokctlResource
+}
+
+// Run runs the steps of the scenario in order and returns the report.
+// The steps after a failed step are skipped.
+func Run(ctx context.Context, scenario *internalversion.Scenario, conf Config) *Report {
+	logger := log.FromContext(ctx)
+	logger = logger.With("scenario", scenario.Name)
+
+	report := &Report{
+		Name:      scenario.Name,
+		StartTime: time.Now(),
+		Steps:     make([]StepReport, 0, len(scenario.Steps)),
+	}
+	failed := false
+	for i, step := range scenario.Steps {
+		name := step.Name
+		if name == "" {
+			name = fmt.Sprintf("step-%d", i)
+		}
+		action, err := stepAction(step)
+		if err != nil {
+			failed = true
+			report.Steps = append(report.Steps, StepReport{
+				Name:   name,
+				Status: StepStatusFailed,
+				Error:  err.Error(),
+			})
+			continue
+		}
+
+		if failed {
+			report.Steps = append(report.Steps, StepReport{
+				Name:   name,
+				Action: action,
+				Status: StepStatusSkipped,
+			})
+			continue
+		}
+
+		stepLogger := logger.With("step", name, "action", action)
+		stepLogger.Info("Run step")
+		start := time.Now()
+		err = runStep(log.NewContext(ctx, stepLogger), step, conf)
+		stepReport := StepReport{
+			Name:            name,
+			Action:          action,
+			Status:          StepStatusPassed,
+			DurationSeconds: time.Since(start).Seconds(),
+		}
+		if err != nil {
+			stepLogger.Error("Failed to run step", err)
+			failed = true
+			stepReport.Status = StepStatusFailed
+			stepReport.Error = err.Error()
+		}
+		report.Steps = append(report.Steps, stepReport)
+	}
+	report.DurationSeconds = time.Since(report.StartTime).Seconds()
+	return report
+}
+
+func stepAction(step internalversion.ScenarioStep) (string, error) {
+	actions := []string{}
+	if step.Scale != nil {
+		actions = append(actions, "scale")
+	}
+	if step.Apply != nil {
+		actions = append(actions, "apply")
+	}
+	if step.Wait != nil {
+		actions = append(actions, "wait")
+	}
+	if step.Assert != nil {
+		actions = append(actions, "assert")
+	}
+	if step.Snapshot != nil {
+		actions = append(actions, "snapshot")
+	}
+	if len(actions) != 1 {
+		return "", fmt.Errorf("step must have exactly one action, but got %d: %v", len(actions), actions)
+	}
+	return actions[0], nil
+}
+
+func runStep(ctx context.Context, step internalversion.ScenarioStep, conf Config) error {
+	switch {
+	case step.Scale != nil:
+		return runScale(ctx, step.Scale, conf)
+	case step.Apply != nil:
+		return runApply(ctx, step.Apply, conf)
+	case step.Wait != nil:
+		return runWait(ctx, step.Wait, conf)
+	case step.Assert != nil:
+		return runAssert(ctx, step.Assert, conf)
+	case step.Snapshot != nil:
+		return runSnapshot(ctx, step.Snapshot, conf)
+	}
+	return nil
+}
+
+func clientsetFor(rt runtime.Runtime) (client.Clientset, error) {
+	kubeconfigPath := rt.GetWorkdirPath(runtime.InHostKubeconfigName)
+	return client.NewClientset("", kubeconfigPath)
+}
+
+func runScale(ctx context.Context, s *internalversion.ScenarioScale, conf Config) error {
+	krc, err := scale.GetResource(ctx, conf.Resources, s.Resource)
+	if err != nil {
+		return err
+	}
+
+	parameters, err := expression.NewParameters(ctx, krc.Parameters, s.Params)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := clientsetFor(conf.Runtime)
+	if err != nil {
+		return err
+	}
+
+	name := s.Name
+	if name == "" {
+		name = s.Resource
+	}
+	return scale.Scale(ctx, clientset, scale.Config{
+		Parameters:   parameters,
+		Template:     krc.Template,
+		Children:     krc.Children,
+		Name:         name,
+		Namespace:    s.Namespace,
+		Replicas:     int(s.Replicas),
+		SerialLength: s.SerialLength,
+		DryRun:       dryrun.DryRun,
+	})
+}
+
+func runApply(ctx context.Context, a *internalversion.ScenarioApply, conf Config) error {
+	switch {
+	case a.Path != "" && a.Manifests != "":
+		return fmt.Errorf("only one of path and manifests can be set")
+	case a.Path != "":
+		return conf.Runtime.KubectlInCluster(ctx, "apply", "-f", a.Path)
+	case a.Manifests != "":
+		ctx = exec.WithReadFrom(ctx, strings.NewReader(a.Manifests))
+		return conf.Runtime.KubectlInCluster(ctx, "apply", "-f", "-")
+	}
+	return fmt.Errorf("path or manifests is required")
+}
+
+func runWait(ctx context.Context, w *internalversion.ScenarioWait, conf Config) error {
+	timeout := time.Duration(w.TimeoutMilliseconds) * time.Millisecond
+	if w.Resource == "" {
+		if dryrun.DryRun {
+			dryrun.PrintMessage("# Wait for %s", timeout)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(timeout):
+			return nil
+		}
+	}
+
+	if w.For == "" {
+		return fmt.Errorf("for is required when resource is set")
+	}
+	args := []string{"wait", w.Resource, "--for", w.For}
+	if w.Namespace != "" {
+		args = append(args, "--namespace", w.Namespace)
+	} else {
+		args = append(args, "--all-namespaces")
+	}
+	if w.Selector != "" {
+		args = append(args, "--selector", w.Selector)
+	} else {
+		args = append(args, "--all")
+	}
+	if timeout > 0 {
+		args = append(args, "--timeout", timeout.String())
+	}
+	return conf.Runtime.KubectlInCluster(ctx, args...)
+}
+
+func runAssert(ctx context.Context, a *internalversion.ScenarioAssert, conf Config) error {
+	if dryrun.DryRun {
+		dryrun.PrintMessage("# Assert the count of %s is %d", a.Resource, a.Count)
+		return nil
+	}
+
+	clientset, err := clientsetFor(conf.Runtime)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := clientset.ToDynamicClient()
+	if err != nil {
+		return err
+	}
+	restMapper, err := clientset.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	gr := schema.ParseGroupResource(a.Resource)
+	gvr, err := restMapper.ResourceFor(gr.WithVersion(""))
+	if err != nil {
+		return err
+	}
+
+	var ri dynamic.ResourceInterface = dynamicClient.Resource(gvr)
+	if a.Namespace != "" {
+		ri = dynamicClient.Resource(gvr).Namespace(a.Namespace)
+	}
+
+	listPager := pager.New(func(ctx context.Context, opts metav1.ListOptions) (apiruntime.Object, error) {
+		return ri.List(ctx, opts)
+	})
+	count := 0
+	err = listPager.EachListItem(ctx, metav1.ListOptions{
+		LabelSelector: a.Selector,
+		FieldSelector: a.FieldSelector,
+	}, func(_ apiruntime.Object) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if count != a.Count {
+		return fmt.Errorf("expected %d %s, but got %d", a.Count, a.Resource, count)
+	}
+	return nil
+}
+
+func runSnapshot(ctx context.Context, s *internalversion.ScenarioSnapshot, conf Config) error {
+	if s.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	switch s.Format {
+	case "etcd":
+		return conf.Runtime.SnapshotSave(ctx, s.Path)
+	case "k8s":
+		filters := s.Filters
+		if len(filters) == 0 {
+			filters = snapshot.Resources
+		}
+		return conf.Runtime.SnapshotSaveWithYAML(ctx, s.Path, runtime.SnapshotSaveWithYAMLConfig{
+			Filters: filters,
+		})
+	}
+	return fmt.Errorf("unsupport format %q", s.Format)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestRun(t *testing.T) {
+	report := Run(context.Background(), &internalversion.Scenario{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Steps: []internalversion.ScenarioStep{
+			{
+				Name: "wait",
+				Wait: &internalversion.ScenarioWait{},
+			},
+			{
+				Name: "invalid",
+			},
+			{
+				Wait: &internalversion.ScenarioWait{},
+			},
+		},
+	}, Config{})
+
+	want := []StepReport{
+		{Name: "wait", Action: "wait", Status: StepStatusPassed},
+		{Name: "invalid", Status: StepStatusFailed},
+		{Name: "step-2", Action: "wait", Status: StepStatusSkipped},
+	}
+	if len(report.Steps) != len(want) {
+		t.Fatalf("expected %d steps, got %d", len(want), len(report.Steps))
+	}
+	for i, step := range report.Steps {
+		if step.Name != want[i].Name || step.Action != want[i].Action || step.Status != want[i].Status {
+			t.Errorf("unexpected step %d: %+v", i, step)
+		}
+	}
+	if !report.Failed() {
+		t.Errorf("expected the report to be failed")
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	reports := []*Report{
+		{
+			Name: "test",
+			Steps: []StepReport{
+				{Name: "scale", Action: "scale", Status: StepStatusPassed},
+				{Name: "assert", Action: "assert", Status: StepStatusFailed, Error: "expected 1 pods, but got 0"},
+				{Name: "snapshot", Action: "snapshot", Status: StepStatusSkipped},
+			},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := WriteJUnit(buf, reports)
+	if err != nil {
+		t.Fatalf("failed to write junit: %v", err)
+	}
+
+	var got junitTestSuites
+	err = xml.Unmarshal(buf.Bytes(), &got)
+	if err != nil {
+		t.Fatalf("failed to unmarshal junit: %v", err)
+	}
+	if len(got.Suites) != 1 {
+		t.Fatalf("expected 1 suite, got %d", len(got.Suites))
+	}
+	suite := got.Suites[0]
+	if suite.Tests != 3 || suite.Failures != 1 || suite.Skipped != 1 {
+		t.Errorf("unexpected suite: %+v", suite)
+	}
+	if suite.Cases[1].Failure == nil || suite.Cases[1].Failure.Message != "expected 1 pods, but got 0" {
+		t.Errorf("unexpected failure: %+v", suite.Cases[1].Failure)
+	}
+	if suite.Cases[2].Skipped == nil {
+		t.Errorf("expected the case to be skipped")
+	}
+}
@@ -0,0 +1,396 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	"sigs.k8s.io/kwok/pkg/apis/action/v1alpha1"
+	"sigs.k8s.io/kwok/pkg/kwokctl/etcd"
+	"sigs.k8s.io/kwok/pkg/kwokctl/recording"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// DiffObject is an object that differs between two snapshots.
+type DiffObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// PatchType is the type of the patch, only set for changed objects.
+	PatchType types.PatchType `json:"patchType,omitempty"`
+	// Patch is the patch from the old object to the new object, only set for changed objects.
+	Patch json.RawMessage `json:"patch,omitempty"`
+
+	object *unstructured.Unstructured
+}
+
+// DiffResult is the result of comparing two snapshots.
+type DiffResult struct {
+	Added   []DiffObject `json:"added"`
+	Removed []DiffObject `json:"removed"`
+	Changed []DiffObject `json:"changed"`
+}
+
+// Empty returns true if there is no difference.
+func (r *DiffResult) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// diffKey is the key to match objects between snapshots,
+// the uid is ignored because it is different between clusters.
+type diffKey struct {
+	uniqueKey
+	Namespace string
+}
+
+func diffKeyFromMetadata(obj *unstructured.Unstructured) diffKey {
+	key := uniqueKeyFromMetadata(obj)
+	key.UID = ""
+	return diffKey{
+		uniqueKey: key,
+		Namespace: obj.GetNamespace(),
+	}
+}
+
+// LoadObjects loads the objects of a k8s format snapshot,
+// the resource patches of a recording are ignored.
+func LoadObjects(decoder *yaml.Decoder) ([]*unstructured.Unstructured, error) {
+	objs := []*unstructured.Unstructured{}
+	for {
+		obj, err := decoder.DecodeUnstructured()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if obj.GetKind() == recording.ResourcePatchType.Kind && obj.GetAPIVersion() == recording.ResourcePatchType.APIVersion {
+			break
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// Diff compares the objects of two snapshots.
+func Diff(oldObjs, newObjs []*unstructured.Unstructured) (*DiffResult, error) {
+	olds := make(map[diffKey]*unstructured.Unstructured, len(oldObjs))
+	for _, obj := range oldObjs {
+		olds[diffKeyFromMetadata(obj)] = obj
+	}
+
+	result := &DiffResult{
+		Added:   []DiffObject{},
+		Removed: []DiffObject{},
+		Changed: []DiffObject{},
+	}
+	news := make(map[diffKey]struct{}, len(newObjs))
+	for _, newObj := range newObjs {
+		key := diffKeyFromMetadata(newObj)
+		news[key] = struct{}{}
+		oldObj, ok := olds[key]
+		if !ok {
+			result.Added = append(result.Added, newDiffObject(key, newObj))
+			continue
+		}
+
+		patchType, patch, err := diffObject(oldObj, newObj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s %s/%s: %w", key.Kind, key.Namespace, key.Name, err)
+		}
+		if patch == nil {
+			continue
+		}
+		o := newDiffObject(key, newObj)
+		o.PatchType = patchType
+		o.Patch = patch
+		result.Changed = append(result.Changed, o)
+	}
+
+	for key, oldObj := range olds {
+		if _, ok := news[key]; !ok {
+			result.Removed = append(result.Removed, newDiffObject(key, oldObj))
+		}
+	}
+
+	sortDiffObjects(result.Added)
+	sortDiffObjects(result.Removed)
+	sortDiffObjects(result.Changed)
+	return result, nil
+}
+
+func newDiffObject(key diffKey, obj *unstructured.Unstructured) DiffObject {
+	return DiffObject{
+		APIVersion: key.APIVersion,
+		Kind:       key.Kind,
+		Namespace:  key.Namespace,
+		Name:       key.Name,
+		object:     obj,
+	}
+}
+
+func sortDiffObjects(objs []DiffObject) {
+	sort.Slice(objs, func(i, j int) bool {
+		a, b := objs[i], objs[j]
+		if a.APIVersion != b.APIVersion {
+			return a.APIVersion < b.APIVersion
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+// diffObject returns the patch from the old object to the new object,
+// the patch is nil if there is no difference.
+func diffObject(oldObj, newObj *unstructured.Unstructured) (types.PatchType, []byte, error) {
+	original, err := json.Marshal(normalizeObject(oldObj))
+	if err != nil {
+		return "", nil, err
+	}
+	modified, err := json.Marshal(normalizeObject(newObj))
+	if err != nil {
+		return "", nil, err
+	}
+
+	var patchType types.PatchType
+	var patch []byte
+	patchMeta, err := etcd.PatchMetaFromStruct(newObj.GroupVersionKind())
+	if err == nil {
+		patchType = types.StrategicMergePatchType
+		patch, err = strategicpatch.CreateTwoWayMergePatchUsingLookupPatchMeta(original, modified, patchMeta)
+	} else {
+		// Unknown types such as custom resources do not have patch meta
+		patchType = types.MergePatchType
+		patch, err = jsonpatch.CreateMergePatch(original, modified)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if string(patch) == "{}" {
+		return "", nil, nil
+	}
+	return patchType, patch, nil
+}
+
+var (
+	volatileMetadataFields = []string{
+		"uid",
+		"resourceVersion",
+		"generation",
+		"managedFields",
+		"creationTimestamp",
+		"deletionTimestamp",
+		"selfLink",
+	}
+	volatileConditionFields = []string{
+		"lastHeartbeatTime",
+		"lastProbeTime",
+		"lastTransitionTime",
+		"lastUpdateTime",
+	}
+	volatileStatusFields = []string{
+		"startTime",
+	}
+	containerStatusesFields = []string{
+		"containerStatuses",
+		"initContainerStatuses",
+		"ephemeralContainerStatuses",
+	}
+	// volatileContainerStateFields are the times in the state and lastState of a container status
+	volatileContainerStateFields = [][]string{
+		{"running", "startedAt"},
+		{"terminated", "startedAt"},
+		{"terminated", "finishedAt"},
+	}
+)
+
+// normalizeObject returns a copy of the object without the volatile fields
+func normalizeObject(obj *unstructured.Unstructured) map[string]any {
+	out := obj.DeepCopy().Object
+	for _, field := range volatileMetadataFields {
+		unstructured.RemoveNestedField(out, "metadata", field)
+	}
+
+	ownerReferences, ok, _ := unstructured.NestedSlice(out, "metadata", "ownerReferences")
+	if ok {
+		for _, ref := range ownerReferences {
+			if m, ok := ref.(map[string]any); ok {
+				delete(m, "uid")
+			}
+		}
+		_ = unstructured.SetNestedSlice(out, ownerReferences, "metadata", "ownerReferences")
+	}
+
+	conditions, ok, _ := unstructured.NestedSlice(out, "status", "conditions")
+	if ok {
+		for _, condition := range conditions {
+			if m, ok := condition.(map[string]any); ok {
+				for _, field := range volatileConditionFields {
+					delete(m, field)
+				}
+			}
+		}
+		_ = unstructured.SetNestedSlice(out, conditions, "status", "conditions")
+	}
+
+	for _, field := range volatileStatusFields {
+		unstructured.RemoveNestedField(out, "status", field)
+	}
+
+	for _, field := range containerStatusesFields {
+		statuses, ok, _ := unstructured.NestedSlice(out, "status", field)
+		if !ok {
+			continue
+		}
+		for _, status := range statuses {
+			m, ok := status.(map[string]any)
+			if !ok {
+				continue
+			}
+			for _, state := range []string{"state", "lastState"} {
+				for _, fields := range volatileContainerStateFields {
+					unstructured.RemoveNestedField(m, append([]string{state}, fields...)...)
+				}
+			}
+		}
+		_ = unstructured.SetNestedSlice(out, statuses, "status", field)
+	}
+	return out
+}
+
+// WriteDiffText writes the result as human-readable text.
+func WriteDiffText(w io.Writer, result *DiffResult) error {
+	for _, obj := range result.Added {
+		_, err := fmt.Fprintf(w, "+ %s\n", formatDiffObject(obj))
+		if err != nil {
+			return err
+		}
+	}
+	for _, obj := range result.Removed {
+		_, err := fmt.Fprintf(w, "- %s\n", formatDiffObject(obj))
+		if err != nil {
+			return err
+		}
+	}
+	for _, obj := range result.Changed {
+		_, err := fmt.Fprintf(w, "~ %s\n", formatDiffObject(obj))
+		if err != nil {
+			return err
+		}
+		var patch any
+		err = json.Unmarshal(obj.Patch, &patch)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(patch)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(indent(data, "    "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatDiffObject(obj DiffObject) string {
+	name := obj.Name
+	if obj.Namespace != "" {
+		name = obj.Namespace + "/" + obj.Name
+	}
+	return fmt.Sprintf("%s/%s %s", obj.APIVersion, obj.Kind, name)
+}
+
+func indent(data []byte, prefix string) []byte {
+	out := make([]byte, 0, len(data))
+	lineStart := true
+	for _, b := range data {
+		if lineStart && b != '\n' {
+			out = append(out, prefix...)
+		}
+		out = append(out, b)
+		lineStart = b == '\n'
+	}
+	return out
+}
+
+// WriteDiffJSON writes the result as JSON.
+func WriteDiffJSON(w io.Writer, result *DiffResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// WriteDiffPatch writes the result as resource patches,
+// which can be replayed on a cluster restored from the old snapshot.
+func WriteDiffPatch(w io.Writer, result *DiffResult) error {
+	encoder := yaml.NewEncoder(w)
+	write := func(obj DiffObject, method v1alpha1.PatchMethod, template []byte) error {
+		gvr, _ := meta.UnsafeGuessKindToResource(obj.object.GroupVersionKind())
+		rp := &recording.ResourcePatch{
+			TypeMeta: recording.ResourcePatchType,
+			Method:   method,
+			Template: template,
+		}
+		rp.SetTargetGroupVersionResource(gvr)
+		rp.SetTargetName(obj.Name, obj.Namespace)
+		return encoder.Encode(rp)
+	}
+
+	for _, obj := range result.Added {
+		o := obj.object.DeepCopy()
+		o.SetResourceVersion("")
+		template, err := json.Marshal(o)
+		if err != nil {
+			return err
+		}
+		err = write(obj, v1alpha1.PatchMethodCreate, template)
+		if err != nil {
+			return err
+		}
+	}
+	for _, obj := range result.Removed {
+		err := write(obj, v1alpha1.PatchMethodDelete, nil)
+		if err != nil {
+			return err
+		}
+	}
+	for _, obj := range result.Changed {
+		err := write(obj, v1alpha1.PatchMethodPatch, obj.Patch)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+func TestDiff(t *testing.T) {
+	oldObjs, err := LoadObjects(yaml.NewDecoder(strings.NewReader(`
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-0
+  uid: 9a8f8e2c-0000-0000-0000-000000000000
+  resourceVersion: "1"
+  creationTimestamp: "2024-01-01T00:00:00Z"
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+    lastHeartbeatTime: "2024-01-01T00:00:00Z"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-0
+  namespace: default
+spec:
+  containers:
+  - name: container-0
+    image: busybox
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-1
+  namespace: default
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-2
+  namespace: default
+status:
+  startTime: "2024-01-01T00:00:00Z"
+  conditions:
+  - type: Ready
+    status: "True"
+    lastTransitionTime: "2024-01-01T00:00:00Z"
+  containerStatuses:
+  - name: container-0
+    ready: true
+    state:
+      running:
+        startedAt: "2024-01-01T00:00:00Z"
+    lastState:
+      terminated:
+        exitCode: 0
+        startedAt: "2024-01-01T00:00:00Z"
+        finishedAt: "2024-01-01T00:00:00Z"
+`)))
+	if err != nil {
+		t.Fatalf("failed to load old objects: %v", err)
+	}
+	newObjs, err := LoadObjects(yaml.NewDecoder(strings.NewReader(`
+apiVersion: v1
+kind: Node
+metadata:
+  name: node-0
+  uid: 1b2c3d4e-0000-0000-0000-000000000000
+  resourceVersion: "100"
+  creationTimestamp: "2024-02-01T00:00:00Z"
+status:
+  conditions:
+  - type: Ready
+    status: "True"
+    lastHeartbeatTime: "2024-02-01T00:00:00Z"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-0
+  namespace: default
+spec:
+  containers:
+  - name: container-0
+    image: nginx
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-1
+  namespace: other
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod-2
+  namespace: default
+status:
+  startTime: "2024-02-01T00:00:00Z"
+  conditions:
+  - type: Ready
+    status: "True"
+    lastTransitionTime: "2024-02-01T00:00:00Z"
+  containerStatuses:
+  - name: container-0
+    ready: true
+    state:
+      running:
+        startedAt: "2024-02-01T00:00:00Z"
+    lastState:
+      terminated:
+        exitCode: 0
+        startedAt: "2024-02-01T00:00:00Z"
+        finishedAt: "2024-02-01T00:00:00Z"
+---
+apiVersion: action.kwok.x-k8s.io/v1alpha1
+kind: ResourcePatch
+`)))
+	if err != nil {
+		t.Fatalf("failed to load new objects: %v", err)
+	}
+
+	result, err := Diff(oldObjs, newObjs)
+	if err != nil {
+		t.Fatalf("failed to diff: %v", err)
+	}
+
+	if len(result.Added) != 1 || result.Added[0].Namespace != "other" {
+		t.Errorf("unexpected added: %+v", result.Added)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].Namespace != "default" || result.Removed[0].Name != "pod-1" {
+		t.Errorf("unexpected removed: %+v", result.Removed)
+	}
+	if len(result.Changed) != 1 || result.Changed[0].Name != "pod-0" {
+		t.Fatalf("unexpected changed: %+v", result.Changed)
+	}
+	changed := result.Changed[0]
+	if changed.PatchType != types.StrategicMergePatchType {
+		t.Errorf("unexpected patch type: %s", changed.PatchType)
+	}
+	want := `{"spec":{"$setElementOrder/containers":[{"name":"container-0"}],"containers":[{"image":"nginx","name":"container-0"}]}}`
+	if string(changed.Patch) != want {
+		t.Errorf("unexpected patch: %s", changed.Patch)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supervisor
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+// ProbeMetric checks the health of the component by its metric endpoint,
+// which is only reachable from the host for the binary runtime.
+// The components without a metric endpoint are healthy.
+func ProbeMetric(ctx context.Context, component internalversion.Component) error {
+	metric := component.Metric
+	if metric == nil || metric.Host == "" {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{
+		//nolint:gosec
+		InsecureSkipVerify: metric.InsecureSkipVerify,
+	}
+	if metric.CertPath != "" && metric.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(metric.CertPath, metric.KeyPath)
+		if err != nil {
+			return err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	cli := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	defer cli.CloseIdleConnections()
+
+	scheme := metric.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+metric.Host+metric.Path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, req.URL)
+	}
+	return nil
+}
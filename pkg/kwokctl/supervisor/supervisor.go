@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// Target is the cluster whose components are supervised.
+type Target interface {
+	// Load the config from disk
+	Load(ctx context.Context) (*internalversion.KwokctlConfiguration, error)
+	// SetConfig set the cluster config
+	SetConfig(ctx context.Context, conf *internalversion.KwokctlConfiguration) error
+	// Save the config to disk
+	Save(ctx context.Context) error
+	// InspectComponent returns the status of the component
+	InspectComponent(ctx context.Context, name string) (runtime.ComponentStatus, error)
+	// StartComponent start cluster component
+	StartComponent(ctx context.Context, name string) error
+	// StopComponent stop cluster component
+	StopComponent(ctx context.Context, name string) error
+}
+
+// Config is the configuration of the supervisor.
+type Config struct {
+	// Interval is the interval between the checks.
+	Interval time.Duration
+	// FailureThreshold is the number of failed checks in a row before the component is restarted.
+	FailureThreshold int
+	// InitialBackoff is the least time between two restarts of a component.
+	InitialBackoff time.Duration
+	// MaxBackoff is the most time between two restarts of a component,
+	// the backoff is reset after the component is healthy for that long.
+	MaxBackoff time.Duration
+	// Probe checks the health of a running component, it is skipped if nil.
+	Probe func(ctx context.Context, component internalversion.Component) error
+}
+
+// Supervisor restarts the failed components with backoff,
+// and records the restarts in the status of the cluster.
+type Supervisor struct {
+	target Target
+	conf   Config
+	states map[string]*state
+	now    func() time.Time
+}
+
+type state struct {
+	failures     int
+	backoff      time.Duration
+	nextRestart  time.Time
+	healthySince time.Time
+}
+
+// NewSupervisor returns a new Supervisor for the target.
+func NewSupervisor(target Target, conf Config) *Supervisor {
+	if conf.Interval <= 0 {
+		conf.Interval = 5 * time.Second
+	}
+	if conf.FailureThreshold <= 0 {
+		conf.FailureThreshold = 1
+	}
+	if conf.InitialBackoff <= 0 {
+		conf.InitialBackoff = time.Second
+	}
+	if conf.MaxBackoff < conf.InitialBackoff {
+		conf.MaxBackoff = conf.InitialBackoff
+	}
+	return &Supervisor{
+		target: target,
+		conf:   conf,
+		states: map[string]*state{},
+		now:    time.Now,
+	}
+}
+
+// Run checks the components at every interval until the context is done.
+func (s *Supervisor) Run(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+	ticker := time.NewTicker(s.conf.Interval)
+	defer ticker.Stop()
+	for {
+		err := s.check(ctx)
+		if err != nil {
+			logger.Error("Failed to check components", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Supervisor) check(ctx context.Context) error {
+	// The cluster may be changed by other kwokctl commands while it is supervised
+	conf, err := s.target.Load(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.target.SetConfig(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	for _, component := range conf.Components {
+		err = s.checkComponent(ctx, component)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Supervisor) checkComponent(ctx context.Context, component internalversion.Component) error {
+	logger := log.FromContext(ctx)
+	logger = logger.With("component", component.Name)
+
+	st, ok := s.states[component.Name]
+	if !ok {
+		st = &state{}
+		s.states[component.Name] = st
+	}
+
+	now := s.now()
+	reason, stopped := s.inspect(ctx, component)
+	if stopped {
+		// The component is stopped on purpose, e.g. by kwokctl stop component
+		logger.Debug("Component is stopped")
+		delete(s.states, component.Name)
+		return nil
+	}
+	if reason == "" {
+		st.failures = 0
+		if st.healthySince.IsZero() {
+			st.healthySince = now
+		} else if st.backoff != 0 && now.Sub(st.healthySince) >= s.conf.MaxBackoff {
+			st.backoff = 0
+		}
+		return nil
+	}
+
+	st.healthySince = time.Time{}
+	st.failures++
+	if st.failures < s.conf.FailureThreshold {
+		logger.Debug("Component is failing", "reason", reason, "failures", st.failures)
+		return nil
+	}
+	if now.Before(st.nextRestart) {
+		logger.Debug("Component is backing off", "reason", reason, "until", st.nextRestart)
+		return nil
+	}
+
+	logger.Warn("Component is restarting", "reason", reason)
+	err := s.target.StopComponent(ctx, component.Name)
+	if err != nil {
+		logger.Warn("Failed to stop component", "err", err)
+	}
+	err = s.target.StartComponent(ctx, component.Name)
+	if err != nil {
+		logger.Error("Failed to start component", err)
+	}
+
+	st.failures = 0
+	if st.backoff == 0 {
+		st.backoff = s.conf.InitialBackoff
+	} else {
+		st.backoff = min(2*st.backoff, s.conf.MaxBackoff)
+	}
+	st.nextRestart = now.Add(st.backoff)
+
+	return s.record(ctx, component.Name, reason, now)
+}
+
+// inspect returns the reason why the component is failed, or empty if it is healthy,
+// the stopped components are not failed, they are left as they are.
+func (s *Supervisor) inspect(ctx context.Context, component internalversion.Component) (reason string, stopped bool) {
+	status, err := s.target.InspectComponent(ctx, component.Name)
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err), false
+	}
+	switch status {
+	case runtime.ComponentStatusReady:
+	case runtime.ComponentStatusStopped:
+		return "", true
+	default:
+		return status.String(), false
+	}
+
+	if s.conf.Probe != nil {
+		err = s.conf.Probe(ctx, component)
+		if err != nil {
+			return fmt.Sprintf("Unhealthy: %v", err), false
+		}
+	}
+	return "", false
+}
+
+// record writes the restart into the status of the cluster,
+// the config is loaded again to keep the changes saved since the check.
+func (s *Supervisor) record(ctx context.Context, name, reason string, now time.Time) error {
+	conf, err := s.target.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	restart := internalversion.ComponentRestart{
+		Name: name,
+	}
+	index := -1
+	for i, r := range conf.Status.Restarts {
+		if r.Name == name {
+			restart = r
+			index = i
+			break
+		}
+	}
+	restart.Count++
+	restart.Reason = reason
+	restart.LastRestartTime = metav1.NewTime(now)
+	if index == -1 {
+		conf.Status.Restarts = append(conf.Status.Restarts, restart)
+	} else {
+		conf.Status.Restarts[index] = restart
+	}
+
+	err = s.target.SetConfig(ctx, conf)
+	if err != nil {
+		return err
+	}
+	return s.target.Save(ctx)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/kwokctl/runtime"
+)
+
+type fakeTarget struct {
+	// disk is the config saved on disk
+	disk     *internalversion.KwokctlConfiguration
+	conf     *internalversion.KwokctlConfiguration
+	status   map[string]runtime.ComponentStatus
+	restarts []string
+	saved    int
+}
+
+func (f *fakeTarget) Load(ctx context.Context) (*internalversion.KwokctlConfiguration, error) {
+	return f.disk.DeepCopy(), nil
+}
+
+func (f *fakeTarget) SetConfig(ctx context.Context, conf *internalversion.KwokctlConfiguration) error {
+	f.conf = conf.DeepCopy()
+	return nil
+}
+
+func (f *fakeTarget) Save(ctx context.Context) error {
+	f.disk = f.conf.DeepCopy()
+	f.saved++
+	return nil
+}
+
+func (f *fakeTarget) InspectComponent(ctx context.Context, name string) (runtime.ComponentStatus, error) {
+	return f.status[name], nil
+}
+
+func (f *fakeTarget) StartComponent(ctx context.Context, name string) error {
+	f.restarts = append(f.restarts, name)
+	return nil
+}
+
+func (f *fakeTarget) StopComponent(ctx context.Context, name string) error {
+	return nil
+}
+
+func TestSupervisor(t *testing.T) {
+	target := &fakeTarget{
+		disk: &internalversion.KwokctlConfiguration{
+			Components: []internalversion.Component{
+				{Name: "kube-scheduler"},
+				{Name: "kwok-controller"},
+			},
+		},
+		status: map[string]runtime.ComponentStatus{
+			"kube-scheduler":  runtime.ComponentStatusCrashed,
+			"kwok-controller": runtime.ComponentStatusReady,
+		},
+	}
+
+	unhealthy := false
+	s := NewSupervisor(target, Config{
+		FailureThreshold: 2,
+		InitialBackoff:   10 * time.Second,
+		MaxBackoff:       40 * time.Second,
+		Probe: func(ctx context.Context, component internalversion.Component) error {
+			if component.Name == "kwok-controller" && unhealthy {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	})
+	now := time.Unix(0, 0)
+	s.now = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	step := func(d time.Duration) {
+		t.Helper()
+		now = now.Add(d)
+		err := s.check(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The first failure is under the threshold
+	step(0)
+	if len(target.restarts) != 0 {
+		t.Fatalf("expected no restart, got %v", target.restarts)
+	}
+
+	step(time.Second)
+	if len(target.restarts) != 1 {
+		t.Fatalf("expected 1 restart, got %v", target.restarts)
+	}
+
+	// Backing off for 10s
+	step(time.Second)
+	step(time.Second)
+	if len(target.restarts) != 1 {
+		t.Fatalf("expected 1 restart while backing off, got %v", target.restarts)
+	}
+
+	step(10 * time.Second)
+	if len(target.restarts) != 2 {
+		t.Fatalf("expected 2 restarts, got %v", target.restarts)
+	}
+
+	// The backoff is doubled to 20s
+	step(10 * time.Second)
+	if len(target.restarts) != 2 {
+		t.Fatalf("expected 2 restarts while backing off, got %v", target.restarts)
+	}
+	step(10 * time.Second)
+	if len(target.restarts) != 3 {
+		t.Fatalf("expected 3 restarts, got %v", target.restarts)
+	}
+
+	// The backoff is reset after being healthy for the max backoff
+	target.status["kube-scheduler"] = runtime.ComponentStatusReady
+	step(time.Second)
+	step(40 * time.Second)
+	if got := s.states["kube-scheduler"].backoff; got != 0 {
+		t.Fatalf("expected the backoff to be reset, got %v", got)
+	}
+
+	// The probe failures restart the running component
+	unhealthy = true
+	step(time.Second)
+	step(time.Second)
+	if len(target.restarts) != 4 || target.restarts[3] != "kwok-controller" {
+		t.Fatalf("expected kwok-controller to be restarted, got %v", target.restarts)
+	}
+
+	restarts := target.disk.Status.Restarts
+	if len(restarts) != 2 {
+		t.Fatalf("expected 2 components in the restarts, got %v", restarts)
+	}
+	if restarts[0].Name != "kube-scheduler" || restarts[0].Count != 3 || restarts[0].Reason != "Crashed" {
+		t.Errorf("unexpected restart of kube-scheduler: %+v", restarts[0])
+	}
+	if restarts[1].Name != "kwok-controller" || restarts[1].Count != 1 || restarts[1].Reason != "Unhealthy: connection refused" {
+		t.Errorf("unexpected restart of kwok-controller: %+v", restarts[1])
+	}
+	if !restarts[1].LastRestartTime.Time.Equal(now) {
+		t.Errorf("unexpected last restart time of kwok-controller: %v", restarts[1].LastRestartTime)
+	}
+	if target.saved != 4 {
+		t.Errorf("expected the config to be saved 4 times, got %d", target.saved)
+	}
+}
+
+func TestSupervisorStopped(t *testing.T) {
+	target := &fakeTarget{
+		disk: &internalversion.KwokctlConfiguration{
+			Components: []internalversion.Component{
+				{Name: "kube-scheduler"},
+			},
+		},
+		status: map[string]runtime.ComponentStatus{
+			"kube-scheduler": runtime.ComponentStatusStopped,
+		},
+	}
+	s := NewSupervisor(target, Config{})
+
+	ctx := context.Background()
+	for i := 0; i != 3; i++ {
+		err := s.check(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(target.restarts) != 0 {
+		t.Fatalf("expected the stopped component not to be restarted, got %v", target.restarts)
+	}
+	if target.saved != 0 {
+		t.Errorf("expected the config not to be saved, got %d", target.saved)
+	}
+}
+
+func TestSupervisorReload(t *testing.T) {
+	target := &fakeTarget{
+		disk: &internalversion.KwokctlConfiguration{
+			Components: []internalversion.Component{
+				{Name: "kube-scheduler"},
+			},
+		},
+		status: map[string]runtime.ComponentStatus{
+			"kube-scheduler":  runtime.ComponentStatusReady,
+			"kwok-controller": runtime.ComponentStatusCrashed,
+		},
+	}
+	s := NewSupervisor(target, Config{})
+
+	ctx := context.Background()
+	err := s.check(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Changed by another kwokctl command while supervised
+	target.disk.Components = append(target.disk.Components, internalversion.Component{Name: "kwok-controller"})
+	target.disk.Status.Version = "v0.7.0"
+
+	err = s.check(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(target.restarts) != 1 || target.restarts[0] != "kwok-controller" {
+		t.Fatalf("expected the new component to be restarted, got %v", target.restarts)
+	}
+	if len(target.disk.Components) != 2 || target.disk.Status.Version != "v0.7.0" {
+		t.Errorf("expected the changes on disk to be kept, got %+v", target.disk)
+	}
+	if len(target.disk.Status.Restarts) != 1 || target.disk.Status.Restarts[0].Name != "kwok-controller" {
+		t.Errorf("unexpected restarts: %+v", target.disk.Status.Restarts)
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+	"sigs.k8s.io/kwok/pkg/utils/file"
+	"sigs.k8s.io/kwok/pkg/utils/gotpl"
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+// MetadataName is the name of the file that declares the parameters and the initial scale of a template
+const MetadataName = "template.yaml"
+
+// The delimiters of the parameters in a template,
+// they differ from {{ and }} so that the templates of the configurations, e.g. of a Stage, are left as they are.
+const (
+	leftDelim  = "${{"
+	rightDelim = "}}"
+)
+
+// Metadata is the content of the MetadataName file, it is not rendered
+type Metadata struct {
+	// Parameters are the default parameters of the template
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+	// Scale is the list of resources to scale once the cluster is created
+	Scale []Scale `json:"scale,omitempty"`
+}
+
+// Scale is a resource to scale once the cluster is created
+type Scale struct {
+	// Resource is the name of the KwokctlResource, e.g. node or pod
+	Resource string `json:"resource"`
+	// Name is the name prefix of the created objects, defaults to the resource
+	Name string `json:"name,omitempty"`
+	// Namespace is the namespace of the created objects
+	Namespace string `json:"namespace,omitempty"`
+	// Replicas is the number of objects, a string is rendered with the parameters, e.g. "${{ .nodes }}"
+	Replicas intstr.IntOrString `json:"replicas"`
+	// Params are the parameters of the KwokctlResource, each is rendered with the parameters
+	Params []string `json:"params,omitempty"`
+}
+
+// ScaleStep is a rendered Scale
+type ScaleStep struct {
+	Resource  string
+	Name      string
+	Namespace string
+	Replicas  int
+	Params    []string
+}
+
+// Template is a rendered cluster template
+type Template struct {
+	// Objects are the configurations of the template
+	Objects []config.InternalObject
+	// Scale is the list of resources to scale once the cluster is created
+	Scale []ScaleStep
+}
+
+// Render renders the template in the directory or tarball src with the parameters,
+// the params update the default parameters, either as key=value or as expressions the same as for kwokctl scale.
+func Render(ctx context.Context, src string, params []string) (*Template, error) {
+	files, err := readFiles(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", src, err)
+	}
+
+	var meta Metadata
+	if data, ok := files[MetadataName]; ok {
+		err = yaml.Unmarshal(data, &meta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s of template %s: %w", MetadataName, src, err)
+		}
+		delete(files, MetadataName)
+	}
+	if len(meta.Parameters) == 0 {
+		meta.Parameters = json.RawMessage("{}")
+	}
+	exprs := make([]string, 0, len(params))
+	for _, p := range params {
+		exprs = append(exprs, paramExpression(p))
+	}
+	parameters, err := expression.NewParameters(ctx, meta.Parameters, exprs)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := gotpl.NewRendererWithDelims(nil, leftDelim, rightDelim)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := bytes.NewBuffer(nil)
+	for _, name := range names {
+		data, err := renderer.ToText(string(files[name]), parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s of template %s: %w", name, src, err)
+		}
+		_, _ = buf.WriteString("\n---\n")
+		_, _ = buf.Write(data)
+	}
+	objs, err := config.LoadFromReader(ctx, buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template %s: %w", src, err)
+	}
+
+	tpl := &Template{
+		Objects: objs,
+		Scale:   make([]ScaleStep, 0, len(meta.Scale)),
+	}
+	for _, s := range meta.Scale {
+		step, err := renderScale(renderer, s, parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render scale of %s of template %s: %w", s.Resource, src, err)
+		}
+		tpl.Scale = append(tpl.Scale, step)
+	}
+	return tpl, nil
+}
+
+// paramExpression converts the key=value param to the expression that sets the parameter,
+// e.g. nodes=500 to .["nodes"] = 500, the expressions are returned as they are.
+// The value is taken as a string unless it is valid JSON, and a dotted key sets a nested parameter.
+func paramExpression(p string) string {
+	if strings.HasPrefix(strings.TrimSpace(p), ".") {
+		return p
+	}
+	key, value, ok := strings.Cut(p, "=")
+	if !ok {
+		return p
+	}
+
+	var path strings.Builder
+	_, _ = path.WriteString(".")
+	for _, k := range strings.Split(strings.TrimSpace(key), ".") {
+		quoted, _ := json.Marshal(k)
+		_, _ = path.WriteString("[")
+		_, _ = path.Write(quoted)
+		_, _ = path.WriteString("]")
+	}
+
+	if !json.Valid([]byte(value)) {
+		quoted, _ := json.Marshal(value)
+		value = string(quoted)
+	}
+	return path.String() + " = " + value
+}
+
+func renderScale(renderer gotpl.Renderer, s Scale, parameters any) (ScaleStep, error) {
+	step := ScaleStep{
+		Resource:  s.Resource,
+		Name:      s.Name,
+		Namespace: s.Namespace,
+		Replicas:  s.Replicas.IntValue(),
+	}
+	if step.Name == "" {
+		step.Name = s.Resource
+	}
+
+	if s.Replicas.Type == intstr.String {
+		data, err := renderer.ToText(s.Replicas.StrVal, parameters)
+		if err != nil {
+			return ScaleStep{}, err
+		}
+		step.Replicas, err = strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return ScaleStep{}, fmt.Errorf("invalid replicas: %w", err)
+		}
+	}
+
+	for _, p := range s.Params {
+		data, err := renderer.ToText(p, parameters)
+		if err != nil {
+			return ScaleStep{}, err
+		}
+		step.Params = append(step.Params, string(data))
+	}
+	return step, nil
+}
+
+// readFiles returns the YAML and JSON files of the directory or the tarball, keyed by their relative path
+func readFiles(src string) (map[string][]byte, error) {
+	stat, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	if stat.IsDir() {
+		err = filepath.WalkDir(src, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !isConfigFile(p) {
+				return nil
+			}
+			rel, err := filepath.Rel(src, p)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			files[filepath.ToSlash(rel)] = data
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return files, nil
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	r, err := file.Decompress(src, f)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg || !isConfigFile(hdr.Name) {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimPrefix(filepath.ToSlash(filepath.Clean(hdr.Name)), "./")] = data
+	}
+	return files, nil
+}
+
+func isConfigFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	nodefast "sigs.k8s.io/kwok/kustomize/stage/node/fast"
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/config"
+	"sigs.k8s.io/kwok/pkg/utils/expression"
+)
+
+var testFiles = map[string]string{
+	MetadataName: `
+parameters:
+  nodes: 10
+  gpu: 8
+scale:
+- resource: node
+  replicas: "${{ .nodes }}"
+  params:
+  - '.allocatable."nvidia.com/gpu" = "${{ .gpu }}"'
+- resource: pod
+  namespace: default
+  replicas: 2
+`,
+	"cluster.yaml": `
+kind: KwokctlConfiguration
+apiVersion: config.kwok.x-k8s.io/v1alpha1
+options:
+  heartbeatFactor: ${{ .gpu }}
+`,
+	"stages/node.yaml": `
+kind: Stage
+apiVersion: kwok.x-k8s.io/v1alpha1
+metadata:
+  name: node-gpu-${{ .gpu }}
+spec:
+  resourceRef:
+    apiGroup: v1
+    kind: Node
+  next:
+    statusTemplate: |
+      allocatable:
+        nvidia.com/gpu: ${{ .gpu }}
+      nodeInfo:
+        machineID: {{ .metadata.name | Quote }}
+`,
+	"stages/node-initialize.yaml": nodefast.DefaultNodeInit,
+	"README.md":                   `{{ not rendered`,
+}
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range testFiles {
+		p := filepath.Join(dir, "dir", filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tarball := filepath.Join(dir, "template.tar")
+	f, err := os.Create(tarball)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(f)
+	for name, content := range testFiles {
+		err = tw.WriteHeader(&tar.Header{Name: name, Mode: 0640, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tw.Write([]byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	wantScale := []ScaleStep{
+		{
+			Resource: "node",
+			Name:     "node",
+			Replicas: 500,
+			Params:   []string{`.allocatable."nvidia.com/gpu" = "4"`},
+		},
+		{
+			Resource:  "pod",
+			Name:      "pod",
+			Namespace: "default",
+			Replicas:  2,
+		},
+	}
+
+	for _, src := range []string{filepath.Join(dir, "dir"), tarball} {
+		t.Run(filepath.Base(src), func(t *testing.T) {
+			tpl, err := Render(context.Background(), src, []string{"nodes=500", ".gpu = 4"})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(tpl.Scale, wantScale) {
+				t.Errorf("Render() scale = %+v, want %+v", tpl.Scale, wantScale)
+			}
+
+			confs := config.FilterWithType[*internalversion.KwokctlConfiguration](tpl.Objects)
+			if len(confs) != 1 || confs[0].Options.HeartbeatFactor != 4 {
+				t.Errorf("Render() KwokctlConfiguration = %+v, want heartbeatFactor 4", confs)
+			}
+
+			stages := config.FilterWithType[*internalversion.Stage](tpl.Objects)
+			if len(stages) != 2 {
+				t.Fatalf("Render() Stage = %+v, want 2 stages", stages)
+			}
+			// The files are rendered in the order of their names
+			if stages[1].Name != "node-gpu-4" {
+				t.Errorf("Render() Stage = %+v, want node-gpu-4", stages[1])
+			}
+			wantStatusTemplate := "allocatable:\n  nvidia.com/gpu: 4\nnodeInfo:\n  machineID: {{ .metadata.name | Quote }}\n"
+			if got := stages[1].Spec.Next.Patches; len(got) != 1 || got[0].Template != wantStatusTemplate {
+				t.Errorf("Render() Stage patches = %+v, want statusTemplate %q", got, wantStatusTemplate)
+			}
+
+			// The templates of a real Stage are left as they are
+			want, err := config.UnmarshalWithType[*internalversion.Stage](nodefast.DefaultNodeInit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(stages[0], want) {
+				t.Errorf("Render() Stage = %+v, want %+v", stages[0], want)
+			}
+		})
+	}
+}
+
+func Test_paramExpression(t *testing.T) {
+	tests := []struct {
+		param string
+		want  string
+	}{
+		{param: ".nodes = 500", want: ".nodes = 500"},
+		{param: "nodes=500", want: `.["nodes"] = 500`},
+		{param: "name=big", want: `.["name"] = "big"`},
+		{param: `name="big"`, want: `.["name"] = "big"`},
+		{param: "gpu.count=8", want: `.["gpu"]["count"] = 8`},
+		{param: "enabled=true", want: `.["enabled"] = true`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.param, func(t *testing.T) {
+			if got := paramExpression(tt.param); got != tt.want {
+				t.Errorf("paramExpression() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	got, err := expression.NewParameters(context.Background(), []byte(`{"gpu":{"count":1}}`), []string{paramExpression("gpu.count=8")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != "map[gpu:map[count:8]]" {
+		t.Errorf("NewParameters() = %v, want gpu.count 8", got)
+	}
+}
@@ -34,6 +34,13 @@ const (
 	unixSecondName  = "UnixSecond"
 
 	quantityName = "Quantity"
+
+	// RandKeyName is the name of the variable used as the key of Rand(),
+	// Rand() is expanded to Rand(randKey, randPurpose), so the random numbers are reproducible for the same key.
+	RandKeyName = "randKey"
+	// RandPurposeName is the name of the variable used as the purpose of Rand(),
+	// the random numbers of different purposes are independent of each other.
+	RandPurposeName = "randPurpose"
 )
 
 var (
@@ -17,12 +17,16 @@ limitations under the License.
 package cel
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common"
+	"github.com/google/cel-go/common/ast"
 	"github.com/google/cel-go/common/types"
 	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter"
 	"github.com/wzshiming/easycel"
 )
 
@@ -53,6 +57,12 @@ func NewEnvironment(conf EnvironmentConfig) (*Environment, error) {
 			return nil, fmt.Errorf("failed to register type %T: %w", typ, err)
 		}
 	}
+	for _, name := range []string{RandKeyName, RandPurposeName} {
+		err := registry.RegisterVariable(name, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to register variable %s: %w", name, err)
+		}
+	}
 	for name, val := range conf.Vars {
 		err := registry.RegisterVariable(name, val)
 		if err != nil {
@@ -75,7 +85,10 @@ func NewEnvironment(conf EnvironmentConfig) (*Environment, error) {
 			}
 		}
 	}
-	env, err := easycel.NewEnvironment(cel.Lib(registry))
+	env, err := easycel.NewEnvironment(
+		cel.Lib(registry),
+		cel.Macros(randMacro),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
@@ -103,16 +116,59 @@ func (e *Environment) Compile(src string) (cel.Program, error) {
 		return program, nil
 	}
 
-	program, err := e.env.Program(src)
+	p, err := e.env.Program(src)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compile expression: %w", err)
 	}
+	program := defaultVarsProgram{p}
 
 	e.cacheProgram[src] = program
 
 	return program, nil
 }
 
+// randMacro expands Rand() to Rand(randKey, randPurpose)
+var randMacro = cel.GlobalMacro(mathRandName, 0, func(eh cel.MacroExprFactory, target ast.Expr, args []ast.Expr) (ast.Expr, *common.Error) {
+	return eh.NewCall(mathRandName, eh.NewIdent(RandKeyName), eh.NewIdent(RandPurposeName)), nil
+})
+
+// defaultVars holds the values of the variables that are not required to be provided
+var defaultVars, _ = interpreter.NewActivation(map[string]any{
+	RandKeyName:     "",
+	RandPurposeName: "",
+})
+
+// defaultVarsProgram is a program that fills in the default variables
+type defaultVarsProgram struct {
+	cel.Program
+}
+
+func (p defaultVarsProgram) withDefaultVars(input any) (any, error) {
+	vars, err := interpreter.NewActivation(input)
+	if err != nil {
+		return nil, err
+	}
+	return interpreter.NewHierarchicalActivation(defaultVars, vars), nil
+}
+
+// Eval implements the cel.Program interface.
+func (p defaultVarsProgram) Eval(input any) (ref.Val, *cel.EvalDetails, error) {
+	vars, err := p.withDefaultVars(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.Program.Eval(vars)
+}
+
+// ContextEval implements the cel.Program interface.
+func (p defaultVarsProgram) ContextEval(ctx context.Context, input any) (ref.Val, *cel.EvalDetails, error) {
+	vars, err := p.withDefaultVars(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.Program.ContextEval(ctx, vars)
+}
+
 // AsFloat64 returns the float64 value of a ref.Val
 func AsFloat64(refVal ref.Val) (float64, error) {
 	switch v := refVal.(type) {
@@ -17,14 +17,16 @@ limitations under the License.
 package cel
 
 import (
-	"math/rand"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/utils/clock"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 )
 
 func timeNow() time.Time {
-	return time.Now()
+	return clock.Now()
 }
 
 func unixSecond(t time.Time) float64 {
@@ -36,10 +38,9 @@ type sinceResource interface {
 }
 
 func sinceSecond[T sinceResource](t T) float64 {
-	return time.Since(t.GetCreationTimestamp().Time).Seconds()
+	return clock.Since(t.GetCreationTimestamp().Time).Seconds()
 }
 
-func mathRand() float64 {
-	//nolint: gosec
-	return rand.Float64()
+func mathRand(key, purpose string) float64 {
+	return rand.ForKey(key, rand.Purpose(purpose)).Float64()
 }
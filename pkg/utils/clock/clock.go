@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"sync/atomic"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// Clock is the clock interface used by the controllers.
+type Clock = clock.WithTicker
+
+type holder struct {
+	Clock
+}
+
+var defaultClock atomic.Pointer[holder]
+
+func init() {
+	defaultClock.Store(&holder{clock.RealClock{}})
+}
+
+// SetScale sets the scale of the default clock.
+// If the scale is greater than 0 and not 1, the durations of the default clock run faster than the real time by the scale.
+func SetScale(scale float64) {
+	if scale <= 0 || scale == 1 {
+		defaultClock.Store(&holder{clock.RealClock{}})
+		return
+	}
+	defaultClock.Store(&holder{NewScaled(scale)})
+}
+
+// Default returns the default clock.
+func Default() Clock {
+	return defaultClock.Load().Clock
+}
+
+// Now returns the current time of the default clock, which is always the real time.
+func Now() time.Time {
+	return Default().Now()
+}
+
+// Since returns the time elapsed since t of the default clock, which is scaled.
+func Since(t time.Time) time.Duration {
+	return Default().Since(t)
+}
+
+// Scaled is a clock whose durations run faster than the real time by the scale.
+// Now returns the real time, so the timestamps keep comparable with the ones of the apiserver,
+// while the elapsed time is multiplied by the scale and the durations passed to it are divided by the scale when waiting.
+type Scaled struct {
+	real  clock.RealClock
+	scale float64
+}
+
+var (
+	_ clock.WithTicker           = (*Scaled)(nil)
+	_ clock.WithDelayedExecution = (*Scaled)(nil)
+)
+
+// NewScaled returns a clock whose durations run faster than the real time by the scale.
+func NewScaled(scale float64) *Scaled {
+	return &Scaled{
+		scale: scale,
+	}
+}
+
+// toReal converts a duration of the scaled clock to the real duration.
+func (c *Scaled) toReal(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / c.scale)
+}
+
+// Now returns the current real time.
+func (c *Scaled) Now() time.Time {
+	return c.real.Now()
+}
+
+// Since returns the real time elapsed since t multiplied by the scale.
+func (c *Scaled) Since(t time.Time) time.Duration {
+	return time.Duration(float64(c.real.Since(t)) * c.scale)
+}
+
+// After waits for the scaled duration to elapse and then sends the current real time on the returned channel.
+func (c *Scaled) After(d time.Duration) <-chan time.Time {
+	return c.real.After(c.toReal(d))
+}
+
+// NewTimer returns a timer that fires after the scaled duration.
+func (c *Scaled) NewTimer(d time.Duration) clock.Timer {
+	return &scaledTimer{
+		Timer: c.real.NewTimer(c.toReal(d)),
+		clock: c,
+	}
+}
+
+// AfterFunc calls f after the scaled duration in its own goroutine.
+func (c *Scaled) AfterFunc(d time.Duration, f func()) clock.Timer {
+	return &scaledTimer{
+		Timer: c.real.AfterFunc(c.toReal(d), f),
+		clock: c,
+	}
+}
+
+// Tick returns a channel that ticks every scaled duration.
+func (c *Scaled) Tick(d time.Duration) <-chan time.Time {
+	return c.real.Tick(c.toReal(d))
+}
+
+// NewTicker returns a ticker that ticks every scaled duration.
+func (c *Scaled) NewTicker(d time.Duration) clock.Ticker {
+	return c.real.NewTicker(c.toReal(d))
+}
+
+// Sleep pauses for the scaled duration.
+func (c *Scaled) Sleep(d time.Duration) {
+	c.real.Sleep(c.toReal(d))
+}
+
+type scaledTimer struct {
+	clock.Timer
+	clock *Scaled
+}
+
+// Reset changes the timer to expire after the scaled duration.
+func (t *scaledTimer) Reset(d time.Duration) bool {
+	return t.Timer.Reset(t.clock.toReal(d))
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScaled(t *testing.T) {
+	c := NewScaled(1000)
+
+	start := time.Now()
+	now := c.Now()
+	c.Sleep(2 * time.Second)
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("expected the real elapsed time to be scaled down, got %v", elapsed)
+	}
+	if since := c.Since(now); since < 2*time.Second {
+		t.Errorf("expected the scaled elapsed time to be at least 2s, got %v", since)
+	}
+
+	start = time.Now()
+	<-c.After(2 * time.Second)
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("expected the real waiting time to be scaled down, got %v", elapsed)
+	}
+}
+
+func TestScaledSince(t *testing.T) {
+	c := NewScaled(60)
+
+	// t is after the clock is created, e.g. a pod created after kwok started
+	time.Sleep(100 * time.Millisecond)
+	created := time.Now()
+	time.Sleep(100 * time.Millisecond)
+
+	since := c.Since(created)
+	if since < 6*time.Second || since > 30*time.Second {
+		t.Errorf("expected the elapsed time since t to be scaled by 60, got %v", since)
+	}
+
+	if d := time.Since(c.Now()); d < 0 || d > time.Second {
+		t.Errorf("expected Now to return the real time, got %v off", d)
+	}
+}
+
+func TestSetScale(t *testing.T) {
+	defer SetScale(1)
+
+	SetScale(1)
+	if _, ok := Default().(*Scaled); ok {
+		t.Errorf("expected the real clock for scale 1")
+	}
+
+	SetScale(60)
+	if _, ok := Default().(*Scaled); !ok {
+		t.Errorf("expected the scaled clock for scale 60")
+	}
+}
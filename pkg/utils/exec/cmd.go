@@ -50,3 +50,21 @@ func KillProcess(pid int) error {
 func IsRunning(pid int) bool {
 	return isRunning(pid)
 }
+
+// SuspendProcess suspends the process with the given pid until it is resumed.
+func SuspendProcess(pid int) error {
+	err := suspendProcess(pid)
+	if err != nil {
+		return fmt.Errorf("suspend process %d: %w", pid, err)
+	}
+	return nil
+}
+
+// ResumeProcess resumes the process with the given pid that was suspended.
+func ResumeProcess(pid int) error {
+	err := resumeProcess(pid)
+	if err != nil {
+		return fmt.Errorf("resume process %d: %w", pid, err)
+	}
+	return nil
+}
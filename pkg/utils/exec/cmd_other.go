@@ -50,6 +50,22 @@ func isRunning(pid int) bool {
 	return err == nil
 }
 
+func suspendProcess(pid int) error {
+	return signalProcess(pid, syscall.SIGSTOP)
+}
+
+func resumeProcess(pid int) error {
+	return signalProcess(pid, syscall.SIGCONT)
+}
+
+func signalProcess(pid int, sig syscall.Signal) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Signal(sig)
+}
+
 func setUser(cmd *exec.Cmd, uid, gid *int64) error {
 	if uid == nil && gid == nil {
 		return nil
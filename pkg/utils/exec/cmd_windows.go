@@ -49,6 +49,14 @@ func isRunning(pid int) bool {
 	return err == nil
 }
 
+func suspendProcess(pid int) error {
+	return fmt.Errorf("suspending process is not supported in windows")
+}
+
+func resumeProcess(pid int) error {
+	return fmt.Errorf("resuming process is not supported in windows")
+}
+
 func setUser(cmd *exec.Cmd, uid, gid *int64) error {
 	if uid == nil && gid == nil {
 		return nil
@@ -110,6 +110,12 @@ func DownloadWithCache(ctx context.Context, cacheDir, src, dest string, mode fs.
 	return nil
 }
 
+// Download downloads the src file into the cache directory and returns the path of the cached file.
+// If the src is a local path, it is returned as is.
+func Download(ctx context.Context, cacheDir, src string, mode fs.FileMode, quiet bool) (string, error) {
+	return getCacheOrDownload(ctx, cacheDir, src, mode, quiet)
+}
+
 func getCachePath(cacheDir, src string) (string, error) {
 	u, err := url.Parse(src)
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/kwok/pkg/consts"
+	"sigs.k8s.io/kwok/pkg/utils/clock"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
 	"sigs.k8s.io/kwok/pkg/utils/yaml"
 )
@@ -54,7 +55,7 @@ var (
 			return strconv.Quote(string(data))
 		},
 		"Now": func() string {
-			return time.Now().Format(time.RFC3339Nano)
+			return clock.Now().Format(time.RFC3339Nano)
 		},
 		"StartTime": func() string {
 			return startTime
@@ -44,6 +44,8 @@ type renderer struct {
 	cache      maps.SyncMap[string, *template.Template]
 	bufferPool *pools.Pool[*bytes.Buffer]
 	funcMap    template.FuncMap
+	leftDelim  string
+	rightDelim string
 }
 
 // NewRenderer creates a new renderer.
@@ -56,12 +58,22 @@ func NewRenderer(funcMap FuncMap) Renderer {
 	}
 }
 
+// NewRendererWithDelims creates a new renderer with the action delimiters instead of {{ and }},
+// so that the text can contain the templates of other renderers.
+func NewRendererWithDelims(funcMap FuncMap, left, right string) Renderer {
+	r := NewRenderer(funcMap).(*renderer)
+	r.leftDelim = left
+	r.rightDelim = right
+	return r
+}
+
 func (r *renderer) render(buf *bytes.Buffer, text string, original interface{}) error {
 	text = strings.TrimSpace(text)
 	temp, ok := r.cache.Load(text)
 	if !ok {
 		var err error
 		temp, err = template.New("_").
+			Delims(r.leftDelim, r.rightDelim).
 			Funcs(genericFuncs).
 			Funcs(defaultFuncs).
 			Funcs(r.funcMap).
@@ -90,3 +90,17 @@ func TestRenderToJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderWithDelims(t *testing.T) {
+	r := NewRendererWithDelims(nil, "${{", "}}")
+	got, err := r.ToText(`name: ${{ .k }}
+template: '{{ .metadata.name }}'`, map[string]interface{}{"k": "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `name: v1
+template: '{{ .metadata.name }}'`
+	if string(got) != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
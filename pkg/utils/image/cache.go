@@ -0,0 +1,31 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"sigs.k8s.io/kwok/pkg/utils/path"
+)
+
+// TarballPath returns the path of the tarball of the image pulled into the cache directory.
+func TarballPath(cacheDir, image string) string {
+	return path.Join(cacheDir, "tarball", image+".tar")
+}
+
+// BundledTarballPath returns the path of the tarball of the image loaded from a bundle into the cache directory.
+func BundledTarballPath(cacheDir, image string) string {
+	return path.Join(cacheDir, "bundle", image+".tar")
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/common/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/cel"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
+)
+
+const (
+	celSelfName = "self"
+	celNodeName = "node"
+	celPodName  = "pod"
+)
+
+// celEnvironment returns the environment used to compile the CEL expressions of the stage selector
+var celEnvironment = sync.OnceValues(func() (*cel.Environment, error) {
+	return cel.NewEnvironment(cel.EnvironmentConfig{
+		Types:       cel.DefaultTypes,
+		Conversions: cel.DefaultConversions,
+		Funcs:       cel.DefaultFuncs,
+		Methods:     cel.FuncsToMethods(cel.DefaultFuncs),
+		Vars: map[string]any{
+			celSelfName: map[string]any{},
+			celNodeName: corev1.Node{},
+			celPodName:  corev1.Pod{},
+		},
+	})
+})
+
+var (
+	podRef  = internalversion.StageResourceRef{APIGroup: "v1", Kind: "Pod"}
+	nodeRef = internalversion.StageResourceRef{APIGroup: "v1", Kind: "Node"}
+)
+
+// celActivation holds the variables of the resource for evaluating CEL expressions,
+// the typed resource is only converted when it is used.
+type celActivation struct {
+	self any
+	vars map[string]any
+}
+
+// Vars returns the variables of the resource
+func (a *celActivation) Vars(ref internalversion.StageResourceRef) (map[string]any, error) {
+	if a.vars != nil {
+		return a.vars, nil
+	}
+
+	vars := map[string]any{
+		celSelfName:         a.self,
+		cel.RandKeyName:     uidOf(a.self),
+		cel.RandPurposeName: string(rand.PurposeStageExpression),
+	}
+	switch ref {
+	case nodeRef:
+		node, err := toTyped[corev1.Node](a.self)
+		if err != nil {
+			return nil, err
+		}
+		vars[celNodeName] = node
+	case podRef:
+		pod, err := toTyped[corev1.Pod](a.self)
+		if err != nil {
+			return nil, err
+		}
+		vars[celPodName] = pod
+	}
+	a.vars = vars
+	return vars, nil
+}
+
+func toTyped[T any](obj any) (*T, error) {
+	u, ok := obj.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", obj)
+	}
+	var out T
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(u, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// celMatches returns true if the CEL program evaluates to true
+func celMatches(ctx context.Context, program cel.Program, vars map[string]any) (bool, error) {
+	refVal, _, err := program.ContextEval(ctx, vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate cel: %w", err)
+	}
+	b, ok := refVal.(types.Bool)
+	if !ok {
+		return false, fmt.Errorf("cel result is not a bool: %T", refVal)
+	}
+	return bool(b), nil
+}
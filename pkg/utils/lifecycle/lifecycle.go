@@ -19,14 +19,15 @@ package lifecycle
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+	"sigs.k8s.io/kwok/pkg/utils/cel"
 	"sigs.k8s.io/kwok/pkg/utils/expression"
 	"sigs.k8s.io/kwok/pkg/utils/format"
+	"sigs.k8s.io/kwok/pkg/utils/rand"
 )
 
 // NewLifecycle returns a new Lifecycle.
@@ -48,10 +49,11 @@ func NewLifecycle(stages []*internalversion.Stage) (Lifecycle, error) {
 // Lifecycle is a list of lifecycle stage.
 type Lifecycle []*Stage
 
-func (s Lifecycle) match(label, annotation labels.Set, data interface{}) ([]*Stage, error) {
+func (s Lifecycle) match(ctx context.Context, label, annotation labels.Set, data interface{}) ([]*Stage, error) {
 	out := []*Stage{}
+	activation := &celActivation{self: data}
 	for _, stage := range s {
-		ok, err := stage.match(label, annotation, data)
+		ok, err := stage.match(ctx, label, annotation, data, activation)
 		if err != nil {
 			return nil, err
 		}
@@ -68,7 +70,7 @@ func (s Lifecycle) ListAllPossible(ctx context.Context, label, annotation labels
 	if err != nil {
 		return nil, err
 	}
-	stages, err := s.match(label, annotation, data)
+	stages, err := s.match(ctx, label, annotation, data)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +129,7 @@ func (s Lifecycle) Match(ctx context.Context, label, annotation labels.Set, data
 	if err != nil {
 		return nil, err
 	}
-	stages, err := s.match(label, annotation, data)
+	stages, err := s.match(ctx, label, annotation, data)
 	if err != nil {
 		return nil, err
 	}
@@ -152,15 +154,14 @@ func (s Lifecycle) Match(ctx context.Context, label, annotation labels.Set, data
 		}
 	}
 
+	r := rand.ForKey(uidOf(data), rand.PurposeWeight)
 	if countError == len(stages) {
-		//nolint:gosec
-		return stages[rand.Intn(len(stages))], nil
+		return stages[r.Intn(len(stages))], nil
 	}
 
 	if totalWeights == 0 {
 		if countError == 0 {
-			//nolint:gosec
-			return stages[rand.Intn(len(stages))], nil
+			return stages[r.Intn(len(stages))], nil
 		}
 
 		stagesWithWeights := make([]*Stage, 0, len(stages))
@@ -171,13 +172,11 @@ func (s Lifecycle) Match(ctx context.Context, label, annotation labels.Set, data
 			stagesWithWeights = append(stagesWithWeights, stage)
 		}
 
-		//nolint:gosec
-		off := rand.Intn(len(stagesWithWeights))
+		off := r.Intn(len(stagesWithWeights))
 		return stagesWithWeights[off], nil
 	}
 
-	//nolint:gosec
-	off := rand.Int63n(totalWeights)
+	off := r.Int63n(totalWeights)
 	for i, stage := range stages {
 		if weights[i] <= 0 {
 			continue
@@ -193,7 +192,8 @@ func (s Lifecycle) Match(ctx context.Context, label, annotation labels.Set, data
 // NewStage returns a new Stage.
 func NewStage(s *internalversion.Stage) (*Stage, error) {
 	stage := &Stage{
-		name: s.Name,
+		name:        s.Name,
+		resourceRef: s.Spec.ResourceRef,
 	}
 	selector := s.Spec.Selector
 	if selector == nil {
@@ -215,6 +215,19 @@ func NewStage(s *internalversion.Stage) (*Stage, error) {
 			stage.matchExpressions = append(stage.matchExpressions, requirement)
 		}
 	}
+	if selector.MatchCEL != nil {
+		env, err := celEnvironment()
+		if err != nil {
+			return nil, err
+		}
+		for _, src := range selector.MatchCEL {
+			program, err := env.Compile(src)
+			if err != nil {
+				return nil, fmt.Errorf("match cel %q: %w", src, err)
+			}
+			stage.matchCEL = append(stage.matchCEL, program)
+		}
+	}
 
 	stage.next = &s.Spec.Next
 	if delay := s.Spec.Delay; delay != nil {
@@ -262,6 +275,7 @@ func NewStage(s *internalversion.Stage) (*Stage, error) {
 	stage.weight = weightGetter
 
 	stage.immediateNextStage = s.Spec.ImmediateNextStage
+	stage.retryPolicy = s.Spec.RetryPolicy
 
 	return stage, nil
 }
@@ -269,9 +283,11 @@ func NewStage(s *internalversion.Stage) (*Stage, error) {
 // Stage is a resource lifecycle stage manager
 type Stage struct {
 	name             string
+	resourceRef      internalversion.StageResourceRef
 	matchLabels      labels.Selector
 	matchAnnotations labels.Selector
 	matchExpressions []*expression.Requirement
+	matchCEL         []cel.Program
 
 	weight expression.IntGetter
 	next   *internalversion.StageNext
@@ -280,9 +296,11 @@ type Stage struct {
 	jitterDuration expression.DurationGetter
 
 	immediateNextStage bool
+
+	retryPolicy *internalversion.StageRetryPolicy
 }
 
-func (s *Stage) match(label, annotation labels.Set, jsonStandard interface{}) (bool, error) {
+func (s *Stage) match(ctx context.Context, label, annotation labels.Set, jsonStandard interface{}, activation *celActivation) (bool, error) {
 	if s.matchLabels != nil {
 		if !s.matchLabels.Matches(label) {
 			return false, nil
@@ -296,7 +314,23 @@ func (s *Stage) match(label, annotation labels.Set, jsonStandard interface{}) (b
 
 	if s.matchExpressions != nil {
 		for _, requirement := range s.matchExpressions {
-			ok, err := requirement.Matches(context.Background(), jsonStandard)
+			ok, err := requirement.Matches(ctx, jsonStandard)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				return false, nil
+			}
+		}
+	}
+
+	if s.matchCEL != nil {
+		vars, err := activation.Vars(s.resourceRef)
+		if err != nil {
+			return false, err
+		}
+		for _, program := range s.matchCEL {
+			ok, err := celMatches(ctx, program, vars)
 			if err != nil {
 				return false, err
 			}
@@ -333,8 +367,7 @@ func (s *Stage) Delay(ctx context.Context, v interface{}, now time.Time) (time.D
 		return jitterDuration, true
 	}
 
-	//nolint:gosec
-	duration += time.Duration(rand.Int63n(int64(jitterDuration - duration)))
+	duration += time.Duration(rand.ForKey(uidOf(v), rand.PurposeDelay).Int63n(int64(jitterDuration - duration)))
 
 	return duration, true
 }
@@ -381,7 +414,26 @@ func (s *Stage) ImmediateNextStage() bool {
 	return s.immediateNextStage
 }
 
+// RetryPolicy returns the retry policy of the stage, nil means the default retry behavior.
+func (s *Stage) RetryPolicy() *internalversion.StageRetryPolicy {
+	return s.retryPolicy
+}
+
 // Weight returns the weight of the stage.
 func (s *Stage) Weight(ctx context.Context, v interface{}) (int64, bool) {
 	return s.weight.Get(ctx, v)
 }
+
+// uidOf returns the uid of the resource
+func uidOf(v interface{}) string {
+	data, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	metadata, ok := data["metadata"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	uid, _ := metadata["uid"].(string)
+	return uid
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kwok/pkg/apis/internalversion"
+)
+
+func TestLifecycleMatchCEL(t *testing.T) {
+	newPod := func(memory string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+				Labels: map[string]string{
+					"app": "test",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "container",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse(memory),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		matchCEL []string
+		pod      *corev1.Pod
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name:     "typed pod matched",
+			matchCEL: []string{`pod.spec.containers[0].resources.limits["memory"] > Quantity("1Gi")`},
+			pod:      newPod("2Gi"),
+			want:     true,
+		},
+		{
+			name:     "typed pod not matched",
+			matchCEL: []string{`pod.spec.containers[0].resources.limits["memory"] > Quantity("1Gi")`},
+			pod:      newPod("512Mi"),
+			want:     false,
+		},
+		{
+			name:     "self matched",
+			matchCEL: []string{`self.spec.containers.exists(c, c.name == "container")`},
+			pod:      newPod("512Mi"),
+			want:     true,
+		},
+		{
+			name: "anded",
+			matchCEL: []string{
+				`self.metadata.labels["app"] == "test"`,
+				`pod.metadata.name == "other"`,
+			},
+			pod:  newPod("512Mi"),
+			want: false,
+		},
+		{
+			name:     "not bool",
+			matchCEL: []string{`pod.metadata.name`},
+			pod:      newPod("512Mi"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc, err := NewLifecycle([]*internalversion.Stage{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "stage",
+					},
+					Spec: internalversion.StageSpec{
+						ResourceRef: internalversion.StageResourceRef{
+							APIGroup: "v1",
+							Kind:     "Pod",
+						},
+						Selector: &internalversion.StageSelector{
+							MatchCEL: tt.matchCEL,
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewLifecycle() error = %v", err)
+			}
+
+			stage, err := lc.Match(context.Background(), tt.pod.Labels, tt.pod.Annotations, tt.pod)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Match() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := stage != nil; got != tt.want {
+				t.Errorf("Match() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
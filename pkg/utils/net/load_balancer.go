@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/kwok/pkg/log"
+)
+
+// LoadBalancer is a TCP load balancer which forwards connections to the backends in round-robin.
+// A backend that cannot be dialed is skipped, so connections fail over to the rest of the backends.
+type LoadBalancer struct {
+	backends []string
+	next     atomic.Uint64
+	dialer   net.Dialer
+}
+
+// NewLoadBalancer creates a new LoadBalancer for the backends.
+func NewLoadBalancer(backends []string) (*LoadBalancer, error) {
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("no backends for load balancer")
+	}
+	return &LoadBalancer{
+		backends: backends,
+		dialer: net.Dialer{
+			Timeout: 5 * time.Second,
+		},
+	}, nil
+}
+
+// Serve accepts connections on the listener and forwards them until the context is canceled.
+func (l *LoadBalancer) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go l.handle(ctx, conn)
+	}
+}
+
+func (l *LoadBalancer) handle(ctx context.Context, conn net.Conn) {
+	logger := log.FromContext(ctx)
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	target, err := l.dial(ctx)
+	if err != nil {
+		logger.Warn("No backend available", "err", err)
+		return
+	}
+	defer func() {
+		_ = target.Close()
+	}()
+
+	// Unlike Tunnel, both connections are closed as soon as either side is done,
+	// a client must not hang on a backend that has gone away.
+	errCh := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(target, conn)
+		errCh <- err
+	}()
+	go func() {
+		_, err := io.Copy(conn, target)
+		errCh <- err
+	}()
+	select {
+	case <-ctx.Done():
+	case err = <-errCh:
+		if err != nil {
+			logger.Debug("Failed tunneling", "backend", target.RemoteAddr(), "err", err)
+		}
+	}
+}
+
+func (l *LoadBalancer) dial(ctx context.Context) (net.Conn, error) {
+	n := uint64(len(l.backends))
+	start := l.next.Add(1)
+	errs := make([]error, 0, n)
+	for i := uint64(0); i != n; i++ {
+		backend := l.backends[(start+i)%n]
+		conn, err := l.dialer.DialContext(ctx, "tcp", backend)
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package net
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+)
+
+func startEchoServer(t *testing.T, name string) string {
+	listener, err := net.Listen("tcp", LocalAddress+":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte(name))
+			_ = conn.Close()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func unusedAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", LocalAddress+":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+	return addr
+}
+
+func TestLoadBalancer(t *testing.T) {
+	backends := []string{
+		startEchoServer(t, "a"),
+		unusedAddress(t),
+		startEchoServer(t, "b"),
+	}
+
+	lb, err := NewLoadBalancer(backends)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", LocalAddress+":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = lb.Serve(ctx, listener)
+	}()
+
+	got := map[string]int{}
+	for i := 0; i != 6; i++ {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(conn)
+		_ = conn.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[string(data)]++
+	}
+
+	if len(got) != 2 || got["a"] == 0 || got["b"] == 0 {
+		t.Errorf("expected connections to be balanced to the live backends, got %v", got)
+	}
+}
+
+func TestNewLoadBalancerWithoutBackends(t *testing.T) {
+	_, err := NewLoadBalancer(nil)
+	if err == nil {
+		t.Errorf("expected error for no backends")
+	}
+}
@@ -24,7 +24,7 @@ import (
 
 // Tunnel create tunnels for two streams.
 func Tunnel(ctx context.Context, c1, c2 io.ReadWriter, buf1, buf2 []byte) error {
-	errCh := make(chan error)
+	errCh := make(chan error, 2)
 	go func() {
 		_, err := io.CopyBuffer(c2, c1, buf1)
 		errCh <- err
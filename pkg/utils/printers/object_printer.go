@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+
+	"sigs.k8s.io/kwok/pkg/utils/yaml"
+)
+
+const jsonpathPrefix = "jsonpath="
+
+// IsObjectOutput returns true if the output format is one of json, yaml or jsonpath=<template>.
+func IsObjectOutput(output string) bool {
+	return output == "json" || output == "yaml" || strings.HasPrefix(output, jsonpathPrefix)
+}
+
+// PrintObject writes the object in the output format, which is one of json, yaml or jsonpath=<template>.
+// The fields are named by their json tags in all formats.
+func PrintObject(w io.Writer, output string, obj any) error {
+	switch {
+	case output == "json":
+		data, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case output == "yaml":
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case strings.HasPrefix(output, jsonpathPrefix):
+		return printJSONPath(w, strings.TrimPrefix(output, jsonpathPrefix), obj)
+	}
+	return fmt.Errorf("unknown output format %q", output)
+}
+
+func printJSONPath(w io.Writer, template string, obj any) error {
+	j := jsonpath.New("output")
+	err := j.Parse(template)
+	if err != nil {
+		return fmt.Errorf("failed to parse jsonpath %q: %w", template, err)
+	}
+
+	// The template refers to the fields by their json tags
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var value any
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	err = j.Execute(w, value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w)
+	return err
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printers
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintObject(t *testing.T) {
+	type item struct {
+		Name  string `json:"name"`
+		Ports []int  `json:"ports,omitempty"`
+	}
+	obj := []item{
+		{Name: "foo", Ports: []int{80, 443}},
+		{Name: "bar"},
+	}
+
+	tests := []struct {
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{
+			output: "json",
+			want: "[\n" +
+				"  {\n" +
+				"    \"name\": \"foo\",\n" +
+				"    \"ports\": [\n" +
+				"      80,\n" +
+				"      443\n" +
+				"    ]\n" +
+				"  },\n" +
+				"  {\n" +
+				"    \"name\": \"bar\"\n" +
+				"  }\n" +
+				"]\n",
+		},
+		{
+			output: "yaml",
+			want: "- name: foo\n" +
+				"  ports:\n" +
+				"  - 80\n" +
+				"  - 443\n" +
+				"- name: bar\n",
+		},
+		{
+			output: "jsonpath={[*].name}",
+			want:   "foo bar\n",
+		},
+		{
+			output: "jsonpath={[0].ports[1]}",
+			want:   "443\n",
+		},
+		{
+			output:  "jsonpath={[",
+			wantErr: true,
+		},
+		{
+			output:  "wide",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			if got := IsObjectOutput(tt.output); got == (tt.output == "wide") {
+				t.Errorf("IsObjectOutput() = %v", got)
+			}
+
+			buf := &bytes.Buffer{}
+			err := PrintObject(buf, tt.output, obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PrintObject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("PrintObject() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progressbar
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Counter writes a progress bar of a count to out.
+type Counter struct {
+	mut     sync.Mutex
+	current uint64
+
+	name  string
+	total uint64
+
+	startTime      time.Time
+	lastUpdateTime time.Time
+	out            *os.File
+}
+
+// NewCounter returns a new counter that writes a progress bar to out,
+// it returns nil if out is not a terminal and all methods of a nil counter are no-op.
+func NewCounter(name string, total uint64) *Counter {
+	out := os.Stderr
+	if !term.IsTerminal(int(out.Fd())) {
+		return nil
+	}
+
+	return &Counter{
+		name:      name,
+		total:     total,
+		startTime: time.Now(),
+		out:       out,
+	}
+}
+
+// Add adds n to the current count.
+func (c *Counter) Add(n uint64) {
+	if c == nil {
+		return
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.current += n
+
+	if c.current != c.total && time.Since(c.lastUpdateTime) < time.Second {
+		return
+	}
+	c.lastUpdateTime = time.Now()
+
+	termWidth, _, _ := term.GetSize(int(c.out.Fd()))
+	if termWidth > 0 {
+		info := formatCountProgress(c.name, uint64(termWidth), c.current, c.total, time.Since(c.startTime))
+		if c.current == c.total {
+			_, _ = c.out.WriteString("\r" + info + "\n")
+		} else {
+			_, _ = c.out.WriteString("\r" + info)
+		}
+	}
+}
@@ -14,5 +14,5 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package progressbar provides a transport that writes a progress bar to out.
+// Package progressbar provides a transport and a counter that write a progress bar to out.
 package progressbar
@@ -27,16 +27,24 @@ import (
 )
 
 func formatProgress(name string, width uint64, current uint64, total uint64, elapsed time.Duration) string {
+	return formatProgressWithUnit(name, width, current, total, elapsed, "size", formatBytes)
+}
+
+func formatCountProgress(name string, width uint64, current uint64, total uint64, elapsed time.Duration) string {
+	return formatProgressWithUnit(name, width, current, total, elapsed, "count", formatCount)
+}
+
+func formatProgressWithUnit(name string, width uint64, current uint64, total uint64, elapsed time.Duration, unit string, format func(uint64) string) string {
 	var per string
 	if current == total {
-		per = fmt.Sprintf("size=%s", formatBytes(total))
+		per = fmt.Sprintf("%s=%s", unit, format(total))
 	} else {
-		per = fmt.Sprintf("size=%s/%s", formatBytes(current), formatBytes(total))
+		per = fmt.Sprintf("%s=%s/%s", unit, format(current), format(total))
 	}
 
 	e := elapsed.Truncate(time.Second)
 	if e != 0 || current != total {
-		widePer := fmt.Sprintf("%s speed=%s elapsed=%s", per, formatSpeed(current, elapsed), e)
+		widePer := fmt.Sprintf("%s speed=%s elapsed=%s", per, formatSpeed(current, elapsed, format), e)
 		if len(widePer) < int(width)-1 {
 			per = widePer
 		}
@@ -96,18 +104,22 @@ var (
 	negativeColor = ctc.Negative.String()
 )
 
-func formatSpeed(size uint64, elapsed time.Duration) string {
+func formatSpeed(size uint64, elapsed time.Duration, format func(uint64) string) string {
 	second := elapsed.Seconds()
 	if second < 1 {
-		return formatBytes(size) + "/s"
+		return format(size) + "/s"
 	}
-	return formatBytes(uint64(float64(size)/second)) + "/s"
+	return format(uint64(float64(size)/second)) + "/s"
 }
 
 var (
 	binaryAbbrs = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB"}
 )
 
+func formatCount(count uint64) string {
+	return fmt.Sprintf("%d", count)
+}
+
 func formatBytes(size uint64) string {
 	s, u := getSizeAndUnit(float64(size), 1024, binaryAbbrs)
 	return fmt.Sprintf("%.0f%s", s, u)
@@ -26,10 +26,17 @@ import (
 // Clock is an interface that returns the current time and duration since a given time.
 type Clock interface {
 	Now() time.Time
+	Since(t time.Time) time.Duration
 	After(d time.Duration) <-chan time.Time
 	Sleep(d time.Duration)
 }
 
+// timeline returns the time elapsed on the clock from the start,
+// which follows the durations of the clock even if they are scaled against its Now.
+func timeline(clock Clock, start time.Time) time.Time {
+	return start.Add(clock.Since(start))
+}
+
 // DelayingQueue is a generic queue interface that supports adding items after
 type DelayingQueue[T comparable] interface {
 	Queue[T]
@@ -48,6 +55,7 @@ type delayingQueue[T comparable] struct {
 	Queue[T]
 
 	clock Clock
+	start time.Time
 
 	heap *heap.Heap[int64, T]
 
@@ -60,6 +68,7 @@ func NewDelayingQueue[T comparable](clock Clock) DelayingQueue[T] {
 	q := &delayingQueue[T]{
 		Queue:  NewQueue[T](),
 		clock:  clock,
+		start:  clock.Now(),
 		heap:   heap.NewHeap[int64, T](),
 		signal: make(chan struct{}, 1),
 	}
@@ -73,7 +82,7 @@ func (q *delayingQueue[T]) AddAfter(item T, duration time.Duration) {
 		return
 	}
 
-	k := q.clock.Now().Add(duration).UnixNano()
+	k := timeline(q.clock, q.start).Add(duration).UnixNano()
 
 	q.mut.Lock()
 	q.heap.Push(k, item)
@@ -113,7 +122,7 @@ func (q *delayingQueue[T]) next() (t T, ok bool, wait *time.Duration) {
 		return t, false, nil
 	}
 
-	waitDuration := time.Unix(0, k).Sub(q.clock.Now())
+	waitDuration := time.Unix(0, k).Sub(timeline(q.clock, q.start))
 	if waitDuration > 0 {
 		return v, false, &waitDuration
 	}
@@ -45,6 +45,7 @@ type weightDelayingQueue[T comparable] struct {
 	orders []int
 
 	clock Clock
+	start time.Time
 
 	heap  *heap.Heap[int64, T]
 	heaps map[int]*heap.Heap[int64, T]
@@ -58,6 +59,7 @@ func NewWeightDelayingQueue[T comparable](clock Clock) WeightDelayingQueue[T] {
 	q := &weightDelayingQueue[T]{
 		WeightQueue: NewWeightQueue[T](),
 		clock:       clock,
+		start:       clock.Now(),
 		heap:        heap.NewHeap[int64, T](),
 		heaps:       map[int]*heap.Heap[int64, T]{},
 		signal:      make(chan struct{}, 1),
@@ -75,7 +77,7 @@ func (q *weightDelayingQueue[T]) AddWeightAfter(item T, weight int, duration tim
 		q.WeightQueue.AddWeight(item, weight)
 		return
 	}
-	k := q.clock.Now().Add(duration).UnixNano()
+	k := timeline(q.clock, q.start).Add(duration).UnixNano()
 
 	q.mut.Lock()
 	if weight <= 0 {
@@ -119,7 +121,7 @@ func (q *weightDelayingQueue[T]) next() (t T, weight int, ok bool, wait *time.Du
 
 	var waitDuration *time.Duration
 
-	now := q.clock.Now()
+	now := timeline(q.clock, q.start)
 	// The highest weight queue is always checked first
 	if k, v, ok := q.heap.Peek(); ok {
 		d := time.Unix(0, k).Sub(now)
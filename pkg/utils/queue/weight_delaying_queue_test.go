@@ -21,6 +21,8 @@ import (
 	"time"
 
 	fakeclock "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/kwok/pkg/utils/clock"
 )
 
 func TestAddWeightAfterWithPositiveDuration(t *testing.T) {
@@ -85,3 +87,22 @@ func TestCancelWithNonExistingItem(t *testing.T) {
 		t.Fatal("expected false, got true")
 	}
 }
+
+func TestAddWeightAfterWithScaledClock(t *testing.T) {
+	pdq := NewWeightDelayingQueue[string](clock.NewScaled(60))
+
+	start := time.Now()
+	pdq.AddWeightAfter("foo", 1, 6*time.Second)
+
+	done := make(chan struct{})
+	timer := time.AfterFunc(5*time.Second, func() { close(done) })
+	defer timer.Stop()
+
+	item, ok := pdq.GetOrWaitWithDone(done)
+	if !ok || item != "foo" {
+		t.Fatalf("expected to get foo, got %q", item)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the delay to be scaled down, got %v", elapsed)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rand
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+
+	"sigs.k8s.io/kwok/pkg/utils/maps"
+)
+
+// Rand is a source of random numbers.
+type Rand interface {
+	// Int63n returns a non-negative random number in [0,n).
+	Int63n(n int64) int64
+	// Intn returns a non-negative random number in [0,n).
+	Intn(n int) int
+	// Float64 returns a random number in [0.0,1.0).
+	Float64() float64
+}
+
+// Purpose distinguishes the random number streams of the same key,
+// the streams of different purposes are independent of each other,
+// so consuming random numbers for one purpose does not shift the others.
+type Purpose string
+
+// The purposes of the random number streams.
+const (
+	// PurposeWeight is used to select a stage by weights.
+	PurposeWeight Purpose = "weight"
+	// PurposeDelay is used to jitter the delay of a stage.
+	PurposeDelay Purpose = "delay"
+	// PurposeStageExpression is used by Rand() in the expressions of a stage.
+	PurposeStageExpression Purpose = "stage-expression"
+	// PurposeMetricExpression is used by Rand() in the expressions of a metric.
+	PurposeMetricExpression Purpose = "metric-expression"
+)
+
+// state is the seed and the random number generators created from it.
+type state struct {
+	seed  int64
+	rands maps.SyncMap[string, *streams]
+}
+
+// streams is the random number generators of a key, one per purpose.
+type streams struct {
+	rands maps.SyncMap[Purpose, *lockedRand]
+}
+
+var current atomic.Pointer[state]
+
+// Seed sets the global seed.
+// After it is set, the random numbers returned by ForKey are reproducible for the same key and purpose.
+func Seed(s int64) {
+	current.Store(&state{seed: s})
+}
+
+// ForKey returns the random number generator of the key for the purpose, the key is usually the UID of a resource.
+// If the global seed is not set or the key is empty, the global random number generator is returned.
+func ForKey(key string, purpose Purpose) Rand {
+	st := current.Load()
+	if st == nil || key == "" {
+		return globalRand{}
+	}
+	ss, ok := st.rands.Load(key)
+	if !ok {
+		ss, _ = st.rands.LoadOrStore(key, &streams{})
+	}
+	r, ok := ss.rands.Load(purpose)
+	if ok {
+		return r
+	}
+	r, _ = ss.rands.LoadOrStore(purpose, newLockedRand(st.seed, key, purpose))
+	return r
+}
+
+// Release releases the random number generators of the key.
+func Release(key string) {
+	st := current.Load()
+	if st == nil {
+		return
+	}
+	st.rands.Delete(key)
+}
+
+// New returns a random number generator seeded by the seed, which is safe for concurrent use,
+// it is independent of the global seed.
+func New(seed int64) Rand {
+	//nolint:gosec
+	return &lockedRand{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func newLockedRand(seed int64, key string, purpose Purpose) *lockedRand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(purpose))
+	//nolint:gosec
+	return &lockedRand{
+		rand: rand.New(rand.NewSource(seed ^ int64(h.Sum64()))),
+	}
+}
+
+type lockedRand struct {
+	mut  sync.Mutex
+	rand *rand.Rand
+}
+
+func (r *lockedRand) Int63n(n int64) int64 {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.rand.Int63n(n)
+}
+
+func (r *lockedRand) Intn(n int) int {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.rand.Intn(n)
+}
+
+func (r *lockedRand) Float64() float64 {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.rand.Float64()
+}
+
+type globalRand struct{}
+
+func (globalRand) Int63n(n int64) int64 {
+	//nolint:gosec
+	return rand.Int63n(n)
+}
+
+func (globalRand) Intn(n int) int {
+	//nolint:gosec
+	return rand.Intn(n)
+}
+
+func (globalRand) Float64() float64 {
+	//nolint:gosec
+	return rand.Float64()
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rand
+
+import (
+	"sync"
+	"testing"
+)
+
+func sequence(key string) []int64 {
+	return sequenceFor(key, PurposeWeight)
+}
+
+func sequenceFor(key string, purpose Purpose) []int64 {
+	r := ForKey(key, purpose)
+	out := make([]int64, 0, 8)
+	for i := 0; i < 8; i++ {
+		out = append(out, r.Int63n(1<<30))
+	}
+	return out
+}
+
+func equal(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestForKey(t *testing.T) {
+	Seed(1)
+	a := sequence("uid-a")
+	b := sequence("uid-b")
+	if equal(a, b) {
+		t.Errorf("expected different sequences for different keys, got %v", a)
+	}
+
+	Release("uid-a")
+	if got := sequence("uid-a"); !equal(a, got) {
+		t.Errorf("expected the same sequence after release, want %v, got %v", a, got)
+	}
+
+	Seed(1)
+	if got := sequence("uid-b"); !equal(b, got) {
+		t.Errorf("expected the same sequence for the same seed, want %v, got %v", b, got)
+	}
+
+	Seed(2)
+	if got := sequence("uid-b"); equal(b, got) {
+		t.Errorf("expected different sequences for different seeds, got %v", got)
+	}
+}
+
+func TestForKeyPurpose(t *testing.T) {
+	Seed(1)
+	weight := sequenceFor("uid-a", PurposeWeight)
+	Release("uid-a")
+
+	// Consuming another purpose of the same key must not shift the stream.
+	_ = sequenceFor("uid-a", PurposeMetricExpression)
+	if got := sequenceFor("uid-a", PurposeWeight); !equal(weight, got) {
+		t.Errorf("expected the same sequence regardless of other purposes, want %v, got %v", weight, got)
+	}
+
+	if got := sequenceFor("uid-a", PurposeDelay); equal(weight, got) {
+		t.Errorf("expected different sequences for different purposes, got %v", got)
+	}
+}
+
+func TestSeedConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			Seed(int64(i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = sequence("uid-a")
+			Release("uid-a")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNew(t *testing.T) {
+	next := func(r Rand) []int64 {
+		out := make([]int64, 0, 8)
+		for i := 0; i < 8; i++ {
+			out = append(out, r.Int63n(1<<30))
+		}
+		return out
+	}
+
+	a := next(New(1))
+	if got := next(New(1)); !equal(a, got) {
+		t.Errorf("expected the same sequence for the same seed, want %v, got %v", a, got)
+	}
+	if got := next(New(2)); equal(a, got) {
+		t.Errorf("expected different sequences for different seeds, got %v", got)
+	}
+}
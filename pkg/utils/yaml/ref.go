@@ -22,8 +22,9 @@ import (
 
 // The following functions are exported for testing purposes only.
 var (
-	YAMLToJSON = yaml.YAMLToJSON
-	JSONToYAML = yaml.JSONToYAML
-	Unmarshal  = yaml.Unmarshal
-	Marshal    = yaml.Marshal
+	YAMLToJSON      = yaml.YAMLToJSON
+	JSONToYAML      = yaml.JSONToYAML
+	Unmarshal       = yaml.Unmarshal
+	UnmarshalStrict = yaml.UnmarshalStrict
+	Marshal         = yaml.Marshal
 )